@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/git"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// Environment variables GitHub Actions sets for the steps of an action.
+const (
+	actionInputPrefix = "INPUT_"
+	actionOutputEnv   = "GITHUB_OUTPUT"
+)
+
+// Author of the commits of the action, GitHub's bot account for Actions.
+const (
+	actionAuthorName  = "github-actions[bot]"
+	actionAuthorEmail = "41898282+github-actions[bot]@users.noreply.github.com"
+)
+
+// actionCmd represents the action command
+var actionCmd = &cobra.Command{
+	Use:   "action",
+	Short: "Run generate as a GitHub Action",
+	Long: `Run generate configured by the inputs of a GitHub Action, as the action.yml
+of this repository does.
+
+Every flag of generate is read from the INPUT_ environment variable GitHub
+Actions sets for the input of the same name, e.g. INPUT_WORKFLOWS for
+--workflows and INPUT_PAGES_DIR or INPUT_PAGES-DIR for --pages-dir. Empty
+inputs are ignored, so that the settings of the configuration file apply.
+
+With the commit input set to true, the regenerated documentation is committed
+with the commit-message input and pushed, leaving other changes of the
+workspace alone. The checkout needs credentials allowed to push, which
+actions/checkout keeps by default.
+
+The outputs are written to the file of GITHUB_OUTPUT:
+- path: the documentation file
+- changed: whether the documentation changed, or with --check is out of date
+- committed: whether the documentation was committed and pushed
+
+When workflow files fail to parse, the documentation of the others is still
+written and changed reports it, but nothing is committed.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := generateCmd.ParseFlags(actionInputArgs(generateCmd)); err != nil {
+			return commandError("Error running action", err)
+		}
+		if err := applyConfig(generateCmd); err != nil {
+			return commandError("Error running action", err)
+		}
+		if err := setupLogging(generateCmd); err != nil {
+			return commandError("Error running action", err)
+		}
+		commit, err := actionBoolInput("commit")
+		if err != nil {
+			return commandError("Error running action", err)
+		}
+		message := actionInput("commit-message")
+		if message == "" {
+			message = "Regenerate workflow documentation"
+		}
+
+		generateCmd.SetContext(cmd.Context())
+		generateErr := generateCmd.RunE(generateCmd, nil)
+
+		paths := actionPaths(generateCmd)
+		outputs := map[string]string{"path": paths[0], "changed": "false", "committed": "false"}
+		var codeErr *codeError
+		if errors.As(generateErr, &codeErr) && codeErr.Code == exitDrift {
+			outputs["changed"] = "true"
+		} else if generateErr == nil || (codeErr != nil && codeErr.Code == exitParseErrors) {
+			// Skipped files still leave the documentation of the others
+			// written, but the failing run does not commit it
+			changed, err := git.Changed(cmd.Context(), ".", paths)
+			if err != nil {
+				slog.Warn("Cannot tell whether the documentation changed", "error", err)
+			}
+			outputs["changed"] = strconv.FormatBool(changed)
+
+			if commit && changed && generateErr == nil {
+				committed, err := git.CommitPaths(cmd.Context(), ".", paths, message, actionAuthorName, actionAuthorEmail)
+				if err == nil && committed {
+					err = git.Push(cmd.Context(), ".")
+				}
+				if err != nil {
+					return commandError("Error committing documentation", err)
+				}
+				outputs["committed"] = strconv.FormatBool(committed)
+				if committed {
+					slog.Info("Successfully committed documentation", "paths", strings.Join(paths, ","))
+				}
+			}
+		}
+
+		if err := writeActionOutputs(outputs); err != nil {
+			return commandError("Error running action", err)
+		}
+		return generateErr
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(actionCmd)
+}
+
+// actionInput returns the value of the action input name, set in
+// INPUT_<NAME> by GitHub Actions, which keeps hyphens, or with hyphens
+// replaced by underscores as composite actions pass them on.
+func actionInput(name string) string {
+	upper := strings.ToUpper(name)
+	if value := os.Getenv(actionInputPrefix + upper); value != "" {
+		return strings.TrimSpace(value)
+	}
+	return strings.TrimSpace(os.Getenv(actionInputPrefix + strings.ReplaceAll(upper, "-", "_")))
+}
+
+// actionBoolInput returns the value of the boolean action input name, false
+// if it is empty.
+func actionBoolInput(name string) (bool, error) {
+	value := actionInput(name)
+	if value == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s input %q, expected true or false", name, value)
+	}
+	return b, nil
+}
+
+// actionInputArgs returns the command-line flags of cmd set by action
+// inputs.
+func actionInputArgs(cmd *cobra.Command) []string {
+	var args []string
+	// Merge the global flags into Flags, so that inputs can set them too
+	cmd.InheritedFlags()
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if value := actionInput(flag.Name); value != "" {
+			args = append(args, "--"+flag.Name+"="+value)
+		}
+	})
+	return args
+}
+
+// actionPaths returns the paths generate writes the documentation to with
+// the flags of cmd, the documentation file first.
+func actionPaths(cmd *cobra.Command) []string {
+	output, _ := cmd.Flags().GetString("output")
+	inject, _ := cmd.Flags().GetString("inject")
+	pagesDir, _ := cmd.Flags().GetString("pages-dir")
+	dirReadmes, _ := cmd.Flags().GetBool("readme-per-dir")
+	workflowDirs, _ := cmd.Flags().GetStringSlice("workflows")
+
+	paths := []string{output}
+	if inject != "" {
+		paths[0] = inject
+	}
+	if pagesDir != "" {
+		paths = append(paths, pagesDir)
+	}
+	if dirReadmes {
+		if len(workflowDirs) == 0 {
+			if dir, err := detectWorkflowsDir(); err == nil {
+				workflowDirs = []string{dir}
+			}
+		}
+		for _, dir := range workflowDirs {
+			paths = append(paths, ":(glob)"+filepath.ToSlash(filepath.Join(dir, "**", "README.md")))
+		}
+	}
+	return paths
+}
+
+// writeActionOutputs appends outputs to the file of GITHUB_OUTPUT, if set.
+func writeActionOutputs(outputs map[string]string) error {
+	path := os.Getenv(actionOutputEnv)
+	if path == "" {
+		slog.Debug("Not writing action outputs", "reason", actionOutputEnv+" is not set")
+		return nil
+	}
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("error writing action outputs: %v", err)
+	}
+	for _, name := range []string{"path", "changed", "committed"} {
+		fmt.Fprintf(file, "%s=%s\n", name, outputs[name])
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("error writing action outputs: %v", err)
+	}
+	return nil
+}
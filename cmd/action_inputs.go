@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/actions"
+	"github.com/spf13/cobra"
+)
+
+// actionInputsCmd represents the action-inputs command
+var actionInputsCmd = &cobra.Command{
+	Use:   "action-inputs",
+	Short: "Analyze how local composite actions use their inputs",
+	Long: `Analyze the inputs of every local composite action (action.yml or
+action.yaml) in a directory and report where each input is used: forwarded to
+a nested action, used by a run step, an environment variable, a condition, or
+an output.
+
+Declared inputs that are never used are flagged as unused, and inputs that
+are referenced but never declared are listed, to keep action contracts honest.
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		actionsDir, _ := cmd.Flags().GetString("actions")
+		output, _ := cmd.Flags().GetString("output")
+
+		found, err := actions.Scan(actionsDir)
+		if err != nil {
+			return commandError("Error analyzing action inputs", err)
+		}
+
+		err = writeOutput(actions.Render(found), output)
+		if err != nil {
+			return commandError("Error analyzing action inputs", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	actionInputsCmd.Flags().StringP("actions", "a", ".github/actions", "Directory containing local actions")
+	actionInputsCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(actionInputsCmd)
+}
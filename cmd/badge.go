@@ -0,0 +1,52 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+// badgeCmd represents the badge command
+var badgeCmd = &cobra.Command{
+	Use:   "badge",
+	Short: "Generate status badge snippets for GitHub Actions workflows",
+	Long: `Generate the markdown status badge snippet for every workflow in a directory.
+
+Badges use either GitHub's native status badge or shields.io. The output is a
+markdown table showing each badge alongside the snippet to embed it.
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		repoURL, _ := cmd.Flags().GetString("repo-url")
+		style, _ := cmd.Flags().GetString("style")
+		branch, _ := cmd.Flags().GetString("branch")
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error generating badges", err)
+		}
+
+		content, err := generate.GenerateBadges(workflows, repoURL, style, branch)
+		if err != nil {
+			return commandError("Error generating badges", err)
+		}
+
+		err = writeOutput(content, output)
+		if err != nil {
+			return commandError("Error generating badges", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	badgeCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	badgeCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	badgeCmd.Flags().StringP("output", "o", "", "Output file for the badges (defaults to stdout)")
+	badgeCmd.Flags().String("repo-url", "", "URL of the repository on GitHub, e.g. https://github.com/owner/repo")
+	badgeCmd.Flags().String("style", generate.BadgeStyleGitHub, "Badge style: github or shields")
+	badgeCmd.Flags().String("branch", "", "Branch whose status the badges report (defaults to the default branch)")
+	badgeCmd.MarkFlagRequired("repo-url")
+	rootCmd.AddCommand(badgeCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// cacheCmd represents the cache command
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the gha-docs cache",
+	Long: `gha-docs caches GitHub API responses and parsed workflows in the user cache
+directory, $XDG_CACHE_HOME/ghadoc or ~/.cache/ghadoc on Linux, so that
+repeated runs are fast and cheap on rate limits.
+
+API responses are used without asking the API for --cache-ttl, one minute by
+default, and afterwards only once a conditional request confirms they have
+not changed. Parsed workflows are keyed by the content of their files and
+never go stale. Use --no-cache to bypass the cache for one run.`,
+}
+
+// cacheClearCmd represents the cache clear command
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove the cached API responses and parsed workflows",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		apiDir, err := github.DefaultCacheDir()
+		if err != nil {
+			return commandError("Error clearing cache", err)
+		}
+		workflowsDir, err := generate.DefaultCacheDir()
+		if err != nil {
+			return commandError("Error clearing cache", err)
+		}
+
+		if err := (&github.Cache{Dir: apiDir}).Clear(); err != nil {
+			return commandError("Error clearing cache", err)
+		}
+		if err := (&generate.Cache{Dir: workflowsDir}).Clear(); err != nil {
+			return commandError("Error clearing cache", err)
+		}
+		slog.Info("Cleared cache", "api", apiDir, "workflows", workflowsDir)
+		return nil
+	},
+}
+
+func init() {
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// newWorkflowCache returns the cache of parsed workflows in the default cache
+// directory, or nil if --no-cache is set.
+func newWorkflowCache(cmd *cobra.Command) *generate.Cache {
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if noCache {
+		return nil
+	}
+	// Without a cache directory workflows are simply parsed every time
+	dir, err := generate.DefaultCacheDir()
+	if err != nil {
+		return nil
+	}
+	return &generate.Cache{Dir: dir}
+}
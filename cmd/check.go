@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+)
+
+// driftChecker compares the files generate --check would write with the
+// files on disk, in place of writing them.
+type driftChecker struct {
+	color bool // Whether to color the diffs
+
+	mu    sync.Mutex
+	stale []string // Files that differ or are missing
+}
+
+// check records path as stale unless the file at path has content, and
+// prints the diff of the file to content to stdout.
+func (c *driftChecker) check(path string, content []byte) {
+	existing, err := os.ReadFile(path)
+	if err == nil && bytes.Equal(existing, content) {
+		return
+	}
+	slog.Warn("File is out of date", "path", path)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.stale = append(c.stale, path)
+
+	lines := diff.Lines(string(existing), string(content))
+	header := fmt.Sprintf("--- %s\n+++ %s (generated)\n", path, path)
+	if c.color {
+		fmt.Print(colorDiff(header + lines))
+	} else {
+		fmt.Print(header + lines)
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// newClient returns a GitHub API client for apiURL authenticating with the
+// token from the environment, allowing --request-timeout for each request.
+// Responses are cached in the default cache directory for --cache-ttl unless
+// --no-cache is set.
+func newClient(cmd *cobra.Command, apiURL string) *github.Client {
+	client := github.NewClient(apiURL, github.TokenFromEnv())
+	if timeout, _ := cmd.Flags().GetDuration("request-timeout"); timeout > 0 {
+		client.HTTPClient.Timeout = timeout
+	}
+
+	noCache, _ := cmd.Flags().GetBool("no-cache")
+	if noCache {
+		return client
+	}
+	// Without a cache directory requests are simply not cached
+	if dir, err := github.DefaultCacheDir(); err == nil {
+		ttl, _ := cmd.Flags().GetDuration("cache-ttl")
+		client.Cache = &github.Cache{Dir: dir, TTL: ttl}
+	}
+	return client
+}
@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/codeowners"
+	"github.com/spf13/cobra"
+)
+
+// codeownersCmd represents the codeowners command
+var codeownersCmd = &cobra.Command{
+	Use:   "codeowners",
+	Short: "Report the owners of GitHub Actions workflows from CODEOWNERS",
+	Long: `Cross-reference the repository's CODEOWNERS file with the GitHub Actions
+workflows in a directory.
+
+The report contains a table with the owners of every workflow and the
+CODEOWNERS rule that assigns them, followed by the list of workflows without
+owners. As on GitHub, the last matching rule in CODEOWNERS takes precedence.
+
+The CODEOWNERS file is looked up in .github/, the repository root, and docs/
+unless --codeowners is given. Output is written to stdout unless an output
+file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		codeownersPath, _ := cmd.Flags().GetString("codeowners")
+		output, _ := cmd.Flags().GetString("output")
+
+		ownerships, err := codeowners.Check(cmd.Context(), workflowDir, codeownersPath)
+		if err != nil {
+			return commandError("Error checking workflow owners", err)
+		}
+
+		err = writeOutput(codeowners.Render(ownerships), output)
+		if err != nil {
+			return commandError("Error checking workflow owners", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	codeownersCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	codeownersCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	codeownersCmd.Flags().String("codeowners", "", "Path to the CODEOWNERS file (defaults to the repository's CODEOWNERS)")
+	codeownersCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(codeownersCmd)
+}
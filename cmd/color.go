@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Modes of --color.
+const (
+	colorAuto   = "auto"   // Color terminals unless NO_COLOR is set
+	colorAlways = "always" // Color even when redirected
+	colorNever  = "never"  // Never color
+)
+
+// ANSI escape sequences of the colors of diagnostics and diffs.
+const (
+	ansiReset  = "\x1b[0m"
+	ansiRed    = "\x1b[31m"
+	ansiGreen  = "\x1b[32m"
+	ansiYellow = "\x1b[33m"
+	ansiCyan   = "\x1b[36m"
+)
+
+// useColor reports whether output to file is colored with the --color mode
+// of cmd. In auto mode, only terminals are colored, and NO_COLOR or a dumb
+// terminal turn color off.
+func useColor(cmd *cobra.Command, file *os.File) (bool, error) {
+	mode, _ := cmd.Flags().GetString("color")
+	switch mode {
+	case colorAlways:
+		return true, nil
+	case colorNever:
+		return false, nil
+	case colorAuto:
+		if os.Getenv("NO_COLOR") != "" || os.Getenv("TERM") == "dumb" {
+			return false, nil
+		}
+		info, err := file.Stat()
+		return err == nil && info.Mode()&os.ModeCharDevice != 0, nil
+	default:
+		return false, fmt.Errorf("invalid color mode %q, expected %s, %s, or %s", mode, colorAuto, colorAlways, colorNever)
+	}
+}
+
+// colorize wraps text in color, unless color is empty.
+func colorize(text, color string) string {
+	if color == "" {
+		return text
+	}
+	return color + text + ansiReset
+}
+
+// colorLogWriter colors the lines of text logs by their level: errors red
+// and warnings yellow. The text handler writes every record with a single
+// call, so each call is one line.
+type colorLogWriter struct {
+	w io.Writer
+}
+
+func (c colorLogWriter) Write(p []byte) (int, error) {
+	var color string
+	switch {
+	case bytes.HasPrefix(p, []byte("level=ERROR")):
+		color = ansiRed
+	case bytes.HasPrefix(p, []byte("level=WARN")):
+		color = ansiYellow
+	default:
+		return c.w.Write(p)
+	}
+	line := bytes.TrimSuffix(p, []byte("\n"))
+	if _, err := io.WriteString(c.w, color+string(line)+ansiReset+"\n"); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// colorDiff colors the lines of a diff of diff.Lines: added lines green,
+// removed lines red, and skipped lines cyan.
+func colorDiff(lines string) string {
+	var sb strings.Builder
+	for _, line := range strings.SplitAfter(lines, "\n") {
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, "+"):
+			sb.WriteString(colorize(text, ansiGreen))
+		case strings.HasPrefix(text, "-"):
+			sb.WriteString(colorize(text, ansiRed))
+		case text == "@@":
+			sb.WriteString(colorize(text, ansiCyan))
+		default:
+			sb.WriteString(text)
+		}
+		if strings.HasSuffix(line, "\n") {
+			sb.WriteString("\n")
+		}
+	}
+	return sb.String()
+}
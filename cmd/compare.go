@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/compare"
+	"github.com/spf13/cobra"
+)
+
+// compareCmd represents the compare command
+var compareCmd = &cobra.Command{
+	Use:   "compare <base> <head>",
+	Short: "Compare the workflows of two directories or git refs",
+	Long: `Compare the GitHub Actions workflows of two sources and report the differences.
+
+Each source is either a directory (for example a checkout of another
+repository) or a "<ref>:<dir>" pair naming a directory of the current git
+repository at a branch, tag, or commit:
+
+  gha-docs compare main:.github/workflows feature:.github/workflows
+  gha-docs compare .github/workflows ../template-repo/.github/workflows
+
+The report is rendered either side-by-side as a markdown table or diff-style.
+Output is written to stdout unless an output file is specified.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		style, _ := cmd.Flags().GetString("style")
+
+		base, err := compare.Load(cmd.Context(), args[0])
+		if err != nil {
+			return commandError("Error comparing workflows", err)
+		}
+		head, err := compare.Load(cmd.Context(), args[1])
+		if err != nil {
+			return commandError("Error comparing workflows", err)
+		}
+
+		report, err := compare.Render(args[0], args[1], base, head, style)
+		if err != nil {
+			return commandError("Error comparing workflows", err)
+		}
+
+		err = writeOutput(report, output)
+		if err != nil {
+			return commandError("Error comparing workflows", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	compareCmd.Flags().StringP("output", "o", "", "Output file for the comparison report (defaults to stdout)")
+	compareCmd.Flags().String("style", compare.StyleSideBySide, "Report style: side-by-side or diff")
+	rootCmd.AddCommand(compareCmd)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/config"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+// completeWorkflowsDirs completes the directories of --workflows that contain
+// workflow files, or directories that do, such as .github. The flag takes a
+// comma-separated list, so only the last directory of the list is completed.
+func completeWorkflowsDirs(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	listed, toComplete := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i != -1 {
+		listed, toComplete = toComplete[:i+1], toComplete[i+1:]
+	}
+	scanOpts := completionScanOptions(cmd)
+
+	dir, prefix := filepath.Split(toComplete)
+	entries, err := os.ReadDir(filepath.Join(".", dir))
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	var dirs []string
+	for _, entry := range entries {
+		if !entry.IsDir() || !strings.HasPrefix(entry.Name(), prefix) {
+			continue
+		}
+		// Hidden directories only when asked for, except .github
+		if strings.HasPrefix(entry.Name(), ".") && prefix == "" && entry.Name() != ".github" {
+			continue
+		}
+		path := dir + entry.Name()
+		if hasWorkflowFiles(path, scanOpts, 2) {
+			dirs = append(dirs, listed+path+"/")
+		}
+	}
+	return dirs, cobra.ShellCompDirectiveNoSpace | cobra.ShellCompDirectiveNoFileComp
+}
+
+// hasWorkflowFiles reports whether dir, or one of its subdirectories up to
+// depth levels down, contains workflow files.
+func hasWorkflowFiles(dir string, scanOpts generate.ScanOptions, depth int) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && scanOpts.IsWorkflowFile(entry.Name()) {
+			return true
+		}
+	}
+	if depth == 0 {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && hasWorkflowFiles(filepath.Join(dir, entry.Name()), scanOpts, depth-1) {
+			return true
+		}
+	}
+	return false
+}
+
+// completeFormats completes --format with the registered renderers,
+// including those of plugins.
+func completeFormats(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	return generate.Formats(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeWorkflowFiles completes the workflow file argument of commands such
+// as parse with the workflow files of the directory generate is configured
+// to scan, or falls back to completing any file.
+func completeWorkflowFiles(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	scanOpts := completionScanOptions(cmd)
+
+	var files []string
+	for _, dir := range configuredWorkflowsDirs(cmd) {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			if entry.IsDir() || !scanOpts.IsWorkflowFile(entry.Name()) {
+				continue
+			}
+			if path := filepath.Join(dir, entry.Name()); strings.HasPrefix(path, toComplete) {
+				files = append(files, path)
+			}
+		}
+	}
+	if len(files) == 0 {
+		return nil, cobra.ShellCompDirectiveDefault
+	}
+	return files, cobra.ShellCompDirectiveNoFileComp
+}
+
+// configuredWorkflowsDirs returns the directories generate scans with the
+// configuration of cmd, from the environment or the configuration file, or
+// else the detected .github/workflows directory. Flags and errors are
+// ignored, since completion runs before either is checked.
+func configuredWorkflowsDirs(cmd *cobra.Command) []string {
+	cfg, _ := loadConfig(cmd)
+	value, err := config.Resolve(generateCmd.Flags(), commandPath(generateCmd), cfg, "workflows")
+	if dirs := splitFlagValue(value); err == nil && dirs[0] != "" {
+		return dirs
+	}
+	if dir, err := detectWorkflowsDir(); err == nil {
+		return []string{dir}
+	}
+	return []string{"."}
+}
+
+// completionScanOptions returns the options recognizing workflow files with
+// the extensions generate is configured with.
+func completionScanOptions(cmd *cobra.Command) generate.ScanOptions {
+	cfg, _ := loadConfig(cmd)
+	value, err := config.Resolve(generateCmd.Flags(), commandPath(generateCmd), cfg, "extensions")
+	if err != nil || value == "" {
+		return generate.ScanOptions{}
+	}
+	return generate.ScanOptions{Extensions: splitFlagValue(value)}
+}
+
+// splitFlagValue splits the value of a slice flag resolved by config.Resolve,
+// which is comma-separated, or for the default enclosed in brackets.
+func splitFlagValue(value string) []string {
+	return strings.Split(strings.TrimSuffix(strings.TrimPrefix(value, "["), "]"), ",")
+}
@@ -0,0 +1,389 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/droctothorpe/gha-docs/internal/config"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configEnv is the environment variable naming the configuration file.
+const configEnv = "GHADOC_CONFIG"
+
+// activeConfig is the configuration applied to the running command, with
+// the configuration file of its workflows directory merged in. It is nil
+// without a configuration file.
+var activeConfig *config.Config
+
+// loadConfig loads the configuration file given by --config or GHADOC_CONFIG,
+// or else the default configuration file if there is one. It returns nil
+// without a configuration file.
+func loadConfig(cmd *cobra.Command) (*config.Config, error) {
+	path, _ := cmd.Flags().GetString("config")
+	if path == "" {
+		path = os.Getenv(configEnv)
+	}
+	if path == "" {
+		path = config.Find()
+	}
+	if path == "" {
+		return nil, nil
+	}
+	return config.Load(path)
+}
+
+// effectiveConfig loads the configuration of cmd. For commands that scan a
+// single workflows directory, a configuration file in that directory is
+// merged over the root configuration file.
+func effectiveConfig(cmd *cobra.Command) (*config.Config, error) {
+	cfg, err := loadConfig(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.Flags().Lookup("workflows") != nil {
+		workflowsDir, err := config.Resolve(cmd.Flags(), commandPath(cmd), cfg, "workflows")
+		if err != nil {
+			return nil, err
+		}
+		// Several directories cannot each override the configuration
+		if strings.Contains(workflowsDir, ",") {
+			return cfg, nil
+		}
+		// Without a directory, generate documents the detected one, unless
+		// it fetches the workflows of a remote repository
+		if strings.Trim(workflowsDir, "[] ") == "" {
+			remote, err := resolveRemote(cmd, cfg)
+			if err != nil {
+				return nil, err
+			}
+			if remote {
+				return cfg, nil
+			}
+			workflowsDir, err = detectWorkflowsDir()
+			if err != nil {
+				// Reported by the command itself
+				return cfg, nil
+			}
+		}
+		if path := config.DirectoryFile(workflowsDir); path != "" {
+			override, err := config.Load(path)
+			if err != nil {
+				return nil, err
+			}
+			cfg = cfg.Merge(override)
+		}
+	}
+	return cfg, nil
+}
+
+// resolveRemote reports whether cmd reads the workflows of a remote
+// repository, given with --repo.
+func resolveRemote(cmd *cobra.Command, cfg *config.Config) (bool, error) {
+	if cmd.Flags().Lookup("repo") == nil {
+		return false, nil
+	}
+	repo, err := config.Resolve(cmd.Flags(), commandPath(cmd), cfg, "repo")
+	return repo != "", err
+}
+
+// applyConfig sets the flags of cmd that were not given on the command line
+// from the environment and the configuration file.
+func applyConfig(cmd *cobra.Command) error {
+	cfg, err := effectiveConfig(cmd)
+	if err != nil {
+		return err
+	}
+
+	activeConfig = cfg
+	return config.Apply(cmd.Flags(), commandPath(cmd), cfg)
+}
+
+// commandPath returns the names of cmd and its parents, without the root.
+func commandPath(cmd *cobra.Command) []string {
+	return strings.Fields(cmd.CommandPath())[1:]
+}
+
+// configSetting is a setting of the configuration file that a command reads
+// directly rather than through a flag, because it is too structured for one.
+type configSetting struct {
+	command string             // Path of the command reading the setting, e.g. "generate"
+	key     string             // Key of the setting
+	target  func() interface{} // Returns a pointer to decode the setting into
+}
+
+// configSettings lists the settings of the configuration file that are not
+// flags.
+var configSettings = []configSetting{
+	{"generate", "column-formats", func() interface{} { return new(map[string]generate.ColumnFormat) }},
+	{"generate", "targets", func() interface{} { return new([]generate.Target) }},
+}
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate and inspect the configuration",
+	Long: `Validate the configuration file and environment variables, and show the
+effective settings of a command, to debug why a setting does not take effect.
+
+Settings are taken from flags first, then from GHADOC_ environment variables,
+then from the configuration file (.ghadoc.yaml, or the file given by --config
+or GHADOC_CONFIG), merged with the .ghadoc.yaml of the workflows directory.`,
+	// Do not apply the configuration being inspected, which may be invalid
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		return startCommand(cmd)
+	},
+}
+
+// configValidateCmd represents the config validate command
+var configValidateCmd = &cobra.Command{
+	Use:   "validate [file...]",
+	Short: "Validate the configuration file and environment variables",
+	Long: `Check the configuration file, or the given files, for keys that are not a
+command section or a setting of the commands they apply to, and for values
+that are not valid for their flags. GHADOC_ environment variables that do
+not set a flag, or set it to an invalid value, are reported too.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var configs []*config.Config
+		for _, path := range args {
+			cfg, err := config.Load(path)
+			if err != nil {
+				return commandError("Error validating configuration", err)
+			}
+			configs = append(configs, cfg)
+		}
+		if len(args) == 0 {
+			cfg, err := loadConfig(cmd)
+			if err != nil {
+				return commandError("Error validating configuration", err)
+			}
+			if cfg == nil {
+				slog.Info("No configuration file found; checking the environment only")
+			}
+			configs = append(configs, cfg)
+		}
+
+		var problems []string
+		for _, cfg := range configs {
+			problems = append(problems, validateConfig(cfg)...)
+		}
+		if len(problems) > 0 {
+			for _, problem := range problems {
+				fmt.Println(problem)
+			}
+			return commandError("Error validating configuration", fmt.Errorf("%d problem(s) found", len(problems)))
+		}
+
+		for _, cfg := range configs {
+			if cfg != nil {
+				fmt.Println("Configuration file", cfg.Path, "is valid")
+			}
+		}
+		return nil
+	},
+}
+
+// configShowCmd represents the config show command
+var configShowCmd = &cobra.Command{
+	Use:   "show COMMAND [flags]",
+	Short: "Show the effective settings of a command",
+	Long: `Show the value every flag of a command takes and where it comes from: the
+command line, an environment variable, the configuration file, or the default.
+Pass the command and its flags as you would run them, e.g.
+
+  gha-docs config show generate -w .github/workflows --badges`,
+	DisableFlagParsing: true,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		target, flagArgs, err := rootCmd.Find(args)
+		if err != nil || target == rootCmd || target == cmd {
+			_ = cmd.Help()
+			return nil
+		}
+		if err := target.ParseFlags(flagArgs); err != nil {
+			return commandError("Error showing configuration", err)
+		}
+
+		cfg, err := effectiveConfig(target)
+		if err != nil {
+			return commandError("Error showing configuration", err)
+		}
+
+		path := commandPath(target)
+		sources := make(map[string]string)
+		target.Flags().VisitAll(func(flag *pflag.Flag) {
+			sources[flag.Name] = config.Source(target.Flags(), path, cfg, flag.Name)
+		})
+		if err := config.Apply(target.Flags(), path, cfg); err != nil {
+			return commandError("Error showing configuration", err)
+		}
+
+		writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(writer, "SETTING\tVALUE\tSOURCE")
+		target.Flags().VisitAll(func(flag *pflag.Flag) {
+			if flag.Name == "help" || flag.Name == "config" {
+				return
+			}
+			fmt.Fprintf(writer, "%s\t%s\t%s\n", flag.Name, flag.Value.String(), sources[flag.Name])
+		})
+		for _, setting := range configSettings {
+			if setting.command != strings.Join(path, " ") || cfg == nil {
+				continue
+			}
+			if value, ok := cfg.Value(path, setting.key); ok {
+				formatted, _ := json.Marshal(value)
+				fmt.Fprintf(writer, "%s\t%s\t%s\n", setting.key, formatted, cfg.Path)
+			}
+		}
+		writer.Flush()
+		return nil
+	},
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configShowCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// copyFlag adds a flag like flag to flags, of the same type but with its own
+// value, so that setting it leaves flag alone. Flags of other types than
+// those of the commands are copied as strings.
+func copyFlag(flags *pflag.FlagSet, flag *pflag.Flag) {
+	if flags.Lookup(flag.Name) != nil {
+		return
+	}
+	switch flag.Value.Type() {
+	case "bool":
+		flags.Bool(flag.Name, false, flag.Usage)
+	case "int":
+		flags.Int(flag.Name, 0, flag.Usage)
+	case "duration":
+		flags.Duration(flag.Name, 0, flag.Usage)
+	case "stringSlice":
+		flags.StringSlice(flag.Name, nil, flag.Usage)
+	case "stringToString":
+		flags.StringToString(flag.Name, nil, flag.Usage)
+	default:
+		flags.String(flag.Name, "", flag.Usage)
+	}
+	flags.Lookup(flag.Name).Changed = flag.Changed
+}
+
+// validateConfig returns the problems of cfg, which may be nil, and of the
+// GHADOC_ environment variables.
+func validateConfig(cfg *config.Config) []string {
+	var problems []string
+	if cfg != nil {
+		for _, key := range cfg.UnknownKeys(isConfigCommand, isConfigSetting) {
+			problems = append(problems, fmt.Sprintf("unknown key %s in %s", key, cfg.Path))
+		}
+		for _, setting := range configSettings {
+			if _, err := cfg.Decode(strings.Fields(setting.command), setting.key, setting.target()); err != nil {
+				problems = append(problems, err.Error())
+			}
+		}
+	}
+
+	// Apply the configuration to copies of the flags of every command to
+	// check the values, leaving the commands' own flags alone
+	seen := make(map[string]bool)
+	visitCommands(rootCmd, func(c *cobra.Command) bool {
+		flags := pflag.NewFlagSet(c.Name(), pflag.ContinueOnError)
+		c.LocalFlags().VisitAll(func(flag *pflag.Flag) { copyFlag(flags, flag) })
+		c.InheritedFlags().VisitAll(func(flag *pflag.Flag) { copyFlag(flags, flag) })
+		if err := config.Apply(flags, commandPath(c), cfg); err != nil && !seen[err.Error()] {
+			seen[err.Error()] = true
+			problems = append(problems, err.Error())
+		}
+		return false
+	})
+
+	var envNames []string
+	for _, entry := range os.Environ() {
+		name, _, _ := strings.Cut(entry, "=")
+		if strings.HasPrefix(name, config.EnvPrefix) && name != configEnv {
+			envNames = append(envNames, name)
+		}
+	}
+	sort.Strings(envNames)
+	for _, name := range envNames {
+		known := visitCommands(rootCmd, func(c *cobra.Command) bool {
+			found := false
+			c.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+				found = found || config.EnvName(flag.Name) == name
+			})
+			return found
+		})
+		if !known {
+			problems = append(problems, fmt.Sprintf("environment variable %s does not set any flag", name))
+		}
+	}
+
+	return problems
+}
+
+// visitCommands calls fn for cmd and its subcommands until fn returns true,
+// reporting whether it did.
+func visitCommands(cmd *cobra.Command, fn func(*cobra.Command) bool) bool {
+	if fn(cmd) {
+		return true
+	}
+	for _, child := range cmd.Commands() {
+		if visitCommands(child, fn) {
+			return true
+		}
+	}
+	return false
+}
+
+// findCommand returns the command at path, or nil if there is none.
+func findCommand(path []string) *cobra.Command {
+	cmd := rootCmd
+	for _, name := range path {
+		var next *cobra.Command
+		for _, child := range cmd.Commands() {
+			if child.Name() == name {
+				next = child
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		cmd = next
+	}
+	return cmd
+}
+
+// isConfigCommand reports whether path names a command, and thus a section
+// of the configuration file.
+func isConfigCommand(path []string) bool {
+	return findCommand(path) != nil
+}
+
+// isConfigSetting reports whether key is a setting of the command at path or
+// any of its subcommands, which the section of the command applies to.
+func isConfigSetting(path []string, key string) bool {
+	cmd := findCommand(path)
+	if cmd == nil {
+		return false
+	}
+	return visitCommands(cmd, func(c *cobra.Command) bool {
+		if key != "help" && key != "config" && (c.LocalFlags().Lookup(key) != nil || c.InheritedFlags().Lookup(key) != nil) {
+			return true
+		}
+		for _, setting := range configSettings {
+			if setting.key == key && setting.command == strings.Join(commandPath(c), " ") {
+				return true
+			}
+		}
+		return false
+	})
+}
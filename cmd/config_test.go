@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/config"
+)
+
+// TestEffectiveConfigDetectedWorkflowsDir tests that without --workflows, the
+// configuration file of the detected workflows directory is merged in
+func TestEffectiveConfigDetectedWorkflowsDir(t *testing.T) {
+	repo := t.TempDir()
+	workflowsDir := filepath.Join(repo, ".github", "workflows")
+	if err := os.MkdirAll(filepath.Join(repo, ".git"), 0755); err != nil {
+		t.Fatalf("Failed to create .git: %v", err)
+	}
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows directory: %v", err)
+	}
+	override := "generate:\n  output: docs/ci.md\n"
+	if err := os.WriteFile(filepath.Join(workflowsDir, ".ghadoc.yaml"), []byte(override), 0644); err != nil {
+		t.Fatalf("Failed to write .ghadoc.yaml: %v", err)
+	}
+
+	cwd, err := os.Getwd()
+	if err != nil {
+		t.Fatalf("Failed to get working directory: %v", err)
+	}
+	if err := os.Chdir(repo); err != nil {
+		t.Fatalf("Failed to change directory: %v", err)
+	}
+	t.Cleanup(func() { os.Chdir(cwd) })
+
+	if generateCmd.Flags().Changed("workflows") {
+		t.Fatal("Expected --workflows not to be given")
+	}
+	cfg, err := effectiveConfig(generateCmd)
+	if err != nil {
+		t.Fatalf("effectiveConfig failed: %v", err)
+	}
+	if cfg == nil {
+		t.Fatal("Expected the configuration of the detected workflows directory")
+	}
+	if value, ok := cfg.Value([]string{"generate"}, "output"); !ok || value != "docs/ci.md" {
+		t.Errorf("Expected output docs/ci.md from the workflows directory, got %v", value)
+	}
+}
+
+// TestValidateConfigLeavesFlags tests that validating the configuration
+// checks its values without setting the flags of the commands
+func TestValidateConfigLeavesFlags(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".ghadoc.yaml")
+	content := "generate:\n  output: docs/ci.md\n  preamble-lines: many\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write .ghadoc.yaml: %v", err)
+	}
+	cfg, err := config.Load(path)
+	if err != nil {
+		t.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	problems := validateConfig(cfg)
+	if len(problems) != 1 || !strings.Contains(problems[0], "preamble-lines") {
+		t.Errorf("Expected a problem with preamble-lines, got %v", problems)
+	}
+	flag := generateCmd.Flags().Lookup("output")
+	if flag.Changed || flag.Value.String() != flag.DefValue {
+		t.Errorf("Expected --output of generate to be left alone, got %q", flag.Value.String())
+	}
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/cost"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// costCmd represents the cost command
+var costCmd = &cobra.Command{
+	Use:   "cost",
+	Short: "Estimate the runner minutes and cost of every workflow",
+	Long: `Estimate the runner minutes used by every workflow in a directory over a
+time window, grouped by runner type, to attribute CI spend.
+
+The runs of each workflow created in the last --days days are fetched from the
+GitHub Actions API, together with their billable time per runner operating
+system (UBUNTU, MACOS, or WINDOWS). Runs without billable time, such as runs
+in public repositories or on self-hosted runners, are reported as "not billed"
+with their wall-clock duration.
+
+Costs are estimated from per-minute rates of the standard GitHub-hosted
+runners. Override them with --rate, e.g. --rate UBUNTU=0.006,MACOS=0.062.
+
+The API token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		repo, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		days, _ := cmd.Flags().GetInt("days")
+		rateFlags, _ := cmd.Flags().GetStringToString("rate")
+
+		owner, name, err := github.ParseRepo(repo)
+		if err != nil {
+			return commandError("Error estimating workflow cost", err)
+		}
+		if days <= 0 {
+			return commandError("Error estimating workflow cost", errors.New("--days must be positive"))
+		}
+
+		rates, err := parseRates(rateFlags)
+		if err != nil {
+			return commandError("Error estimating workflow cost", err)
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error estimating workflow cost", err)
+		}
+
+		since := time.Now().AddDate(0, 0, -days)
+		client := newClient(cmd, apiURL)
+		usages, err := cost.Collect(cmd.Context(), client, owner, name, workflows, since)
+		if err != nil {
+			return commandError("Error estimating workflow cost", err)
+		}
+
+		err = writeOutput(cost.Render(repo, since, usages, rates), output)
+		if err != nil {
+			return commandError("Error estimating workflow cost", err)
+		}
+		return nil
+	},
+}
+
+// parseRates returns the default runner rates overridden by the given
+// runner to rate mappings.
+func parseRates(overrides map[string]string) (map[string]float64, error) {
+	rates := make(map[string]float64)
+	for runner, rate := range cost.DefaultRates {
+		rates[runner] = rate
+	}
+	for runner, value := range overrides {
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil || rate < 0 {
+			return nil, fmt.Errorf("invalid rate %q for runner %s", value, runner)
+		}
+		rates[strings.ToUpper(runner)] = rate
+	}
+	return rates, nil
+}
+
+func init() {
+	costCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	costCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	costCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	costCmd.Flags().StringP("repo", "r", "", "GitHub repository (owner/name) the workflows belong to")
+	costCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	costCmd.Flags().Int("days", 30, "Number of days of runs to include")
+	costCmd.Flags().StringToString("rate", nil, "Per-minute rate in USD of a runner type, e.g. UBUNTU=0.008")
+	costCmd.MarkFlagRequired("repo")
+	rootCmd.AddCommand(costCmd)
+}
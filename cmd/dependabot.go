@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/dependabot"
+	"github.com/spf13/cobra"
+)
+
+// dependabotCmd represents the dependabot command
+var dependabotCmd = &cobra.Command{
+	Use:   "dependabot",
+	Short: "Generate documentation for the Dependabot configuration",
+	Long: `Generate a markdown table of the version updates of the Dependabot
+configuration of a repository, read from .github/dependabot.yml or
+.github/dependabot.yaml.
+
+The table includes the following columns:
+- Ecosystem: The package ecosystem updated, e.g. gomod or npm
+- Directories: The directories of the package manifests
+- Schedule: How often, on which day, and at what time updates are checked
+- Reviewers: Users and teams requested to review the pull requests
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoDir, _ := cmd.Flags().GetString("repo-dir")
+		output, _ := cmd.Flags().GetString("output")
+
+		config, err := dependabot.Scan(repoDir)
+		if err != nil {
+			return commandError("Error generating Dependabot documentation", err)
+		}
+
+		err = writeOutput(dependabot.Render(config, repoDir, output), output)
+		if err != nil {
+			return commandError("Error generating Dependabot documentation", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	dependabotCmd.Flags().StringP("repo-dir", "d", ".", "Root directory of the repository")
+	dependabotCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(dependabotCmd)
+}
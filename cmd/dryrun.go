@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Modes of --dry-run.
+const (
+	dryRunSummary = "summary" // Paths and sizes of the files
+	dryRunContent = "content" // Paths and content of the files
+)
+
+// dryRunReporter returns the function printing each file a dry run of mode
+// would write to stdout, or nil without --dry-run.
+func dryRunReporter(mode string) (func(path string, content []byte), error) {
+	switch mode {
+	case "":
+		return nil, nil
+	case dryRunSummary:
+		return func(path string, content []byte) {
+			fmt.Printf("Would write %s (%d bytes)\n", path, len(content))
+		}, nil
+	case dryRunContent:
+		return func(path string, content []byte) {
+			fmt.Printf("==> %s <==\n%s", path, content)
+			if !strings.HasSuffix(string(content), "\n") {
+				fmt.Println()
+			}
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid dry run mode %q, expected %s or %s", mode, dryRunSummary, dryRunContent)
+	}
+}
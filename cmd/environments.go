@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/droctothorpe/gha-docs/internal/environments"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/spf13/cobra"
+)
+
+// environmentsCmd represents the environments command
+var environmentsCmd = &cobra.Command{
+	Use:   "environments",
+	Short: "Document deployment environments and the workflows deploying to them",
+	Long: `Query the deployment environments of a repository from the GitHub API and
+cross-reference them with the environment: of the jobs in a directory of
+workflows.
+
+Every environment is listed with its required reviewers, wait timer, and
+deployment branch policy, and the workflows that deploy to it. Environments
+used by workflows but not configured in GitHub are flagged, since GitHub
+creates them without protection on the first deployment.
+
+The API token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		repo, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+
+		owner, name, err := github.ParseRepo(repo)
+		if err != nil {
+			return commandError("Error documenting environments", err)
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error documenting environments", err)
+		}
+
+		configured, err := newClient(cmd, apiURL).ListEnvironments(cmd.Context(), owner, name)
+		if err != nil {
+			return commandError("Error documenting environments", fmt.Errorf("error listing environments: %v", err))
+		}
+
+		err = writeOutput(environments.Render(repo, configured, workflows), output)
+		if err != nil {
+			return commandError("Error documenting environments", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	environmentsCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	environmentsCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	environmentsCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	environmentsCmd.Flags().StringP("repo", "r", "", "GitHub repository (owner/name) the workflows belong to")
+	environmentsCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	environmentsCmd.MarkFlagRequired("repo")
+	rootCmd.AddCommand(environmentsCmd)
+}
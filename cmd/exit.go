@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+)
+
+// Exit codes of gha-docs, so that CI pipelines can tell the failures apart.
+// When several apply, the lowest one is used.
+const (
+	exitOK          = 0 // Success
+	exitError       = 1 // The command failed, e.g. documentation could not be generated
+	exitDrift       = 2 // --check found documentation out of date
+	exitLint        = 3 // Workflows violate lint rules
+	exitParseErrors = 4 // Workflow files failed to parse
+)
+
+// codeError is an error ending gha-docs with a specific exit code. Message
+// is logged with Err as its error.
+type codeError struct {
+	Code    int
+	Message string
+	Err     error
+}
+
+func (e *codeError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Message, e.Err)
+}
+
+func (e *codeError) Unwrap() error {
+	return e.Err
+}
+
+// exit logs err, if any, and ends gha-docs with its exit code: the code of
+// a codeError, else exitError.
+func exit(err error) {
+	if err == nil {
+		os.Exit(exitOK)
+	}
+	var codeErr *codeError
+	if errors.As(err, &codeErr) {
+		slog.Error(codeErr.Message, "error", codeErr.Err)
+		os.Exit(codeErr.Code)
+	}
+	slog.Error("Error", "error", err)
+	os.Exit(exitError)
+}
+
+// commandError returns the error of a command failing with err, logged as
+// message.
+func commandError(message string, err error) error {
+	return &codeError{Code: exitError, Message: message, Err: err}
+}
@@ -1,9 +1,22 @@
 package cmd
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/git"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/metrics"
+	"github.com/droctothorpe/gha-docs/internal/remote"
 	"github.com/spf13/cobra"
 )
 
@@ -17,24 +30,724 @@ var generateCmd = &cobra.Command{
 The table includes the following columns:
 - Filename: Name of the workflow file with a link to the file
 - Description: Extracted from the first line starting with "##" in the workflow file
-- On Push: Indicates if the workflow runs on push events
-- On PR: Indicates if the workflow runs on pull request events
+- Triggers: The events that trigger the workflow
+- Badge: A status badge for the workflow (only with --badges and --repo-url)
 
-Output is written to workflows.md in the current directory.`,
-	Run: func(cmd *cobra.Command, args []string) {
-		workflowDir, _ := cmd.Flags().GetString("workflows")
+Output is written to workflows.md in the current directory. Without
+--workflows, the .github/workflows directory of the git repository containing
+the current directory is documented, so that gha-docs generate works without
+flags from anywhere in a repository.
+
+Descriptions are read from the first of the sources of --description-from a
+workflow has: "comments" (the leading "##" comments), "key" (a top-level
+x-description key or the description key of the metadata block), and "marker"
+("# ghadoc:description" comments anywhere in the file). Add "name" (the
+workflow name) and "job" (the name of the first job) as fallbacks, for example
+--description-from comments,name,job, so that workflows without doc comments
+still get a meaningful description.
+
+The "##" doc comments may follow up to --preamble-lines blank lines, comments
+such as a license header, and "---" document markers. Set it to 0 to only
+read doc comments that start on the first line. Teams with an existing
+comment convention can change the "##" prefix with --comment-prefix, e.g.
+--comment-prefix "# @doc".
+
+With --check, nothing is written either: the files generation would write are
+compared with the files on disk, and if any of them differs or is missing,
+gha-docs prints its diff and exits with code 2, e.g. to fail CI when the
+documentation was not regenerated after a workflow changed. The time of
+generation is left out of --provenance comments for the comparison.
+
+With --dry-run, the workflows are scanned and rendered as usual, but the
+files that would be written, including pages, READMEs, and the job summary,
+are only listed with their sizes, or with --dry-run=content printed.
+
+Workflow files that fail to parse are skipped and listed in a "Parse errors"
+section below the markdown table, unless --hide-parse-errors is set, and in
+the warnings of JSON output. They are reported as warnings once generation is
+done, and --warnings-json writes these warnings to a file as JSON for tools to
+read, and gha-docs exits with code 4 once the documentation is written. With
+--strict, any of them fails the whole run with exit code 4 instead, before
+anything is written.
+
+Symlinked workflow files, and with --recursive symlinked directories, are
+documented like the others with --symlinks follow, the default. With
+--symlinks resolve, their links point to the files the symlinks point to,
+e.g. shared workflows of a monorepo, and with --symlinks skip they are
+ignored.
+
+Workflow files are recognized by the extensions of --extensions, .yml and
+.yaml by default, in any case so that files such as LEGACY.YML are not skipped.
+
+Workflow files larger than --max-file-size bytes, 1 MiB by default, and with
+--recursive the subdirectories nested deeper than --max-depth, are skipped
+with a warning like files that fail to parse, so that a stray generated YAML
+file or a deep vendor tree cannot stall generation.
+
+Workflows disabled by convention, either with a .disabled suffix such as
+ci.yml.disabled or with a "# ghadoc:disabled" comment, are listed in a
+"Disabled" section below the table rather than omitted.
+
+With --format html, a self-contained HTML widget summarizing workflow counts,
+trigger usage, and description coverage is generated instead, with
+--github-status also the latest run of every workflow and how many pass and
+fail. The widget has no external dependencies and can be embedded in
+dashboards via an iframe.
+
+With --format csv, the table is exported as CSV. With --format json, everything
+parsed from the workflows, including jobs and steps, is exported as JSON.
+
+With --pages-dir, a markdown page per workflow is also written to the given
+directory. Use the nav command to generate docs site navigation for them.
+
+With --trigger-hints, a note on the context and payload each trigger provides
+(for example whether secrets are available to pull requests from forks) is
+added below the table and to the pages.
+
+With --trigger-links, the Triggers cells link to the line of the "on" block in
+the workflow file, e.g. ci.yml#L12, to jump to the trigger configuration of
+large workflows.
+
+With --security-notes, workflows triggered by pull_request, pull_request_target,
+or workflow_run are annotated with notes on secret availability and
+GITHUB_TOKEN write access for pull requests from forks.
+
+With --repo, the workflow files are fetched from a GitHub repository through
+the GitHub API instead of being read from a local directory, at --ref or the
+default branch. --workflows is then the directory within the repository and
+defaults to .github/workflows. Links point to the files on GitHub. The API
+token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.
+
+With --repo-url and --ref, links to local workflow files point to the files
+on GitHub at --ref instead of relative paths, for documentation published
+outside of the repository, such as a wiki, Confluence, or a docs site.
+With --permalink, links are pinned to the commit --ref (or HEAD of the local
+repository, or the default branch with --repo) points to, so that published
+documentation does not drift as the branch moves.
+
+With --github-status, the latest run of every workflow is queried from the
+GitHub Actions API and Status and Last Run columns are added to the table.
+The repository is taken from --repo or --repo-url.
+
+With --run-metrics N, the last N runs of every workflow are queried from the
+GitHub Actions API and Success Rate and Median Duration columns are added to
+the table, to help identify flaky or slow workflows. Cancelled and skipped
+runs do not count towards the success rate.
+
+With --workflow-state, the state of every workflow is queried from the GitHub
+Actions API and a State column is added to the table, marking workflows that
+are disabled manually, due to inactivity, or in a fork. Workflows GitHub does
+not know yet are marked as not on GitHub.
+
+With --step-summary, inside GitHub Actions the markdown table is also appended
+to the job summary (the file in GITHUB_STEP_SUMMARY), so that it appears in
+the run UI. Links in the summary point to the workflow files at the commit of
+the run.
+
+With --template, the workflows are rendered through a Go text/template instead
+of the built-in table, for full control over the layout. The template is
+executed with the workflows, the repository URL, and the status, metrics, and
+state of the workflows if requested. Besides the functions link, badge, and
+page, which format a workflow like the built-in table, the functions of the
+sprig library are available (lower, upper, trim, replace, join, default,
+toJson, and so on).
+
+With --theme, the workflows are rendered through one of the built-in
+templates: compact (a minimal table of names and descriptions), detailed (the
+table plus a section per workflow with its schedules, permissions,
+environments, secrets, and jobs), badge (status badges first; requires
+--repo-url), or grouped (one table per trigger).
+
+With --header and --footer, markdown is prepended and appended to the
+generated markdown, for example an introduction or a notice that the file is
+generated and should not be edited. Use --header-file and --footer-file to
+read them from files instead.
+
+With --columns NAME=PATH, a column is added to the table for each field a
+team wants to surface, extracted from the workflow file at a dot-separated
+path. * matches every entry of a map or list, so Runners=jobs.*.runs-on lists
+the runners of all jobs. Lists at the end of the path contribute their items
+and maps their keys.
+
+With --columns NAME=exec:COMMAND, the values of the column are provided by
+COMMAND instead, run once with the column name and the workflows as JSON on
+standard input, and printing {"values": [[...], ...]}, the values of each
+workflow in order, on standard output.
+
+The values of the columns can be formatted in the column-formats section of
+the configuration file, keyed by column header: map replaces values, for
+example with emoji, separator joins the values of a cell, and truncate limits
+the length of a cell.
+
+With --lang, the title, column headers, and boilerplate text are written in
+another language, e.g. de, es, fr, or ja.
+
+With --respect-ignore, hidden files and files ignored by .gitignore or
+.ghadocignore files, in the workflows directory or its parents up to the root
+of the git repository, are not documented.
+
+With --recursive, the subdirectories of the workflows directory are scanned
+too, for example for reusable workflows organized in subfolders or several
+.github/workflows trees in a monorepo. The table shows the path of each
+workflow relative to the workflows directory. YAML files in subdirectories
+that have no jobs are not workflows and are skipped.
+
+With --readme-per-dir, a README.md documenting only the workflows of each
+scanned directory is also written into that directory. An existing README is
+kept: the documentation replaces the content between the
+"<!-- ghadoc:start -->" and "<!-- ghadoc:end -->" markers, which are appended
+if missing.
+
+--workflows can be repeated, or given a comma-separated list, to document
+several directories together, for example separate CI and CD workflow
+folders.
+
+--inject writes the documentation into an existing file, such as README.md,
+between "<!-- ghadoc:start -->" and "<!-- ghadoc:end -->" markers instead of
+into --output. The rest of the file is kept, and the file is only rewritten
+when the documentation changes.
+
+Several documents can be generated in one run by listing targets under
+generate in the configuration file. Each target sets an output or inject
+file and optionally its own format, template, theme, columns, filter-trigger,
+filter-tag, group-by, and sort; other settings are shared. --output and
+--inject are not used when targets are configured.
+
+--filter-trigger documents only the workflows run by any of the given
+triggers, for example only scheduled or manually dispatched workflows.
+--filter-tag documents only the workflows with any of the given tags, set by
+a "## @tags deploy, production" comment or the tags of the metadata block.
+Both filters can be combined.
+
+--provenance adds a comment to the top of the document naming the gha-docs
+version, the time of generation, and the source directory, with a warning not
+to edit the file by hand. Add --no-timestamp to leave out the time, so that
+regenerating unchanged workflows gives an identical file.
+
+--reproducible guarantees byte-identical output for identical workflows on
+any operating system, so that diffs of the generated file in CI are reliable:
+workflows are sorted by filename unless --sort is given (sorting by
+modification time is refused), no timestamp is written, and paths and line
+endings are normalized.
+
+--toc adds a table of contents linking to the sections of the document,
+such as the groups of --group-by or the workflow sections of the detailed
+theme, below its title.
+
+--sort orders the workflows by name, filename, trigger, or modified (the
+modification time of the file, oldest first), and --desc reverses the order.
+Workflows of several directories are sorted within each directory, and the
+directories stay in the order given, except that the directories of reusable
+workflows come before the directories calling them.
+
+--group-by splits the table into a section per trigger, directory, owner, or
+tag, which keeps large inventories navigable. Owners and tags are read from
+the metadata block (owner/owners and tags); a workflow with several is listed
+in each of their sections, and workflows without any get a last section.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDirs, _ := cmd.Flags().GetStringSlice("workflows")
+		repo, _ := cmd.Flags().GetString("repo")
+		if len(workflowDirs) == 0 {
+			// Remote repositories default to remote.DefaultWorkflowsDir below
+			workflowDirs = []string{"."}
+			if repo == "" {
+				dir, err := detectWorkflowsDir()
+				if err != nil {
+					return generateError(err)
+				}
+				slog.Debug("Detected workflows directory", "dir", dir)
+				workflowDirs = []string{dir}
+			}
+		}
 		output, _ := cmd.Flags().GetString("output")
+		inject, _ := cmd.Flags().GetString("inject")
+		format, _ := cmd.Flags().GetString("format")
+		pagesDir, _ := cmd.Flags().GetString("pages-dir")
+		repoURL, _ := cmd.Flags().GetString("repo-url")
+		badges, _ := cmd.Flags().GetBool("badges")
+		badgeStyle, _ := cmd.Flags().GetString("badge-style")
+		branch, _ := cmd.Flags().GetString("branch")
+		ref, _ := cmd.Flags().GetString("ref")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		triggerHints, _ := cmd.Flags().GetBool("trigger-hints")
+		triggerLinks, _ := cmd.Flags().GetBool("trigger-links")
+		security, _ := cmd.Flags().GetBool("security-notes")
+		githubStatus, _ := cmd.Flags().GetBool("github-status")
+		runMetrics, _ := cmd.Flags().GetInt("run-metrics")
+		workflowState, _ := cmd.Flags().GetBool("workflow-state")
+		stepSummary, _ := cmd.Flags().GetBool("step-summary")
+		templatePath, _ := cmd.Flags().GetString("template")
+		theme, _ := cmd.Flags().GetString("theme")
+		columnSpecs, _ := cmd.Flags().GetStringSlice("columns")
+		lang, _ := cmd.Flags().GetString("lang")
+		respectIgnore, _ := cmd.Flags().GetBool("respect-ignore")
+		recursive, _ := cmd.Flags().GetBool("recursive")
+		descriptionFrom, _ := cmd.Flags().GetStringSlice("description-from")
+		preambleLines, _ := cmd.Flags().GetInt("preamble-lines")
+		commentPrefix, _ := cmd.Flags().GetString("comment-prefix")
+		strict, _ := cmd.Flags().GetBool("strict")
+		symlinks, _ := cmd.Flags().GetString("symlinks")
+		extensions, _ := cmd.Flags().GetStringSlice("extensions")
+		workers, _ := cmd.Flags().GetInt("workers")
+		maxFileSize, _ := cmd.Flags().GetInt64("max-file-size")
+		maxDepth, _ := cmd.Flags().GetInt("max-depth")
+		hideParseErrors, _ := cmd.Flags().GetBool("hide-parse-errors")
+		warningsJSON, _ := cmd.Flags().GetString("warnings-json")
+		dryRunMode, _ := cmd.Flags().GetString("dry-run")
+		check, _ := cmd.Flags().GetBool("check")
+		dirReadmes, _ := cmd.Flags().GetBool("readme-per-dir")
+		groupBy, _ := cmd.Flags().GetString("group-by")
+		filterTriggers, _ := cmd.Flags().GetStringSlice("filter-trigger")
+		filterTags, _ := cmd.Flags().GetStringSlice("filter-tag")
+		sortBy, _ := cmd.Flags().GetString("sort")
+		toc, _ := cmd.Flags().GetBool("toc")
+		provenance, _ := cmd.Flags().GetBool("provenance")
+		noTimestamp, _ := cmd.Flags().GetBool("no-timestamp")
+		reproducible, _ := cmd.Flags().GetBool("reproducible")
+		permalink, _ := cmd.Flags().GetBool("permalink")
+		desc, _ := cmd.Flags().GetBool("desc")
+
+		var columns []generate.Column
+		for _, spec := range columnSpecs {
+			column, err := generate.ParseColumn(spec)
+			if err != nil {
+				return generateError(err)
+			}
+			columns = append(columns, column)
+		}
+
+		var columnFormats map[string]generate.ColumnFormat
+		_, err := activeConfig.Decode(commandPath(cmd), "column-formats", &columnFormats)
+		if err != nil {
+			return generateError(err)
+		}
+
+		var targets []generate.Target
+		_, err = activeConfig.Decode(commandPath(cmd), "targets", &targets)
+		if err != nil {
+			return generateError(err)
+		}
+
+		header, err := readPartial(cmd, "header")
+		if err != nil {
+			return generateError(err)
+		}
+		footer, err := readPartial(cmd, "footer")
+		if err != nil {
+			return generateError(err)
+		}
+
+		opts := generate.Options{
+			WorkflowsDir:    workflowDirs[0],
+			Output:          output,
+			Inject:          inject,
+			Format:          format,
+			PagesDir:        pagesDir,
+			RepoURL:         repoURL,
+			Badges:          badges,
+			BadgeStyle:      badgeStyle,
+			Branch:          branch,
+			TriggerHints:    triggerHints,
+			TriggerLinks:    triggerLinks,
+			Security:        security,
+			Template:        templatePath,
+			Theme:           theme,
+			Header:          header,
+			Footer:          footer,
+			Columns:         columns,
+			ColumnFormats:   columnFormats,
+			Lang:            lang,
+			GroupBy:         groupBy,
+			FilterTriggers:  filterTriggers,
+			FilterTags:      filterTags,
+			Sort:            sortBy,
+			Desc:            desc,
+			TOC:             toc,
+			DirReadmes:      dirReadmes,
+			Provenance:      provenance,
+			Version:         buildVersion(),
+			Reproducible:    reproducible,
+			HideParseErrors: hideParseErrors,
+			ScanOptions: generate.ScanOptions{
+				RespectIgnore: respectIgnore,
+				Recursive:     recursive,
+				Parse: generate.ParseOptions{
+					DescriptionFrom: descriptionFrom,
+					PreambleLines:   preambleLines,
+					CommentPrefix:   commentPrefix,
+				},
+				Strict:      strict,
+				Symlinks:    symlinks,
+				Extensions:  extensions,
+				Workers:     workers,
+				MaxFileSize: maxFileSize,
+				MaxDepth:    maxDepth,
+				Cache:       newWorkflowCache(cmd),
+			},
+		}
+
+		opts.DryRun, err = dryRunReporter(dryRunMode)
+		if err != nil {
+			return generateError(err)
+		}
+		var checker *driftChecker
+		if check {
+			if opts.DryRun != nil {
+				return generateError(errors.New("--check and --dry-run cannot be combined"))
+			}
+			if opts.Output == generate.StdoutOutput && opts.Inject == "" {
+				return generateError(errors.New("--check requires an output file"))
+			}
+			color, err := useColor(cmd, os.Stdout)
+			if err != nil {
+				return generateError(err)
+			}
+			checker = &driftChecker{color: color}
+			opts.DryRun = checker.check
+			// The time of generation would always differ
+			noTimestamp = true
+			// The job summary is not kept in the repository
+			stepSummary = false
+		}
+		if opts.DryRun != nil {
+			// Dry runs leave the cache untouched too
+			opts.ScanOptions.Cache = nil
+		}
+
+		if len(workflowDirs) > 1 {
+			opts.WorkflowsDirs = workflowDirs
+		}
+
+		if !noTimestamp {
+			opts.Timestamp = time.Now()
+		}
+
+		if stepSummary {
+			opts.StepSummary = os.Getenv(generate.StepSummaryEnv)
+			if opts.StepSummary == "" {
+				return generateError(fmt.Errorf("--step-summary requires %s, which GitHub Actions sets", generate.StepSummaryEnv))
+			}
+		}
+
+		// Local workflows are linked on GitHub at --ref, if given
+		if repo == "" && repoURL != "" {
+			opts.Ref = ref
+		}
+
+		if permalink && repo == "" {
+			if repoURL == "" {
+				return generateError(errors.New("--permalink requires --repo or --repo-url"))
+			}
+			if ref == "" {
+				ref = "HEAD"
+			}
+			opts.Ref, err = git.RevParse(cmd.Context(), workflowDirs[0], ref)
+			if err != nil {
+				return generateError(err)
+			}
+		}
+
+		client := newClient(cmd, apiURL)
 
-		err := generate.Generate(workflowDir, output)
+		if repo != "" {
+			owner, name, err := github.ParseRepo(repo)
+			if err != nil {
+				return generateError(err)
+			}
+
+			repository, err := client.GetRepo(cmd.Context(), owner, name)
+			if err != nil {
+				return generateError(fmt.Errorf("error reading repository %s: %v", repo, err))
+			}
+			if ref == "" {
+				ref = repository.DefaultBranch
+			}
+			if permalink {
+				sha, err := client.GetCommitSHA(cmd.Context(), owner, name, ref)
+				if err != nil {
+					return generateError(fmt.Errorf("error resolving %s: %v", ref, err))
+				}
+				ref = sha
+			}
+
+			if !cmd.Flags().Changed("workflows") {
+				opts.WorkflowsDir = remote.DefaultWorkflowsDir
+			}
+			if opts.RepoURL == "" {
+				opts.RepoURL = repository.HTMLURL
+			}
+			opts.Ref = ref
+			opts.Scan = func(workflowsDir string) ([]generate.WorkflowInfo, []generate.ParseError, error) {
+				return remote.WorkflowsWithErrors(cmd.Context(), client, owner, name, ref, workflowsDir, opts.ScanOptions)
+			}
+		}
+
+		if githubStatus || runMetrics > 0 {
+			err := addRunStats(cmd.Context(), &opts, client, repo, githubStatus, runMetrics)
+			if err != nil {
+				return generateError(err)
+			}
+		}
+
+		if workflowState {
+			err := addWorkflowState(cmd.Context(), &opts, client, repo)
+			if err != nil {
+				return generateError(err)
+			}
+		}
+
+		var warnings []generate.Warning
+		start := time.Now()
+		if len(targets) > 0 {
+			warnings, err = generate.GenerateTargets(cmd.Context(), opts, targets)
+		} else {
+			warnings, err = generate.GenerateContext(cmd.Context(), opts)
+		}
+		slog.Debug("Generated workflow documentation", "duration", time.Since(start))
+		for _, warning := range warnings {
+			slog.Warn(warning.Message, "file", warning.File)
+		}
+		if err == nil && warningsJSON != "" && !check {
+			err = writeWarnings(warningsJSON, warnings, opts.DryRun)
+		}
+		var parseErrors generate.ParseErrors
+		if errors.As(err, &parseErrors) {
+			if len(parseErrors) > 1 {
+				// Log every file on its own rather than all on one line
+				for _, parseError := range parseErrors {
+					slog.Error("Error parsing workflow file", "file", parseError.Filename, "dir", parseError.Dir, "error", parseError.Err)
+				}
+				err = fmt.Errorf("%d workflow files failed to parse", len(parseErrors))
+			}
+			return &codeError{Code: exitParseErrors, Message: "Error generating workflow documentation", Err: err}
+		}
 		if err != nil {
-			fmt.Printf("Error generating workflow documentation: %v\n", err)
+			return generateError(err)
+		}
+		if checker != nil && len(checker.stale) > 0 {
+			return &codeError{Code: exitDrift, Message: "Documentation is out of date", Err: fmt.Errorf("regenerate %s without --check", strings.Join(checker.stale, ", "))}
+		}
+		if len(warnings) > 0 {
+			return &codeError{Code: exitParseErrors, Message: "Workflow files were skipped", Err: fmt.Errorf("%d workflow files failed to parse", len(warnings))}
 		}
+		return nil
 	},
 }
 
+// detectWorkflowsDir returns the .github/workflows directory of the git
+// repository containing the current directory, relative to it.
+func detectWorkflowsDir() (string, error) {
+	root, err := git.FindRoot(".")
+	if err != nil {
+		return "", fmt.Errorf("no --workflows given and %v", err)
+	}
+	dir := filepath.Join(root, ".github", "workflows")
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("no --workflows given and %s does not exist", dir)
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		if rel, err := filepath.Rel(cwd, dir); err == nil {
+			return rel, nil
+		}
+	}
+	return dir, nil
+}
+
+// generateError returns the error of generate failing with err.
+func generateError(err error) error {
+	return commandError("Error generating workflow documentation", err)
+}
+
 func init() {
-	generateCmd.Flags().StringP("workflows", "w", ".", "Directory containing GitHub workflow files")
-	generateCmd.Flags().StringP("output", "o", "./workflows.md", "Output file for the markdown table")
-	generateCmd.MarkFlagRequired("workflows")
+	generateCmd.Flags().StringSliceP("workflows", "w", nil, "Directories containing GitHub workflow files, repeated or comma-separated (defaults to .github/workflows of the repository)")
+	generateCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	generateCmd.Flags().StringP("output", "o", "./workflows.md", "Output file for the markdown table, or - for stdout")
+	generateCmd.Flags().String("inject", "", "Inject the documentation into this file between <!-- ghadoc:start --> and <!-- ghadoc:end --> markers instead of writing --output")
+	generateCmd.Flags().StringP("format", "f", generate.FormatMarkdown, "Output format: "+strings.Join(generate.Formats(), ", "))
+	generateCmd.RegisterFlagCompletionFunc("format", completeFormats)
+	generateCmd.Flags().String("pages-dir", "", "Directory to write one markdown page per workflow into")
+	generateCmd.Flags().String("repo-url", "", "URL of the repository on GitHub, e.g. https://github.com/owner/repo")
+	generateCmd.Flags().Bool("badges", false, "Add a status badge column to the table (requires --repo-url)")
+	generateCmd.Flags().String("badge-style", generate.BadgeStyleGitHub, "Badge style: github or shields")
+	generateCmd.Flags().String("branch", "", "Branch whose status the badges report (defaults to the default branch)")
+	generateCmd.Flags().Bool("trigger-hints", false, "Add notes on the context and payload each trigger provides")
+	generateCmd.Flags().Bool("trigger-links", false, "Link the Triggers cells to the line of the on block in the workflow file")
+	generateCmd.Flags().Bool("security-notes", false, "Add security notes for workflows that run on pull requests from forks")
+	generateCmd.Flags().Bool("github-status", false, "Add Status and Last Run columns from the GitHub Actions API")
+	generateCmd.Flags().Int("run-metrics", 0, "Add Success Rate and Median Duration columns computed over the last N runs from the GitHub Actions API")
+	generateCmd.Flags().Bool("workflow-state", false, "Add a State column marking workflows disabled in GitHub")
+	generateCmd.Flags().Bool("step-summary", false, "Also write the table to the GitHub Actions job summary")
+	generateCmd.Flags().String("template", "", "Go text/template to render the workflows through instead of the built-in table")
+	generateCmd.Flags().String("theme", "", "Built-in template to render the workflows through: "+strings.Join(generate.Themes, ", "))
+	generateCmd.Flags().String("header", "", "Markdown to prepend to the generated markdown")
+	generateCmd.Flags().String("footer", "", "Markdown to append to the generated markdown")
+	generateCmd.Flags().String("header-file", "", "File with markdown to prepend to the generated markdown")
+	generateCmd.Flags().String("footer-file", "", "File with markdown to append to the generated markdown")
+	generateCmd.Flags().StringSlice("columns", nil, "Columns to add to the table as NAME=PATH, e.g. Runners=jobs.*.runs-on, or NAME=exec:COMMAND")
+	generateCmd.Flags().String("lang", generate.DefaultLanguage, "Language of the headings and boilerplate text: "+strings.Join(generate.Languages(), ", "))
+	generateCmd.Flags().Bool("respect-ignore", false, "Skip hidden files and files ignored by .gitignore or .ghadocignore")
+	generateCmd.Flags().StringSlice("description-from", generate.DefaultDescriptionFrom, "Sources of the descriptions in order of preference: comments, key, marker, name, or job")
+	generateCmd.Flags().Int("preamble-lines", 20, "Number of blank, comment, and --- lines that may precede the ## doc comments")
+	generateCmd.Flags().String("comment-prefix", generate.DefaultCommentPrefix, "Prefix of the doc comment lines holding the description, e.g. \"# @doc\"")
+	generateCmd.Flags().Bool("strict", false, "Fail with exit code 4 if any workflow file fails to parse instead of skipping it")
+	generateCmd.Flags().Bool("hide-parse-errors", false, "Leave the Parse errors section out of the markdown table")
+	generateCmd.Flags().String("dry-run", "", "Scan and render, but only print the files that would be written: summary (paths and sizes, the default) or content")
+	generateCmd.Flags().Lookup("dry-run").NoOptDefVal = dryRunSummary
+	generateCmd.Flags().Bool("check", false, "Exit with code 2 instead of writing the documentation if it is out of date")
+	generateCmd.Flags().String("warnings-json", "", "Also write the warnings, such as skipped workflow files, to this file as JSON")
+	generateCmd.Flags().String("symlinks", generate.SymlinksFollow, "Policy for symlinked workflow files and directories: "+strings.Join(generate.SymlinkPolicies, ", "))
+	generateCmd.Flags().StringSlice("extensions", generate.DefaultExtensions, "Extensions of the workflow files, matched in any case")
+	generateCmd.Flags().Int("workers", 0, "Number of workflow files parsed at once (defaults to the number of CPUs)")
+	generateCmd.Flags().Bool("recursive", false, "Also scan the subdirectories of the workflows directory")
+	generateCmd.Flags().Int64("max-file-size", generate.DefaultMaxFileSize, "Skip workflow files larger than this many bytes, with a warning (negative for no limit)")
+	generateCmd.Flags().Int("max-depth", 0, "With --recursive, skip subdirectories more than this many levels deep, with a warning (0 for no limit)")
+	generateCmd.Flags().Bool("readme-per-dir", false, "Also write a README.md documenting the workflows of each scanned directory into it")
+	generateCmd.Flags().StringSlice("filter-trigger", nil, "Only document workflows run by any of these triggers, e.g. schedule,workflow_dispatch")
+	generateCmd.Flags().StringSlice("filter-tag", nil, "Only document workflows with any of these tags, e.g. deploy")
+	generateCmd.Flags().String("sort", generate.SortFilename, "Order of the workflows: name, filename, trigger, or modified")
+	generateCmd.Flags().Bool("desc", false, "Sort the workflows in descending order")
+	generateCmd.Flags().Bool("provenance", false, "Add a comment with the gha-docs version, time of generation, and source to the top of the document")
+	generateCmd.Flags().Bool("no-timestamp", false, "Leave the time of generation out of the provenance comment")
+	generateCmd.Flags().Bool("reproducible", false, "Guarantee identical output for identical workflows on any system")
+	generateCmd.Flags().Bool("toc", false, "Add a table of contents linking to the sections of the document")
+	generateCmd.Flags().String("group-by", "", "Split the table into a section per group: trigger, directory, owner, or tag")
+	generateCmd.Flags().String("repo", "", "GitHub repository (owner/name) to fetch the workflows from instead of a local directory")
+	generateCmd.Flags().Bool("permalink", false, "Pin links on GitHub to the commit SHA of --ref or HEAD instead of a branch name")
+	generateCmd.Flags().String("ref", "", "Branch, tag, or commit to fetch the workflows at with --repo (defaults to the default branch), or to link them at with --repo-url")
+	generateCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
 	rootCmd.AddCommand(generateCmd)
 }
+
+// readPartial returns the markdown of the header or footer flag name, read
+// from the file of the name-file flag if that is set instead.
+func readPartial(cmd *cobra.Command, name string) (string, error) {
+	inline, _ := cmd.Flags().GetString(name)
+	file, _ := cmd.Flags().GetString(name + "-file")
+	if file == "" {
+		return inline, nil
+	}
+	if inline != "" {
+		return "", fmt.Errorf("--%s and --%s-file cannot be used together", name, name)
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return "", fmt.Errorf("error reading %s: %v", name, err)
+	}
+	return string(content), nil
+}
+
+// writeWarnings writes warnings to path as a JSON list, empty if there are
+// none, or passes them to dryRun if set.
+func writeWarnings(path string, warnings []generate.Warning, dryRun func(path string, content []byte)) error {
+	if warnings == nil {
+		warnings = []generate.Warning{}
+	}
+	content, err := json.MarshalIndent(warnings, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dryRun != nil {
+		dryRun(path, append(content, '\n'))
+		return nil
+	}
+	if err := os.WriteFile(path, append(content, '\n'), 0644); err != nil {
+		return fmt.Errorf("error writing warnings: %v", err)
+	}
+	return nil
+}
+
+// statusRuns is the number of recent runs searched for the latest completed
+// run of each workflow.
+const statusRuns = 10
+
+// addRunStats scans the workflows of opts and fetches their recent runs from
+// the repository repo, or from opts.RepoURL if repo is empty. With status, the
+// latest completed run of each workflow is added to opts.Status. With runs
+// above zero, the metrics of the last runs runs are added to opts.Metrics.
+func addRunStats(ctx context.Context, opts *generate.Options, client *github.Client, repo string, status bool, runs int) error {
+	owner, name, err := apiRepo(opts, repo)
+	if err != nil {
+		return err
+	}
+
+	// Keep the scanned workflows for generation rather than scanning again
+	opts.ReuseScans()
+	workflows, _, err := opts.ScanWorkflows(ctx)
+	if err != nil {
+		return err
+	}
+
+	count := runs
+	if status && count < statusRuns {
+		count = statusRuns
+	}
+	stats, err := metrics.Collect(ctx, client, owner, name, workflows, count)
+	if err != nil {
+		return err
+	}
+
+	if status {
+		opts.Status = make(map[string]generate.RunStatus)
+		for _, stat := range stats {
+			opts.Status[stat.Workflow] = generate.RunStatus{Conclusion: stat.LastConclusion, RunAt: stat.LastRunAt}
+		}
+	}
+	if runs > 0 {
+		opts.Metrics = make(map[string]generate.RunMetrics)
+		for _, stat := range stats {
+			if count > runs {
+				// The status needed more runs than the metrics cover
+				runList, err := client.ListWorkflowRuns(ctx, owner, name, stat.Workflow, runs)
+				if err != nil {
+					return fmt.Errorf("error fetching runs of %s: %v", stat.Workflow, err)
+				}
+				stat = metrics.Compute(stat.Workflow, runList)
+			}
+			opts.Metrics[stat.Workflow] = generate.RunMetrics{
+				Runs:           stat.Runs,
+				Decided:        stat.Successes + stat.Failures,
+				SuccessRate:    stat.SuccessRate,
+				MedianDuration: stat.MedianDuration,
+			}
+		}
+	}
+	return nil
+}
+
+// addWorkflowState fetches the state of every workflow registered in the
+// repository repo, or in opts.RepoURL if repo is empty, into opts.State.
+func addWorkflowState(ctx context.Context, opts *generate.Options, client *github.Client, repo string) error {
+	owner, name, err := apiRepo(opts, repo)
+	if err != nil {
+		return err
+	}
+
+	workflows, err := client.ListWorkflows(ctx, owner, name)
+	if err != nil {
+		return fmt.Errorf("error fetching workflows of %s/%s: %v", owner, name, err)
+	}
+
+	opts.State = make(map[string]string)
+	for _, workflow := range workflows {
+		opts.State[path.Base(workflow.Path)] = workflow.State
+	}
+	return nil
+}
+
+// apiRepo returns the owner and name of the repository queried through the
+// GitHub API: repo, or the repository of opts.RepoURL if repo is empty.
+func apiRepo(opts *generate.Options, repo string) (string, string, error) {
+	if repo == "" {
+		if opts.RepoURL == "" {
+			return "", "", fmt.Errorf("--github-status, --run-metrics, and --workflow-state require --repo or --repo-url")
+		}
+
+		var err error
+		repo, err = github.RepoFromURL(opts.RepoURL)
+		if err != nil {
+			return "", "", err
+		}
+	}
+	return github.ParseRepo(repo)
+}
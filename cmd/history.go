@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/history"
+	"github.com/spf13/cobra"
+)
+
+// historyCmd represents the history command
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Generate a changelog of workflow changes between two git refs",
+	Long: `Generate a markdown changelog describing how the GitHub Actions workflows in a
+directory changed between two git refs or tags.
+
+For each workflow the changelog reports whether it was added or removed, which
+triggers were added or removed, whether its description changed, and the
+commits that touched it.
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+
+		entries, err := history.Changelog(cmd.Context(), workflowDir, from, to)
+		if err != nil {
+			return commandError("Error generating workflow changelog", err)
+		}
+
+		err = writeOutput(history.RenderChangelog(entries, workflowDir, from, to), output)
+		if err != nil {
+			return commandError("Error generating workflow changelog", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	historyCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	historyCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	historyCmd.Flags().StringP("output", "o", "", "Output file for the changelog (defaults to stdout)")
+	historyCmd.Flags().String("from", "", "Git ref or tag to compare from")
+	historyCmd.Flags().String("to", "HEAD", "Git ref or tag to compare to")
+	historyCmd.MarkFlagRequired("from")
+	rootCmd.AddCommand(historyCmd)
+}
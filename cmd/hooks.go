@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/git"
+	"github.com/droctothorpe/gha-docs/internal/hooks"
+	"github.com/spf13/cobra"
+)
+
+// hooksCmd represents the hooks command
+var hooksCmd = &cobra.Command{
+	Use:   "hooks",
+	Short: "Manage the git hooks of gha-docs",
+}
+
+// hooksInstallCmd represents the hooks install command
+var hooksInstallCmd = &cobra.Command{
+	Use:   "install [-- generate flags]",
+	Short: "Install a pre-commit hook checking that the documentation is up to date",
+	Long: `Install a git pre-commit hook running gha-docs generate --check, so that
+commits fail while the documentation is out of date with the workflows. The
+hook prints the command regenerating the documentation. Flags after -- are
+passed to generate, e.g.
+
+  gha-docs hooks install -- -w .github/workflows -o docs/workflows.md
+
+An existing pre-commit hook that gha-docs did not install is only replaced
+with --force.
+
+With --pre-commit, the gha-docs-check hook is added to the
+.pre-commit-config.yaml of the repository for the pre-commit framework
+instead, pinned to --rev, the version of gha-docs by default.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		force, _ := cmd.Flags().GetBool("force")
+		preCommit, _ := cmd.Flags().GetBool("pre-commit")
+		rev, _ := cmd.Flags().GetString("rev")
+
+		root, err := git.FindRoot(".")
+		if err != nil {
+			return commandError("Error installing hook", err)
+		}
+
+		if preCommit {
+			if rev == "" {
+				rev = buildVersion()
+				if !isRelease(rev) {
+					rev = "main"
+					slog.Warn("gha-docs has no release version, pin --rev to a release", "rev", rev)
+				}
+			}
+			path := filepath.Join(root, ".pre-commit-config.yaml")
+			added, err := hooks.AddPreCommitEntry(path, rev, args)
+			if err != nil {
+				return commandError("Error installing hook", err)
+			}
+			if !added {
+				slog.Info("Hook is already configured", "hook", hooks.PreCommitHookID, "path", path)
+				return nil
+			}
+			slog.Info("Successfully added hook", "hook", hooks.PreCommitHookID, "path", path)
+			return nil
+		}
+
+		hooksDir, err := git.HooksDir(cmd.Context(), root)
+		if err != nil {
+			return commandError("Error installing hook", err)
+		}
+		path, err := hooks.Install(hooksDir, hooks.Script(args), force)
+		if errors.Is(err, hooks.ErrHookExists) {
+			err = fmt.Errorf("%v, replace it with --force", err)
+		}
+		if err != nil {
+			return commandError("Error installing hook", err)
+		}
+		slog.Info("Successfully installed hook", "path", path)
+		return nil
+	},
+}
+
+// pseudoVersion matches the pseudo-versions of builds from commits that are
+// not tagged, e.g. v0.0.0-20240101120000-abcdef123456.
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}`)
+
+// isRelease reports whether version is a tagged release of gha-docs.
+func isRelease(version string) bool {
+	return strings.HasPrefix(version, "v") && !strings.Contains(version, "+") && !pseudoVersion.MatchString(version)
+}
+
+func init() {
+	hooksInstallCmd.Flags().Bool("force", false, "Replace a pre-commit hook that gha-docs did not install")
+	hooksInstallCmd.Flags().Bool("pre-commit", false, "Add the hook to .pre-commit-config.yaml for the pre-commit framework instead")
+	hooksInstallCmd.Flags().String("rev", "", "Revision of gha-docs the pre-commit framework runs (defaults to the version of gha-docs)")
+	hooksCmd.AddCommand(hooksInstallCmd)
+	rootCmd.AddCommand(hooksCmd)
+}
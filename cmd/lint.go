@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/lint"
+	"github.com/spf13/cobra"
+)
+
+// lintCmd represents the lint command
+var lintCmd = &cobra.Command{
+	Use:   "lint",
+	Short: "Check GitHub Actions workflows against lint rules",
+	Long: `Check the workflows in a directory against lint rules, reporting each
+violation with its file and line.
+
+Rules:
+- unpinned-action: actions and reusable workflows referenced by a tag or
+  branch, which can change under the workflow, rather than a full commit SHA.
+  Owners or repositories of --trusted-owners, e.g. actions or
+  docker/build-push-action, are allowed mutable refs.
+- deprecated-runner: jobs running on runner images GitHub deprecated or
+  retired, e.g. ubuntu-18.04 or macos-11, including those of the matrix.
+- deprecated-command: run steps printing the deprecated set-output,
+  save-state, set-env, or add-path workflow commands instead of writing to
+  the files of $GITHUB_OUTPUT, $GITHUB_STATE, $GITHUB_ENV, or $GITHUB_PATH.
+- write-all-permissions: workflows or jobs with "permissions: write-all".
+- missing-permissions: jobs without permissions of their own or of the
+  workflow, which inherit the default permissions of the repository. It is a
+  warning by default, which --severity missing-permissions=error enforces.
+- missing-timeout: jobs without timeout-minutes, which run for up to six
+  hours when a step hangs, burning runner minutes. With
+  --max-timeout-minutes, jobs whose timeout-minutes exceed it are reported
+  too.
+
+Every rule has a default severity, which --severity overrides with
+RULE=SEVERITY, e.g. --severity unpinned-action=warning. Severities are error,
+warning, or off, which disables the rule. Both flags can be set in the
+configuration file, e.g.
+
+  lint:
+    trusted-owners: [actions, github]
+    severity: [unpinned-action=warning]
+    max-timeout-minutes: 60
+
+gha-docs exits with code 3 if any violation is an error. Workflow files that
+fail to parse are skipped with a warning and exit code 4.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		trustedOwners, _ := cmd.Flags().GetStringSlice("trusted-owners")
+		severitySpecs, _ := cmd.Flags().GetStringSlice("severity")
+		extensions, _ := cmd.Flags().GetStringSlice("extensions")
+		maxTimeout, _ := cmd.Flags().GetInt("max-timeout-minutes")
+
+		severities, err := lint.ParseSeverities(severitySpecs)
+		if err != nil {
+			return commandError("Error linting workflows", err)
+		}
+		opts := lint.Options{
+			Severities:        severities,
+			TrustedOwners:     trustedOwners,
+			MaxTimeoutMinutes: maxTimeout,
+			ScanOptions:       generate.ScanOptions{Extensions: extensions},
+		}
+
+		violations, parseErrors, err := lint.Lint(workflowDir, opts)
+		if err != nil {
+			return commandError("Error linting workflows", err)
+		}
+		for _, parseError := range parseErrors {
+			slog.Warn("Skipped workflow file", "file", parseError.Filename, "error", parseError.Err)
+		}
+
+		content, err := lint.Render(violations, format)
+		if err != nil {
+			return commandError("Error linting workflows", err)
+		}
+		if format == lint.FormatText && (output == "" || output == generate.StdoutOutput) {
+			color, err := useColor(cmd, os.Stdout)
+			if err != nil {
+				return commandError("Error linting workflows", err)
+			}
+			if color {
+				content = colorSeverities(content)
+			}
+		}
+		if err := writeOutput(content, output); err != nil {
+			return commandError("Error linting workflows", err)
+		}
+
+		if count := lint.Errors(violations); count > 0 {
+			return &codeError{Code: exitLint, Message: "Workflows violate lint rules", Err: fmt.Errorf("%d violations are errors", count)}
+		}
+		if len(parseErrors) > 0 {
+			return &codeError{Code: exitParseErrors, Message: "Workflow files were skipped", Err: fmt.Errorf("%d workflow files failed to parse", len(parseErrors))}
+		}
+		return nil
+	},
+}
+
+// colorSeverities colors the severities of lint violations in text format:
+// errors red and warnings yellow.
+func colorSeverities(content string) string {
+	content = strings.ReplaceAll(content, ": "+string(lint.SeverityError)+": ", ": "+colorize(string(lint.SeverityError), ansiRed)+": ")
+	return strings.ReplaceAll(content, ": "+string(lint.SeverityWarning)+": ", ": "+colorize(string(lint.SeverityWarning), ansiYellow)+": ")
+}
+
+func init() {
+	lintCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	lintCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	lintCmd.Flags().StringP("format", "f", lint.FormatText, "Output format: text or json")
+	lintCmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions([]string{lint.FormatText, lint.FormatJSON}, cobra.ShellCompDirectiveNoFileComp))
+	lintCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	lintCmd.Flags().StringSlice("trusted-owners", nil, "Owners or owner/repo repositories whose actions may be pinned to tags or branches")
+	lintCmd.Flags().StringSlice("severity", nil, "Severity of a rule as RULE=SEVERITY, with severity error, warning, or off")
+	lintCmd.Flags().Int("max-timeout-minutes", 0, "Longest timeout-minutes jobs may set (0 for no maximum)")
+	lintCmd.Flags().StringSlice("extensions", generate.DefaultExtensions, "Extensions of the workflow files, matched in any case")
+	rootCmd.AddCommand(lintCmd)
+}
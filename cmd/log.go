@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Formats of --log-format.
+const (
+	logFormatText = "text"
+	logFormatJSON = "json"
+)
+
+// logLevels are the levels of --log-level by name.
+var logLevels = map[string]slog.Level{
+	"debug": slog.LevelDebug,
+	"info":  slog.LevelInfo,
+	"warn":  slog.LevelWarn,
+	"error": slog.LevelError,
+}
+
+// setupLogging makes the default slog logger write to stderr at the level of
+// --log-level, in the format of --log-format. --quiet and --verbose override
+// the level with warn and debug. Text logs leave out the time, which the
+// terminal or CI runner already shows, and color warnings and errors as
+// --color says.
+func setupLogging(cmd *cobra.Command) error {
+	levelName, _ := cmd.Flags().GetString("log-level")
+	format, _ := cmd.Flags().GetString("log-format")
+	quiet, _ := cmd.Flags().GetBool("quiet")
+	verbose, _ := cmd.Flags().GetBool("verbose")
+
+	level, ok := logLevels[strings.ToLower(levelName)]
+	if !ok {
+		return fmt.Errorf("invalid log level %q, expected debug, info, warn, or error", levelName)
+	}
+	switch {
+	case quiet && verbose:
+		return fmt.Errorf("--quiet and --verbose cannot be combined")
+	case quiet:
+		level = slog.LevelWarn
+	case verbose:
+		level = slog.LevelDebug
+	}
+	var w io.Writer = os.Stderr
+	color, err := useColor(cmd, os.Stderr)
+	if err != nil {
+		return err
+	}
+	if color && format == logFormatText {
+		w = colorLogWriter{w}
+	}
+
+	handler, err := newLogHandler(level, format, w)
+	if err != nil {
+		return err
+	}
+	slog.SetDefault(slog.New(handler))
+	return nil
+}
+
+// newLogHandler returns the handler writing logs of level and above to w in
+// format.
+func newLogHandler(level slog.Level, format string, w io.Writer) (slog.Handler, error) {
+	options := &slog.HandlerOptions{Level: level}
+	switch format {
+	case logFormatText:
+		options.ReplaceAttr = func(groups []string, attr slog.Attr) slog.Attr {
+			if len(groups) == 0 && attr.Key == slog.TimeKey {
+				return slog.Attr{}
+			}
+			return attr
+		}
+		return slog.NewTextHandler(w, options), nil
+	case logFormatJSON:
+		return slog.NewJSONHandler(w, options), nil
+	default:
+		return nil, fmt.Errorf("invalid log format %q, expected %s or %s", format, logFormatText, logFormatJSON)
+	}
+}
+
+// startCommand sets up the logging and profiling of every command.
+func startCommand(cmd *cobra.Command) error {
+	if err := setupLogging(cmd); err != nil {
+		return err
+	}
+	return startProfile(cmd)
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/merge"
+	"github.com/spf13/cobra"
+)
+
+// mergeCmd represents the merge command
+var mergeCmd = &cobra.Command{
+	Use:   "merge <file.json>...",
+	Short: "Merge JSON outputs from several sources into one report",
+	Long: `Combine the JSON outputs of "generate --format json" from different
+repositories or directories into a single aggregated markdown report.
+
+The report starts with an overview of the workflow count per source, followed
+by the workflow table of every source.
+
+Output is written to stdout unless an output file is specified.`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+
+		documents, err := merge.Load(args)
+		if err != nil {
+			return commandError("Error merging reports", err)
+		}
+
+		err = writeOutput(merge.Render(documents), output)
+		if err != nil {
+			return commandError("Error merging reports", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	mergeCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(mergeCmd)
+}
@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/metrics"
+	"github.com/spf13/cobra"
+)
+
+// metricsCmd represents the metrics command
+var metricsCmd = &cobra.Command{
+	Use:   "metrics",
+	Short: "Export workflow run metrics from the GitHub API",
+	Long: `Fetch the most recent runs of every workflow in a directory from the GitHub
+Actions API and export per-workflow run metrics: run, success, and failure
+counts, success rate, average and median duration, and the latest run.
+
+Supported formats:
+- grafana: a Grafana JSON datasource table response
+- prometheus: the Prometheus text exposition format
+
+With --pushgateway, the metrics are pushed to a Prometheus Pushgateway instead
+of being written out.
+
+The API token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+		repo, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		runs, _ := cmd.Flags().GetInt("runs")
+		pushgateway, _ := cmd.Flags().GetString("pushgateway")
+
+		owner, name, err := github.ParseRepo(repo)
+		if err != nil {
+			return commandError("Error exporting workflow metrics", err)
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error exporting workflow metrics", err)
+		}
+
+		client := newClient(cmd, apiURL)
+		stats, err := metrics.Collect(cmd.Context(), client, owner, name, workflows, runs)
+		if err != nil {
+			return commandError("Error exporting workflow metrics", err)
+		}
+
+		if pushgateway != "" {
+			err = metrics.Push(cmd.Context(), pushgateway, metrics.RenderPrometheus(repo, stats))
+			if err != nil {
+				return commandError("Error exporting workflow metrics", err)
+			}
+			slog.Info("Successfully pushed metrics", "pushgateway", pushgateway)
+			return nil
+		}
+
+		var content string
+		switch format {
+		case metrics.FormatGrafana:
+			content, err = metrics.RenderGrafana(stats)
+		case metrics.FormatPrometheus:
+			content = metrics.RenderPrometheus(repo, stats)
+		default:
+			err = fmt.Errorf("unsupported metrics format %q", format)
+		}
+		if err != nil {
+			return commandError("Error exporting workflow metrics", err)
+		}
+
+		err = writeOutput(content, output)
+		if err != nil {
+			return commandError("Error exporting workflow metrics", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	metricsCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	metricsCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	metricsCmd.Flags().StringP("output", "o", "", "Output file for the metrics (defaults to stdout)")
+	metricsCmd.Flags().StringP("format", "f", metrics.FormatGrafana, "Metrics format: grafana or prometheus")
+	metricsCmd.Flags().StringP("repo", "r", "", "GitHub repository (owner/name) the workflows belong to")
+	metricsCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	metricsCmd.Flags().Int("runs", 20, "Number of recent runs per workflow to consider (max 100)")
+	metricsCmd.Flags().String("pushgateway", "", "Prometheus Pushgateway URL to push the metrics to")
+	metricsCmd.MarkFlagRequired("repo")
+	rootCmd.AddCommand(metricsCmd)
+}
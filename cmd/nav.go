@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/nav"
+	"github.com/spf13/cobra"
+)
+
+// navCmd represents the nav command
+var navCmd = &cobra.Command{
+	Use:   "nav",
+	Short: "Generate docs site navigation for per-workflow pages",
+	Long: `Generate navigation entries for the per-workflow pages written by
+"gha-docs generate --pages-dir", so that they can be added to a docs site.
+
+Supported formats:
+- mkdocs: a nav: YAML fragment for mkdocs.yml
+- docusaurus: a sidebars.json document with a category of workflow pages
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDirs, _ := cmd.Flags().GetStringSlice("workflows")
+		if len(workflowDirs) == 0 {
+			dir, err := detectWorkflowsDir()
+			if err != nil {
+				return commandError("Error generating navigation", err)
+			}
+			workflowDirs = []string{dir}
+		}
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+		pagesDir, _ := cmd.Flags().GetString("pages-dir")
+		docsDir, _ := cmd.Flags().GetString("docs-dir")
+		index, _ := cmd.Flags().GetString("index")
+		section, _ := cmd.Flags().GetString("section")
+
+		// Scan like generate does, so that the workflows of several
+		// directories point to the subdirectories of their pages
+		opts := generate.Options{WorkflowsDir: workflowDirs[0]}
+		if len(workflowDirs) > 1 {
+			opts.WorkflowsDirs = workflowDirs
+		}
+		workflows, parseErrors, err := opts.ScanWorkflows(cmd.Context())
+		if err != nil {
+			return commandError("Error generating navigation", err)
+		}
+		for _, parseError := range parseErrors {
+			slog.Warn("Error parsing workflow file", "file", parseError.Filename, "error", parseError.Err)
+		}
+
+		entries, err := nav.Entries(workflows, pagesDir, docsDir, index)
+		if err != nil {
+			return commandError("Error generating navigation", err)
+		}
+
+		content, err := nav.Render(entries, section, format)
+		if err != nil {
+			return commandError("Error generating navigation", err)
+		}
+
+		err = writeOutput(content, output)
+		if err != nil {
+			return commandError("Error generating navigation", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	navCmd.Flags().StringSliceP("workflows", "w", nil, "Directories containing GitHub workflow files, as given to generate (defaults to .github/workflows of the repository)")
+	navCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	navCmd.Flags().StringP("output", "o", "", "Output file for the navigation (defaults to stdout)")
+	navCmd.Flags().StringP("format", "f", nav.FormatMkDocs, "Navigation format: mkdocs or docusaurus")
+	navCmd.Flags().String("pages-dir", "docs/workflows", "Directory containing the per-workflow pages")
+	navCmd.Flags().String("docs-dir", "docs", "Root directory of the docs site")
+	navCmd.Flags().String("index", "", "Optional summary page to list first as an overview")
+	navCmd.Flags().String("section", "Workflows", "Title of the navigation section")
+	rootCmd.AddCommand(navCmd)
+}
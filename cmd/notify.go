@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"errors"
+	"log/slog"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/notify"
+	"github.com/spf13/cobra"
+)
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify",
+	Short: "Post workflow documentation changes to a Slack or Teams webhook",
+	Long: `Compare the workflows in a directory with the state recorded by the previous
+run and post a summary of the added, removed, and modified workflows to a
+Slack or Microsoft Teams incoming webhook. The state file is then updated, so
+every change is posted once. The first run only records the state.
+
+The webhook type is detected from its URL unless --type is given. Like every
+flag, the webhook URL can also be set with the GHADOC_WEBHOOK_URL environment
+variable, to keep it out of command lines.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		state, _ := cmd.Flags().GetString("state")
+		webhookURL, _ := cmd.Flags().GetString("webhook-url")
+		webhookType, _ := cmd.Flags().GetString("type")
+
+		if webhookURL == "" {
+			return commandError("Error notifying about workflow changes", errors.New("--webhook-url or GHADOC_WEBHOOK_URL is required"))
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error notifying about workflow changes", err)
+		}
+
+		previous, ok, err := notify.LoadState(state)
+		if err != nil {
+			return commandError("Error notifying about workflow changes", err)
+		}
+
+		if changes := diff.Diff(previous, workflows); ok && len(changes) > 0 {
+			err = notify.Post(cmd.Context(), webhookURL, webhookType, notify.Summary(changes, workflowDir))
+			if err != nil {
+				return commandError("Error notifying about workflow changes", err)
+			}
+			slog.Info("Successfully posted workflow changes", "changes", len(changes))
+		} else if ok {
+			slog.Info("No workflow changes")
+		}
+
+		err = notify.SaveState(state, workflowDir, workflows)
+		if err != nil {
+			return commandError("Error notifying about workflow changes", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	notifyCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	notifyCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	notifyCmd.Flags().String("state", ".gha-docs-state.json", "File recording the workflows of the previous run")
+	notifyCmd.Flags().String("webhook-url", "", "URL of the Slack or Teams incoming webhook")
+	notifyCmd.Flags().String("type", notify.TypeAuto, "Webhook type: auto, slack, or teams")
+	rootCmd.AddCommand(notifyCmd)
+}
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/org"
+	"github.com/droctothorpe/gha-docs/internal/remote"
+	"github.com/spf13/cobra"
+)
+
+// orgCmd represents the org command
+var orgCmd = &cobra.Command{
+	Use:   "org <organization>",
+	Short: "Document the GitHub Actions workflows of an entire organization",
+	Long: `Fetch the GitHub Actions workflows of every repository in an organization
+through the GitHub API and generate a consolidated report grouped by
+repository: an overview of the workflow count and triggers of every repository
+and of trigger usage across the organization, followed by a workflow table per
+repository. With --format json, the report is written as JSON instead.
+
+Repositories can be filtered before scanning to keep fleet scans scoped:
+- --topic: only repositories with all of the given topics
+- --visibility: only public, private, or internal repositories
+- --name: only repositories whose name matches one of the glob patterns
+- --include-archived: also scan archived repositories, which are skipped by default
+
+Repositories are scanned concurrently (--concurrency) with a time limit per
+repository (--timeout). A repository that fails to scan does not stop the
+scan; it is listed with the reason in a failed repositories section instead.
+
+The API token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.
+Output is written to stdout unless an output file is specified.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		format, _ := cmd.Flags().GetString("format")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+		topics, _ := cmd.Flags().GetStringSlice("topic")
+		visibility, _ := cmd.Flags().GetString("visibility")
+		names, _ := cmd.Flags().GetStringSlice("name")
+		includeArchived, _ := cmd.Flags().GetBool("include-archived")
+		concurrency, _ := cmd.Flags().GetInt("concurrency")
+		timeout, _ := cmd.Flags().GetDuration("timeout")
+
+		filter := org.Filter{
+			Topics:          topics,
+			Visibility:      visibility,
+			IncludeArchived: includeArchived,
+			NamePatterns:    names,
+		}
+
+		client := newClient(cmd, apiURL)
+		results, err := org.Scan(cmd.Context(), client, args[0], filter, org.ScanOptions{
+			WorkflowsDir: workflowDir,
+			Concurrency:  concurrency,
+			Timeout:      timeout,
+			Cache:        newWorkflowCache(cmd),
+		})
+		if err != nil {
+			return commandError("Error scanning organization", err)
+		}
+
+		var content string
+		switch format {
+		case org.FormatMarkdown:
+			content = org.Render(args[0], results, workflowDir)
+		case org.FormatJSON:
+			content, err = org.RenderJSON(args[0], results, workflowDir)
+		default:
+			err = fmt.Errorf("unsupported report format %q", format)
+		}
+		if err != nil {
+			return commandError("Error scanning organization", err)
+		}
+
+		err = writeOutput(content, output)
+		if err != nil {
+			return commandError("Error scanning organization", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	orgCmd.Flags().StringP("workflows", "w", remote.DefaultWorkflowsDir, "Directory containing GitHub workflow files in each repository")
+	orgCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	orgCmd.Flags().StringP("format", "f", org.FormatMarkdown, "Report format: markdown or json")
+	orgCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	orgCmd.Flags().StringSlice("topic", nil, "Only scan repositories with this topic (repeatable)")
+	orgCmd.Flags().String("visibility", org.VisibilityAll, "Only scan repositories with this visibility: all, public, private, or internal")
+	orgCmd.Flags().StringSlice("name", nil, "Only scan repositories whose name matches this glob pattern (repeatable)")
+	orgCmd.Flags().Bool("include-archived", false, "Also scan archived repositories")
+	orgCmd.Flags().Int("concurrency", org.DefaultConcurrency, "Number of repositories to scan at once")
+	orgCmd.Flags().Duration("timeout", org.DefaultTimeout, "Time allowed to scan each repository")
+	rootCmd.AddCommand(orgCmd)
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// writeOutput writes content to the output file, or to stdout if output is
+// empty or "-".
+func writeOutput(content, output string) error {
+	if output == "" || output == generate.StdoutOutput {
+		fmt.Print(content)
+		return nil
+	}
+
+	err := os.WriteFile(output, []byte(content), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing to output file: %v", err)
+	}
+
+	slog.Info("Successfully generated", "path", output)
+	return nil
+}
@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/outputs"
+	"github.com/spf13/cobra"
+)
+
+// outputsCmd represents the outputs command
+var outputsCmd = &cobra.Command{
+	Use:   "outputs",
+	Short: "Report unused and undeclared workflow, job, and step outputs",
+	Long: `Analyze the outputs of the workflows in a directory and report declared
+outputs that nothing consumes, and consumed outputs that are never declared.
+
+Step outputs are detected from writes to $GITHUB_OUTPUT in run steps, job
+outputs from the outputs of each job, and workflow outputs from the
+workflow_call trigger of reusable workflows called by other workflows in the
+directory. Outputs of actions are not known, so they are never reported as
+undeclared.
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowsDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+
+		findings, err := outputs.Analyze(workflowsDir)
+		if err != nil {
+			return commandError("Error analyzing outputs", err)
+		}
+
+		err = writeOutput(outputs.Render(findings), output)
+		if err != nil {
+			return commandError("Error analyzing outputs", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	outputsCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing workflow files")
+	outputsCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	outputsCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(outputsCmd)
+}
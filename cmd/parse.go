@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/spf13/cobra"
+)
+
+// parseCmd represents the parse command
+var parseCmd = &cobra.Command{
+	Use:   "parse <file>",
+	Short: "Dump everything parsed from a single workflow file",
+	Long: `Parse a single GitHub Actions workflow file and print everything extracted
+from it: the description, triggers and their filters, schedules, permissions,
+environments, secrets, jobs and steps, and the metadata block.
+
+This is useful for debugging why a particular workflow renders the way it does
+in the generated documentation. The description is read as the generate
+command does, with the same --description-from, --preamble-lines, and
+--comment-prefix flags.
+
+The output is YAML unless --format json is set.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeWorkflowFiles,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		descriptionFrom, _ := cmd.Flags().GetStringSlice("description-from")
+		preambleLines, _ := cmd.Flags().GetInt("preamble-lines")
+		commentPrefix, _ := cmd.Flags().GetString("comment-prefix")
+
+		content, err := os.ReadFile(args[0])
+		if err != nil {
+			return commandError("Error parsing workflow", err)
+		}
+
+		workflow, err := generate.ParseWorkflowWithOptions(content, generate.ParseOptions{
+			DescriptionFrom: descriptionFrom,
+			PreambleLines:   preambleLines,
+			CommentPrefix:   commentPrefix,
+		})
+		if err != nil {
+			return commandError("Error parsing workflow", err)
+		}
+		workflow.Filename = filepath.Base(args[0])
+		if (generate.ScanOptions{}).IsDisabledFile(workflow.Filename) {
+			workflow.Disabled = true
+		}
+
+		dump, err := generate.Dump(workflow, format)
+		if err != nil {
+			return commandError("Error parsing workflow", err)
+		}
+		fmt.Print(dump)
+		return nil
+	},
+}
+
+func init() {
+	parseCmd.Flags().StringP("format", "f", generate.DumpYAML, "Output format: yaml or json")
+	parseCmd.Flags().StringSlice("description-from", generate.DefaultDescriptionFrom, "Sources of the description in order of preference: comments, key, marker, name, or job")
+	parseCmd.Flags().Int("preamble-lines", 20, "Number of blank, comment, and --- lines that may precede the ## doc comments")
+	parseCmd.Flags().String("comment-prefix", generate.DefaultCommentPrefix, "Prefix of the doc comment lines holding the description, e.g. \"# @doc\"")
+	rootCmd.AddCommand(parseCmd)
+}
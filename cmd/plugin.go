@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+
+	"github.com/droctothorpe/gha-docs/internal/plugins"
+	"github.com/spf13/cobra"
+)
+
+// pluginCmd represents the plugin command
+var pluginCmd = &cobra.Command{
+	Use:   "plugin",
+	Short: "Work with gha-docs plugins",
+	Long: `gha-docs can be extended with plugins: executables on your PATH named
+ghadoc-<name>. Running "gha-docs <name> [args]" runs the plugin with the
+remaining arguments when gha-docs has no <name> command, so
+"gha-docs audit --org octo-org" runs "ghadoc-audit --org octo-org".`,
+}
+
+// pluginListCmd represents the plugin list command
+var pluginListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the plugins on your PATH",
+	Run: func(cmd *cobra.Command, args []string) {
+		found := plugins.List(os.Getenv("PATH"))
+		if len(found) == 0 {
+			slog.Info("No plugins found on PATH")
+			return
+		}
+		for _, plugin := range found {
+			fmt.Printf("%s\t%s\n", plugin.Name, plugin.Path)
+		}
+	},
+}
+
+// runPlugin runs the plugin providing the subcommand args[0] with the
+// remaining arguments, if gha-docs has no such command. It reports whether a
+// plugin ran, and its exit code.
+func runPlugin(ctx context.Context, args []string) (bool, int) {
+	if len(args) == 0 {
+		return false, 0
+	}
+	if found, _, err := rootCmd.Find(args); err == nil && found != rootCmd {
+		return false, 0
+	}
+	plugin, ok := plugins.Find(args[0])
+	if !ok {
+		return false, 0
+	}
+
+	command := exec.CommandContext(ctx, plugin.Path, args[1:]...)
+	command.Stdin, command.Stdout, command.Stderr = os.Stdin, os.Stdout, os.Stderr
+	err := command.Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return true, exitErr.ExitCode()
+	}
+	if err != nil {
+		slog.Error("Error running plugin", "plugin", plugin.Path, "error", err)
+		return true, 1
+	}
+	return true, 0
+}
+
+func init() {
+	pluginCmd.AddCommand(pluginListCmd)
+	rootCmd.AddCommand(pluginCmd)
+}
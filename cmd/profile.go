@@ -0,0 +1,35 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"runtime/pprof"
+
+	"github.com/spf13/cobra"
+)
+
+// stopProfile stops the CPU profile started for --profile, if any.
+var stopProfile = func() {}
+
+// startProfile starts writing a CPU profile of the command to the file of
+// --profile, for go tool pprof, if it is set.
+func startProfile(cmd *cobra.Command) error {
+	path, _ := cmd.Flags().GetString("profile")
+	if path == "" {
+		return nil
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("error creating profile: %v", err)
+	}
+	if err := pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("error starting profile: %v", err)
+	}
+	stopProfile = func() {
+		pprof.StopCPUProfile()
+		file.Close()
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/publish"
+	"github.com/spf13/cobra"
+)
+
+// publishCmd represents the publish command
+var publishCmd = &cobra.Command{
+	Use:   "publish",
+	Short: "Publish generated documentation to another system",
+	Long: `Publish generated markdown documentation to another system, so that it is
+updated in place on every CI run without manual copying.`,
+}
+
+// publishConfluenceCmd represents the publish confluence command
+var publishConfluenceCmd = &cobra.Command{
+	Use:   "confluence",
+	Short: "Publish generated documentation to a Confluence page",
+	Long: `Convert a generated markdown file to the Confluence storage format and
+replace the content of an existing Confluence page with it, creating a new
+version of the page.
+
+The API token is read from the CONFLUENCE_TOKEN environment variable. With
+--user, it is sent with basic authentication as Confluence Cloud expects for
+API tokens; otherwise it is sent as a Data Center personal access token.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input, _ := cmd.Flags().GetString("input")
+		baseURL, _ := cmd.Flags().GetString("url")
+		space, _ := cmd.Flags().GetString("space")
+		pageID, _ := cmd.Flags().GetString("page-id")
+		title, _ := cmd.Flags().GetString("title")
+		user, _ := cmd.Flags().GetString("user")
+
+		content, err := os.ReadFile(input)
+		if err != nil {
+			return commandError("Error publishing to Confluence", err)
+		}
+
+		confluence := publish.NewConfluence(baseURL, user, os.Getenv("CONFLUENCE_TOKEN"))
+		err = confluence.UpdatePage(cmd.Context(), space, pageID, title, string(content))
+		if err != nil {
+			return commandError("Error publishing to Confluence", err)
+		}
+
+		slog.Info("Successfully published to Confluence", "file", input, "page", pageID)
+		return nil
+	},
+}
+
+// publishWikiCmd represents the publish wiki command
+var publishWikiCmd = &cobra.Command{
+	Use:   "wiki",
+	Short: "Publish generated documentation as a GitHub wiki page",
+	Long: `Push a generated markdown file to a page of the GitHub wiki of a
+repository, so that the documentation lives in the wiki without manual
+copying. The page is committed and pushed only if it changed.
+
+The wiki's git repository is derived from --repo-url, or given with
+--wiki-url. The wiki must have at least one page, which GitHub only allows
+creating on the web. The token used to push over HTTPS is read from the
+GITHUB_TOKEN or GH_TOKEN environment variable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		input, _ := cmd.Flags().GetString("input")
+		repoURL, _ := cmd.Flags().GetString("repo-url")
+		wikiURL, _ := cmd.Flags().GetString("wiki-url")
+		page, _ := cmd.Flags().GetString("page")
+		message, _ := cmd.Flags().GetString("message")
+		authorName, _ := cmd.Flags().GetString("author-name")
+		authorEmail, _ := cmd.Flags().GetString("author-email")
+
+		if wikiURL == "" {
+			if repoURL == "" {
+				return commandError("Error publishing to the wiki", errors.New("--repo-url or --wiki-url is required"))
+			}
+			wikiURL = publish.WikiURL(repoURL)
+		}
+
+		content, err := os.ReadFile(input)
+		if err != nil {
+			return commandError("Error publishing to the wiki", err)
+		}
+
+		wiki := &publish.Wiki{
+			RemoteURL:   wikiURL,
+			Token:       github.TokenFromEnv(),
+			AuthorName:  authorName,
+			AuthorEmail: authorEmail,
+		}
+		changed, err := wiki.Publish(cmd.Context(), page, string(content), message)
+		if err != nil {
+			return commandError("Error publishing to the wiki", err)
+		}
+
+		if !changed {
+			slog.Info("Wiki page is up to date", "page", page)
+			return nil
+		}
+		slog.Info("Successfully published to the wiki", "file", input, "page", page)
+		return nil
+	},
+}
+
+// publishPRCommentCmd represents the publish pr-comment command
+var publishPRCommentCmd = &cobra.Command{
+	Use:   "pr-comment",
+	Short: "Comment on a pull request whether the documentation is up to date",
+	Long: `Regenerate the markdown documentation of the workflows and compare it with
+the committed documentation file. If they differ, a sticky comment with the
+changes and the command to regenerate the documentation is posted on the pull
+request, or the existing one is updated. Once the documentation is up to
+date, the comment is updated to say so.
+
+Run it on pull requests as a documentation freshness check. The API token is
+read from the GITHUB_TOKEN or GH_TOKEN environment variable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		docs, _ := cmd.Flags().GetString("docs")
+		repo, _ := cmd.Flags().GetString("repo")
+		pr, _ := cmd.Flags().GetInt("pr")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+
+		owner, name, err := github.ParseRepo(repo)
+		if err != nil {
+			return commandError("Error commenting on pull request", err)
+		}
+
+		regenerated, err := generate.RenderContext(cmd.Context(), generate.Options{WorkflowsDir: workflowDir, Output: docs})
+		if err != nil {
+			return commandError("Error commenting on pull request", err)
+		}
+
+		// A missing documentation file is stale like an outdated one
+		current, err := os.ReadFile(docs)
+		if err != nil && !os.IsNotExist(err) {
+			return commandError("Error commenting on pull request", err)
+		}
+
+		command := fmt.Sprintf("gha-docs generate -w %s -o %s", workflowDir, docs)
+		body, stale := publish.CommentBody(docs, string(current), regenerated, command)
+		// Comments are listed afresh, so that a comment posted moments ago
+		// is updated rather than posted again
+		client := newClient(cmd, apiURL)
+		if client.Cache != nil {
+			client.Cache.TTL = 0
+		}
+		posted, err := publish.UpsertComment(cmd.Context(), client, owner, name, pr, body, stale)
+		if err != nil {
+			return commandError("Error commenting on pull request", err)
+		}
+
+		if stale {
+			fmt.Println(docs, "is out of date")
+		} else {
+			fmt.Println(docs, "is up to date")
+		}
+		if posted {
+			slog.Info("Successfully commented on pull request", "pr", pr)
+		}
+		return nil
+	},
+}
+
+func init() {
+	publishConfluenceCmd.Flags().StringP("input", "i", "./workflows.md", "Markdown file to publish")
+	publishConfluenceCmd.Flags().String("url", "", "Base URL of the Confluence site, e.g. https://example.atlassian.net/wiki")
+	publishConfluenceCmd.Flags().String("space", "", "Key of the space the page must be in")
+	publishConfluenceCmd.Flags().String("page-id", "", "ID of the page to update")
+	publishConfluenceCmd.Flags().String("title", "", "New title of the page (defaults to the current title)")
+	publishConfluenceCmd.Flags().String("user", "", "Email of the Confluence Cloud user the API token belongs to")
+	publishConfluenceCmd.MarkFlagRequired("url")
+	publishConfluenceCmd.MarkFlagRequired("page-id")
+	publishCmd.AddCommand(publishConfluenceCmd)
+
+	publishWikiCmd.Flags().StringP("input", "i", "./workflows.md", "Markdown file to publish")
+	publishWikiCmd.Flags().String("repo-url", "", "URL of the repository on GitHub, e.g. https://github.com/owner/repo")
+	publishWikiCmd.Flags().String("wiki-url", "", "URL of the wiki's git repository (defaults to the wiki of --repo-url)")
+	publishWikiCmd.Flags().String("page", "GitHub Workflows", "Title of the wiki page")
+	publishWikiCmd.Flags().String("message", "Update workflow documentation", "Message of the commit updating the page")
+	publishWikiCmd.Flags().String("author-name", publish.DefaultAuthorName, "Author name of the commit")
+	publishWikiCmd.Flags().String("author-email", publish.DefaultAuthorEmail, "Author email of the commit")
+	publishCmd.AddCommand(publishWikiCmd)
+
+	publishPRCommentCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	publishPRCommentCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	publishPRCommentCmd.Flags().String("docs", "./workflows.md", "Committed documentation file to check")
+	publishPRCommentCmd.Flags().StringP("repo", "r", "", "GitHub repository (owner/name) of the pull request")
+	publishPRCommentCmd.Flags().Int("pr", 0, "Number of the pull request to comment on")
+	publishPRCommentCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	publishPRCommentCmd.MarkFlagRequired("repo")
+	publishPRCommentCmd.MarkFlagRequired("pr")
+	publishCmd.AddCommand(publishPRCommentCmd)
+	rootCmd.AddCommand(publishCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/report"
+	"github.com/spf13/cobra"
+)
+
+// reportCmd represents the report command
+var reportCmd = &cobra.Command{
+	Use:   "report <" + strings.Join(report.Names(), "|") + ">",
+	Short: "Generate an analysis report of GitHub Actions workflows",
+	Long: `Generate an analysis report of the GitHub Actions workflows in a directory.
+
+Available reports:
+- actions: inventory of the actions and reusable workflows used, by ref
+- runners: runner labels used by jobs, with job counts
+- schedules: cron schedules of scheduled workflows
+- secrets: secrets referenced by each workflow
+
+Every report can be exported as markdown, CSV, or JSON with --format so it
+can be handed to its downstream owners individually. Reports are written to
+stdout unless --output is set.`,
+	Args:      cobra.ExactArgs(1),
+	ValidArgs: report.Names(),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+
+		build, ok := report.Reports[args[0]]
+		if !ok {
+			return commandError("Error generating report", fmt.Errorf("unknown report %q, expected one of %s", args[0], strings.Join(report.Names(), ", ")))
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error generating report", err)
+		}
+
+		content, err := build(workflows).Render(format)
+		if err != nil {
+			return commandError("Error generating report", err)
+		}
+
+		err = writeOutput(content, output)
+		if err != nil {
+			return commandError("Error generating report", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	reportCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	reportCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	reportCmd.Flags().StringP("format", "f", report.FormatMarkdown, "Output format: markdown, csv, or json")
+	reportCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(reportCmd)
+}
@@ -1,11 +1,33 @@
 package cmd
 
 import (
+	"context"
+	"log/slog"
 	"os"
+	"os/signal"
+	"runtime/debug"
+	"syscall"
 
+	"github.com/droctothorpe/gha-docs/internal/github"
 	"github.com/spf13/cobra"
 )
 
+// version is the version of gha-docs, set at build time with
+// -ldflags "-X github.com/droctothorpe/gha-docs/cmd.version=v1.2.3".
+var version string
+
+// buildVersion returns the version of gha-docs: the one set at build time,
+// else the module version of go install, else "dev".
+func buildVersion() string {
+	if version != "" {
+		return version
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" {
+		return info.Main.Version
+	}
+	return "dev"
+}
+
 // rootCmd represents the base command when called without any subcommands
 var rootCmd = &cobra.Command{
 	Use:   "gha-docs",
@@ -13,25 +35,71 @@ var rootCmd = &cobra.Command{
 	Long: `gha-docs is a command-line tool that parses GitHub Actions workflow files
 and generates markdown documentation summarizing their key properties.
 
-Use the generate command to create a markdown table of all workflows in a directory.`,
+Use the generate command to create a markdown table of all workflows in a directory.
+
+Exit codes:
+  0  Success
+  1  The command failed, e.g. documentation could not be generated
+  2  generate --check found the documentation out of date
+  3  Workflows violate lint rules
+  4  Workflow files failed to parse`,
+	// Errors are logged by Execute, without the usage, which --help shows
+	SilenceErrors: true,
+	SilenceUsage:  true,
 	// Uncomment the following line if your bare application
 	// has an action associated with it:
 	// Run: func(cmd *cobra.Command, args []string) { },
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := applyConfig(cmd); err != nil {
+			return err
+		}
+		return startCommand(cmd)
+	},
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 // This is called by main.main(). It only needs to happen once to the rootCmd.
+// Interrupting gha-docs cancels the context of the running command, so that
+// long scans stop cleanly. Unknown commands run the ghadoc-<name> plugin on
+// PATH if there is one. Errors are logged, and end gha-docs with the exit
+// code of their failure class.
 func Execute() {
-	err := rootCmd.Execute()
-	if err != nil {
-		os.Exit(1)
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if ran, code := runPlugin(ctx, os.Args[1:]); ran {
+		stop()
+		os.Exit(code)
 	}
+
+	// Errors before the logging of the command is set up, such as unknown
+	// flags, are logged as text
+	handler, _ := newLogHandler(slog.LevelInfo, logFormatText, os.Stderr)
+	slog.SetDefault(slog.New(handler))
+
+	err := rootCmd.ExecuteContext(ctx)
+	stopProfile()
+	stop()
+	exit(err)
 }
 
 func init() {
+	rootCmd.Version = buildVersion()
+
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	// rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is $HOME/.gha-docs.yaml)")
+	rootCmd.PersistentFlags().String("config", "", "Config file (defaults to .ghadoc.yaml in the current directory)")
+
+	rootCmd.PersistentFlags().Bool("no-cache", false, "Do not cache GitHub API responses or parsed workflows")
+	rootCmd.PersistentFlags().Duration("cache-ttl", github.DefaultCacheTTL, "Time cached GitHub API responses are used without a request")
+	rootCmd.PersistentFlags().String("log-level", "info", "Level of the messages logged to stderr: debug, info, warn, or error")
+	rootCmd.PersistentFlags().BoolP("quiet", "q", false, "Only log warnings and errors, not the files written")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Also log every workflow file parsed and how long each step took")
+	rootCmd.PersistentFlags().String("log-format", logFormatText, "Format of the messages logged to stderr: text or json")
+	rootCmd.PersistentFlags().String("color", colorAuto, "Color warnings, errors, and diffs: auto (in terminals unless NO_COLOR is set), always, or never")
+	rootCmd.RegisterFlagCompletionFunc("color", cobra.FixedCompletions([]string{colorAuto, colorAlways, colorNever}, cobra.ShellCompDirectiveNoFileComp))
+	rootCmd.PersistentFlags().String("profile", "", "Write a CPU profile of the command to this file, for go tool pprof")
+	rootCmd.PersistentFlags().Duration("request-timeout", github.DefaultTimeout, "Time allowed for each GitHub API request")
 }
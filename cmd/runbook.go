@@ -0,0 +1,49 @@
+package cmd
+
+import (
+	"log/slog"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/runbook"
+	"github.com/spf13/cobra"
+)
+
+// runbookCmd represents the runbook command
+var runbookCmd = &cobra.Command{
+	Use:   "runbook",
+	Short: "Generate an operational runbook per workflow",
+	Long: `Generate a detailed operational document for every GitHub Actions workflow in
+a directory, one markdown page per workflow.
+
+Each runbook covers:
+- What it does: the workflow's description
+- When it runs: triggers with their branch, tag, path, and type filters and schedules
+- Required secrets: secrets referenced by the workflow
+- Environments: deployment environments used by its jobs
+- Owners: the owner or owners key of the workflow's metadata block
+- Troubleshooting: the troubleshooting and links keys of the metadata block`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error generating runbooks", err)
+		}
+
+		err = runbook.Generate(workflows, workflowDir, output)
+		if err != nil {
+			return commandError("Error generating runbooks", err)
+		}
+
+		slog.Info("Successfully generated runbooks", "dir", output)
+		return nil
+	},
+}
+
+func init() {
+	runbookCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	runbookCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	runbookCmd.Flags().StringP("output", "o", "docs/runbooks", "Directory to write the runbooks into")
+	rootCmd.AddCommand(runbookCmd)
+}
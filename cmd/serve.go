@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"log/slog"
+	"os"
+
+	"github.com/droctothorpe/gha-docs/internal/server"
+	"github.com/spf13/cobra"
+)
+
+// serveCmd represents the serve command
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve workflow documentation over HTTP",
+	Long: `Run an HTTP server that answers questions about the GitHub Actions workflows
+in a directory. Workflows are re-read on every request, so answers reflect the
+current state of the directory.
+
+Endpoints:
+- /healthz: liveness check
+- /slack/command: Slack slash command endpoint (requires a signing secret)
+
+The Slack slash command supports:
+- describe <workflow file>: the description and triggers of a workflow
+- which-workflows <path>: the workflows a change to path triggers
+
+The Slack signing secret is read from --slack-signing-secret or the
+SLACK_SIGNING_SECRET environment variable.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		addr, _ := cmd.Flags().GetString("addr")
+		signingSecret, _ := cmd.Flags().GetString("slack-signing-secret")
+		if signingSecret == "" {
+			signingSecret = os.Getenv("SLACK_SIGNING_SECRET")
+		}
+
+		if signingSecret == "" {
+			slog.Warn("No Slack signing secret configured; the Slack endpoint is disabled")
+		}
+
+		slog.Info("Serving workflow documentation", "addr", addr)
+		srv := server.New(workflowDir, signingSecret)
+		srv.Cache = newWorkflowCache(cmd)
+		err := srv.ListenAndServe(cmd.Context(), addr)
+		if err != nil {
+			return commandError("Error serving workflow documentation", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	serveCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	serveCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	serveCmd.Flags().String("addr", ":8080", "Address to listen on")
+	serveCmd.Flags().String("slack-signing-secret", "", "Slack signing secret used to verify slash command requests")
+	rootCmd.AddCommand(serveCmd)
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/storage"
+	"github.com/spf13/cobra"
+)
+
+// storageCmd represents the storage command
+var storageCmd = &cobra.Command{
+	Use:   "storage",
+	Short: "Report artifact and cache storage per workflow",
+	Long: `Query the Actions artifacts and caches APIs and report the storage they
+consume, attributed to the workflows that produced them, to help manage the
+repository's storage quota.
+
+Unexpired artifacts are attributed to the workflow of the run that uploaded
+them. Caches are not linked to runs, so they are attributed to the workflows
+whose actions/cache keys or setup actions (setup-go, setup-node, setup-python,
+and setup-java) produce matching keys. Storage that cannot be attributed to a
+workflow in the directory is reported as unattributed.
+
+The API token is read from the GITHUB_TOKEN or GH_TOKEN environment variable.
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		workflowDir, _ := cmd.Flags().GetString("workflows")
+		output, _ := cmd.Flags().GetString("output")
+		repo, _ := cmd.Flags().GetString("repo")
+		apiURL, _ := cmd.Flags().GetString("api-url")
+
+		owner, name, err := github.ParseRepo(repo)
+		if err != nil {
+			return commandError("Error reporting storage", err)
+		}
+
+		workflows, err := generate.ScanDir(workflowDir)
+		if err != nil {
+			return commandError("Error reporting storage", err)
+		}
+
+		report, err := storage.Collect(cmd.Context(), newClient(cmd, apiURL), owner, name, workflows)
+		if err != nil {
+			return commandError("Error reporting storage", err)
+		}
+
+		err = writeOutput(storage.Render(repo, report), output)
+		if err != nil {
+			return commandError("Error reporting storage", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	storageCmd.Flags().StringP("workflows", "w", ".github/workflows", "Directory containing GitHub workflow files")
+	storageCmd.RegisterFlagCompletionFunc("workflows", completeWorkflowsDirs)
+	storageCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	storageCmd.Flags().StringP("repo", "r", "", "GitHub repository (owner/name) the workflows belong to")
+	storageCmd.Flags().String("api-url", github.DefaultBaseURL, "Base URL of the GitHub API")
+	storageCmd.MarkFlagRequired("repo")
+	rootCmd.AddCommand(storageCmd)
+}
@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/templates"
+	"github.com/spf13/cobra"
+)
+
+// templatesCmd represents the templates command
+var templatesCmd = &cobra.Command{
+	Use:   "templates",
+	Short: "Generate documentation for issue and pull request templates",
+	Long: `Generate a markdown table of the issue and pull request templates of a
+repository.
+
+Issue templates and issue forms are read from .github/ISSUE_TEMPLATE/, and pull
+request templates from PULL_REQUEST_TEMPLATE.md in .github/, the repository
+root, or docs/, and from .github/PULL_REQUEST_TEMPLATE/.
+
+The table includes the following columns:
+- Template: Name of the template with a link to the file
+- Type: Issue template, issue form, or pull request template
+- Description: The about or description of the template
+- Labels: Labels added to issues created from the template
+- Assignees: Users assigned to issues created from the template
+
+Output is written to stdout unless an output file is specified.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		repoDir, _ := cmd.Flags().GetString("repo-dir")
+		output, _ := cmd.Flags().GetString("output")
+
+		found, err := templates.Scan(repoDir)
+		if err != nil {
+			return commandError("Error generating template documentation", err)
+		}
+
+		err = writeOutput(templates.Render(found, repoDir, output), output)
+		if err != nil {
+			return commandError("Error generating template documentation", err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	templatesCmd.Flags().StringP("repo-dir", "d", ".", "Root directory of the repository")
+	templatesCmd.Flags().StringP("output", "o", "", "Output file (defaults to stdout)")
+	rootCmd.AddCommand(templatesCmd)
+}
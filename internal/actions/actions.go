@@ -0,0 +1,261 @@
+package actions
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// inputPattern matches references to action inputs in expressions, as
+// inputs.name or inputs['name'].
+var inputPattern = regexp.MustCompile(`inputs(?:\.([A-Za-z_][A-Za-z0-9_-]*)|\[\s*['"]([^'"]+)['"]\s*\])`)
+
+// Action stores the input analysis of a local composite action.
+type Action struct {
+	Path        string // Directory of the action, relative to the scanned directory
+	Name        string
+	Description string
+	Inputs      []Input
+	Undeclared  []string // Inputs referenced by the action but not declared
+}
+
+// Input is a declared input of an action and where it is used.
+type Input struct {
+	Name        string
+	Description string
+	Required    bool
+	UsedBy      []string // Where the input is used, e.g. "actions/setup-go@v5 (go-version)" or "run step 2"
+}
+
+// Unused reports whether the input is never used by the action.
+func (i Input) Unused() bool {
+	return len(i.UsedBy) == 0
+}
+
+// definition is the part of action.yml relevant to the analysis.
+type definition struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Inputs      map[string]struct {
+		Description string      `yaml:"description"`
+		Required    interface{} `yaml:"required"`
+	} `yaml:"inputs"`
+	Outputs map[string]struct {
+		Value string `yaml:"value"`
+	} `yaml:"outputs"`
+	Runs struct {
+		Using string `yaml:"using"`
+		Steps []struct {
+			Name             string            `yaml:"name"`
+			ID               string            `yaml:"id"`
+			If               string            `yaml:"if"`
+			Uses             string            `yaml:"uses"`
+			Run              string            `yaml:"run"`
+			Shell            string            `yaml:"shell"`
+			WorkingDirectory string            `yaml:"working-directory"`
+			With             map[string]string `yaml:"with"`
+			Env              map[string]string `yaml:"env"`
+		} `yaml:"steps"`
+	} `yaml:"runs"`
+}
+
+// Scan analyzes every composite action defined by an action.yml or
+// action.yaml file under dir. Other kinds of actions are skipped.
+func Scan(dir string) ([]Action, error) {
+	var found []Action
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || (entry.Name() != "action.yml" && entry.Name() != "action.yaml") {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		relativeDir, err := filepath.Rel(dir, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+
+		action, composite, err := Analyze(content)
+		if err != nil {
+			return fmt.Errorf("error parsing action %s: %v", path, err)
+		}
+		if !composite {
+			return nil
+		}
+		action.Path = filepath.ToSlash(relativeDir)
+		found = append(found, action)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error reading actions directory: %v", err)
+	}
+
+	return found, nil
+}
+
+// Analyze analyzes the inputs of the action defined by content. It reports
+// false if the action is not a composite action.
+func Analyze(content []byte) (Action, bool, error) {
+	var def definition
+	if err := yaml.Unmarshal(content, &def); err != nil {
+		return Action{}, false, err
+	}
+	if def.Runs.Using != "composite" {
+		return Action{}, false, nil
+	}
+
+	usedBy := make(map[string][]string)
+	record := func(text, use string) {
+		for _, name := range referencedInputs(text) {
+			usedBy[name] = appendUnique(usedBy[name], use)
+		}
+	}
+
+	for i, step := range def.Runs.Steps {
+		label := fmt.Sprintf("step %d", i+1)
+		if step.ID != "" {
+			label = fmt.Sprintf("step %s", step.ID)
+		}
+
+		if step.Uses != "" {
+			for _, name := range sortedKeys(step.With) {
+				record(step.With[name], fmt.Sprintf("%s (%s)", step.Uses, name))
+			}
+		} else {
+			record(step.Run, "run "+label)
+			record(step.Shell, "run "+label)
+			record(step.WorkingDirectory, "run "+label)
+		}
+		for _, name := range sortedKeys(step.Env) {
+			record(step.Env[name], fmt.Sprintf("env %s of %s", name, label))
+		}
+		record(step.If, "condition of "+label)
+	}
+
+	var outputs []string
+	for name := range def.Outputs {
+		outputs = append(outputs, name)
+	}
+	sort.Strings(outputs)
+	for _, name := range outputs {
+		record(def.Outputs[name].Value, "output "+name)
+	}
+
+	var inputs []string
+	for name := range def.Inputs {
+		inputs = append(inputs, name)
+	}
+	sort.Strings(inputs)
+
+	action := Action{Name: def.Name, Description: def.Description}
+	for _, name := range inputs {
+		input := def.Inputs[name]
+		action.Inputs = append(action.Inputs, Input{
+			Name:        name,
+			Description: input.Description,
+			Required:    input.Required == true || input.Required == "true",
+			UsedBy:      usedBy[name],
+		})
+	}
+
+	for name := range usedBy {
+		if _, declared := def.Inputs[name]; !declared {
+			action.Undeclared = append(action.Undeclared, name)
+		}
+	}
+	sort.Strings(action.Undeclared)
+
+	return action, true, nil
+}
+
+// referencedInputs returns the names of the inputs referenced in text.
+func referencedInputs(text string) []string {
+	var names []string
+	for _, match := range inputPattern.FindAllStringSubmatch(text, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		names = appendUnique(names, name)
+	}
+	return names
+}
+
+// appendUnique appends item to items unless it is already present.
+func appendUnique(items []string, item string) []string {
+	for _, existing := range items {
+		if existing == item {
+			return items
+		}
+	}
+	return append(items, item)
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Render renders the input analysis of the actions as a markdown document.
+func Render(actions []Action) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Composite Action Inputs\n")
+	if len(actions) == 0 {
+		sb.WriteString("\nNo composite actions found.\n")
+		return sb.String()
+	}
+
+	for _, action := range actions {
+		title := action.Name
+		if title == "" {
+			title = action.Path
+		}
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+		sb.WriteString(fmt.Sprintf("Path: `%s`\n\n", action.Path))
+		if action.Description != "" {
+			sb.WriteString(action.Description + "\n\n")
+		}
+
+		if len(action.Inputs) == 0 {
+			sb.WriteString("_No inputs declared._\n")
+		} else {
+			sb.WriteString("| Input | Required | Used by |\n")
+			sb.WriteString("| --- | --- | --- |\n")
+			for _, input := range action.Inputs {
+				usedBy := "**Unused**"
+				if !input.Unused() {
+					usedBy = strings.Join(input.UsedBy, ", ")
+				}
+				required := "no"
+				if input.Required {
+					required = "yes"
+				}
+				sb.WriteString(fmt.Sprintf("| `%s` | %s | %s |\n", input.Name, required, usedBy))
+			}
+		}
+
+		if len(action.Undeclared) > 0 {
+			sb.WriteString(fmt.Sprintf("\nReferenced but not declared: %s\n", "`"+strings.Join(action.Undeclared, "`, `")+"`"))
+		}
+	}
+
+	return sb.String()
+}
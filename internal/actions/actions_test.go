@@ -0,0 +1,117 @@
+package actions
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const compositeAction = `name: Setup
+description: Sets up the toolchain.
+inputs:
+  go-version:
+    description: Go version to install
+    required: true
+  cache:
+    description: Whether to cache modules
+    default: 'true'
+  working-dir:
+    description: Directory to build in
+  legacy-flag:
+    description: No longer used
+outputs:
+  version:
+    value: ${{ steps.setup.outputs.go-version }}
+runs:
+  using: composite
+  steps:
+    - id: setup
+      uses: actions/setup-go@v5
+      with:
+        go-version: ${{ inputs.go-version }}
+        cache: ${{ inputs['cache'] }}
+    - run: go build ./...
+      shell: bash
+      working-directory: ${{ inputs.working-dir }}
+    - if: ${{ inputs.verbose == 'true' }}
+      run: go version
+      shell: bash
+`
+
+// TestAnalyze tests the input analysis of a composite action
+func TestAnalyze(t *testing.T) {
+	action, composite, err := Analyze([]byte(compositeAction))
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if !composite {
+		t.Fatal("Expected a composite action")
+	}
+
+	expected := []Input{
+		{Name: "cache", Description: "Whether to cache modules", UsedBy: []string{"actions/setup-go@v5 (cache)"}},
+		{Name: "go-version", Description: "Go version to install", Required: true, UsedBy: []string{"actions/setup-go@v5 (go-version)"}},
+		{Name: "legacy-flag", Description: "No longer used"},
+		{Name: "working-dir", Description: "Directory to build in", UsedBy: []string{"run step 2"}},
+	}
+	if !reflect.DeepEqual(action.Inputs, expected) {
+		t.Errorf("Expected inputs:\n%+v\nGot:\n%+v", expected, action.Inputs)
+	}
+
+	if !reflect.DeepEqual(action.Undeclared, []string{"verbose"}) {
+		t.Errorf("Expected undeclared input verbose, got %v", action.Undeclared)
+	}
+}
+
+// TestAnalyzeNonComposite tests that other kinds of actions are skipped
+func TestAnalyzeNonComposite(t *testing.T) {
+	_, composite, err := Analyze([]byte("name: Node\nruns:\n  using: node20\n  main: index.js\n"))
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+	if composite {
+		t.Error("Expected a JavaScript action not to be treated as composite")
+	}
+}
+
+// TestScan tests finding and rendering the composite actions of a directory
+func TestScan(t *testing.T) {
+	dir := t.TempDir()
+	for path, content := range map[string]string{
+		"setup/action.yml":    compositeAction,
+		"node/action.yaml":    "runs:\n  using: node20\n  main: index.js\n",
+		"setup/README.md":     "Not an action\n",
+		"nested/a/action.yml": "runs:\n  using: composite\n  steps: []\n",
+	} {
+		fullPath := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	found, err := Scan(dir)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(found) != 2 || found[0].Path != "nested/a" || found[1].Path != "setup" {
+		t.Fatalf("Expected nested/a and setup, got %+v", found)
+	}
+
+	content := Render(found)
+	for _, expected := range []string{
+		"## nested/a\n\nPath: `nested/a`\n\n_No inputs declared._\n",
+		"## Setup\n\nPath: `setup`\n\nSets up the toolchain.\n",
+		"| `legacy-flag` | no | **Unused** |",
+		"| `go-version` | yes | actions/setup-go@v5 (go-version) |",
+		"Referenced but not declared: `verbose`",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
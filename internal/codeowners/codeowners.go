@@ -0,0 +1,167 @@
+package codeowners
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/git"
+	"github.com/droctothorpe/gha-docs/internal/pathmatch"
+)
+
+// Locations are the paths, relative to the repository root, where GitHub
+// looks for a CODEOWNERS file, in order.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// Rule is a single pattern and its owners from a CODEOWNERS file.
+type Rule struct {
+	Pattern string
+	Owners  []string // Empty when the rule removes ownership
+	Line    int
+}
+
+// Ownership is the ownership of a single workflow.
+type Ownership struct {
+	Workflow string // Path of the workflow relative to the repository root
+	Owners   []string
+	Rule     *Rule // Rule assigning the owners, nil if no rule matches
+}
+
+// Parse parses the rules of a CODEOWNERS file, in file order.
+func Parse(content []byte) []Rule {
+	var rules []Rule
+	for i, line := range strings.Split(string(content), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		// Drop trailing comments
+		if comment := strings.Index(line, " #"); comment != -1 {
+			line = line[:comment]
+		}
+
+		fields := strings.Fields(line)
+		rules = append(rules, Rule{Pattern: fields[0], Owners: fields[1:], Line: i + 1})
+	}
+	return rules
+}
+
+// OwnersOf returns the rule that decides the owners of path. As on GitHub,
+// the last matching rule takes precedence. It returns nil if no rule
+// matches.
+func OwnersOf(rules []Rule, path string) *Rule {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if pathmatch.MatchGitignore(rules[i].Pattern, path) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// Find returns the path of the CODEOWNERS file of the repository at
+// repoDir, or "" if it has none.
+func Find(repoDir string) string {
+	for _, location := range Locations {
+		candidate := filepath.Join(repoDir, location)
+		if _, err := os.Stat(candidate); err == nil {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// Check cross-references the CODEOWNERS file at codeownersPath with the
+// workflows in workflowsDir. If codeownersPath is empty, the file is looked up
+// in the repository containing workflowsDir. git is stopped once ctx is done.
+func Check(ctx context.Context, workflowsDir, codeownersPath string) ([]Ownership, error) {
+	repoDir, err := git.TopLevel(ctx, workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error locating git repository: %v", err)
+	}
+
+	relativeDir, err := git.RelativePath(repoDir, workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving workflows directory: %v", err)
+	}
+
+	if codeownersPath == "" {
+		codeownersPath = Find(repoDir)
+		if codeownersPath == "" {
+			return nil, fmt.Errorf("no CODEOWNERS file found in %s", strings.Join(Locations, ", "))
+		}
+	}
+
+	content, err := os.ReadFile(codeownersPath)
+	if err != nil {
+		return nil, fmt.Errorf("error reading CODEOWNERS file: %v", err)
+	}
+	rules := Parse(content)
+
+	workflows, err := generate.ScanDir(workflowsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var ownerships []Ownership
+	for _, workflow := range workflows {
+		ownership := Ownership{Workflow: path.Join(relativeDir, workflow.Filename)}
+		if rule := OwnersOf(rules, ownership.Workflow); rule != nil {
+			ownership.Owners = rule.Owners
+			ownership.Rule = rule
+		}
+		ownerships = append(ownerships, ownership)
+	}
+	return ownerships, nil
+}
+
+// Unowned returns the workflows without owners.
+func Unowned(ownerships []Ownership) []string {
+	var unowned []string
+	for _, ownership := range ownerships {
+		if len(ownership.Owners) == 0 {
+			unowned = append(unowned, ownership.Workflow)
+		}
+	}
+	return unowned
+}
+
+// Render renders the ownership table and the list of unowned workflows as a
+// markdown document.
+func Render(ownerships []Ownership) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Workflow Ownership\n\n")
+
+	unowned := Unowned(ownerships)
+	sb.WriteString(fmt.Sprintf("%d of %d workflows have owners.\n\n", len(ownerships)-len(unowned), len(ownerships)))
+
+	sb.WriteString("| Workflow | Owners | Rule |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+	for _, ownership := range ownerships {
+		owners := "_Unowned_"
+		if len(ownership.Owners) > 0 {
+			owners = strings.Join(ownership.Owners, ", ")
+		}
+
+		rule := ""
+		if ownership.Rule != nil {
+			rule = fmt.Sprintf("`%s` (line %d)", ownership.Rule.Pattern, ownership.Rule.Line)
+		}
+
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n", ownership.Workflow, owners, rule))
+	}
+
+	if len(unowned) > 0 {
+		sb.WriteString("\n## Unowned workflows\n\n")
+		for _, workflow := range unowned {
+			sb.WriteString("- " + workflow + "\n")
+		}
+	}
+
+	return sb.String()
+}
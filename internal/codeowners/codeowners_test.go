@@ -0,0 +1,134 @@
+package codeowners
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParse tests parsing of CODEOWNERS rules
+func TestParse(t *testing.T) {
+	rules := Parse([]byte(`# Default owners
+*       @octo-org/everyone
+
+/.github/workflows/   @octo-org/platform  # CI owners
+/.github/workflows/experimental.yml
+`))
+
+	expected := []Rule{
+		{Pattern: "*", Owners: []string{"@octo-org/everyone"}, Line: 2},
+		{Pattern: "/.github/workflows/", Owners: []string{"@octo-org/platform"}, Line: 4},
+		{Pattern: "/.github/workflows/experimental.yml", Owners: []string{}, Line: 5},
+	}
+	if !reflect.DeepEqual(rules, expected) {
+		t.Errorf("Expected rules %+v, got %+v", expected, rules)
+	}
+}
+
+// TestOwnersOf tests that the last matching rule takes precedence
+func TestOwnersOf(t *testing.T) {
+	rules := Parse([]byte(`*.yml @octo-org/yaml
+/.github/workflows/deploy*.yml @octo-org/deployers
+/.github/workflows/experimental.yml
+`))
+
+	testCases := []struct {
+		path   string
+		owners []string
+	}{
+		{".github/workflows/ci.yml", []string{"@octo-org/yaml"}},
+		{".github/workflows/deploy-prod.yml", []string{"@octo-org/deployers"}},
+		{".github/workflows/experimental.yml", []string{}},
+	}
+	for _, tc := range testCases {
+		rule := OwnersOf(rules, tc.path)
+		if rule == nil {
+			t.Errorf("Expected a rule to match %s", tc.path)
+			continue
+		}
+		if !reflect.DeepEqual(rule.Owners, tc.owners) {
+			t.Errorf("Expected owners %v for %s, got %v", tc.owners, tc.path, rule.Owners)
+		}
+	}
+
+	if rule := OwnersOf(rules, ".github/workflows/ci.yaml"); rule != nil {
+		t.Errorf("Expected no rule to match, got %+v", rule)
+	}
+}
+
+// TestCheck tests cross-referencing CODEOWNERS with a workflows directory
+func TestCheck(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	workflowsDir := filepath.Join(repo, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows dir: %v", err)
+	}
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+
+	files := map[string]string{
+		".github/CODEOWNERS":             "/.github/workflows/ci.yml @octo-org/ci\n",
+		".github/workflows/ci.yml":       "on: push\n",
+		".github/workflows/release.yaml": "on: workflow_dispatch\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(repo, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	ownerships, err := Check(context.Background(), workflowsDir, "")
+	if err != nil {
+		t.Fatalf("Check failed: %v", err)
+	}
+
+	if len(ownerships) != 2 {
+		t.Fatalf("Expected 2 workflows, got %d", len(ownerships))
+	}
+	if ownerships[0].Workflow != ".github/workflows/ci.yml" || !reflect.DeepEqual(ownerships[0].Owners, []string{"@octo-org/ci"}) {
+		t.Errorf("Unexpected ownership of ci.yml: %+v", ownerships[0])
+	}
+
+	unowned := Unowned(ownerships)
+	if !reflect.DeepEqual(unowned, []string{".github/workflows/release.yaml"}) {
+		t.Errorf("Expected release.yaml to be unowned, got %v", unowned)
+	}
+
+	content := Render(ownerships)
+	for _, expected := range []string{
+		"1 of 2 workflows have owners.",
+		"| .github/workflows/ci.yml | @octo-org/ci | `/.github/workflows/ci.yml` (line 1) |",
+		"| .github/workflows/release.yaml | _Unowned_ |  |",
+		"## Unowned workflows\n\n- .github/workflows/release.yaml\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// TestCheckMissingCodeowners tests the error when no CODEOWNERS file exists
+func TestCheckMissingCodeowners(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+
+	_, err := Check(context.Background(), repo, "")
+	if err == nil || !strings.Contains(err.Error(), "no CODEOWNERS file found") {
+		t.Errorf("Expected missing CODEOWNERS error, got %v", err)
+	}
+}
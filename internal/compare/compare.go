@@ -0,0 +1,156 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/git"
+	"github.com/droctothorpe/gha-docs/internal/history"
+)
+
+// Supported comparison report styles.
+const (
+	StyleSideBySide = "side-by-side"
+	StyleDiff       = "diff"
+)
+
+// Load parses the workflows identified by source. A source is either a
+// directory on disk or a "<ref>:<dir>" pair naming a directory, relative to
+// the root of the current git repository, as it exists at a git ref. Scans
+// and git are stopped once ctx is done.
+func Load(ctx context.Context, source string) ([]generate.WorkflowInfo, error) {
+	if info, err := os.Stat(source); err == nil && info.IsDir() {
+		workflows, parseErrors, err := generate.ScanDirContext(ctx, source, generate.ScanOptions{})
+		for _, parseError := range parseErrors {
+			slog.Warn("Error parsing workflow file", "file", parseError.Filename, "error", parseError.Err)
+		}
+		return workflows, err
+	}
+
+	ref, dir, ok := strings.Cut(source, ":")
+	if !ok || ref == "" {
+		return nil, fmt.Errorf("%s is neither a directory nor a <ref>:<dir> source", source)
+	}
+	if dir == "" {
+		dir = "."
+	}
+
+	repoDir, err := git.TopLevel(ctx, ".")
+	if err != nil {
+		return nil, fmt.Errorf("error locating git repository: %v", err)
+	}
+	return history.Snapshot(ctx, repoDir, ref, dir)
+}
+
+// Render renders a comparison of the base and head workflows in the given
+// style.
+func Render(baseName, headName string, base, head []generate.WorkflowInfo, style string) (string, error) {
+	rows := pair(base, head)
+
+	var sb strings.Builder
+	sb.WriteString("# Workflow Comparison\n\n")
+	sb.WriteString(fmt.Sprintf("Comparing `%s` with `%s`: %s.\n\n", baseName, headName, summarize(rows)))
+
+	switch style {
+	case StyleSideBySide:
+		// Cells are escaped like the generated tables, so that pipes and
+		// line breaks in names or descriptions do not break the table
+		baseCell, headCell := generate.EscapeCell("`"+baseName+"`"), generate.EscapeCell("`"+headName+"`")
+		sb.WriteString(fmt.Sprintf("| Workflow | Status | Triggers (%s) | Triggers (%s) | Description (%s) | Description (%s) |\n",
+			baseCell, headCell, baseCell, headCell))
+		sb.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, row := range rows {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s | %s |\n",
+				generate.EscapeCell(row.filename),
+				row.status,
+				generate.EscapeCell(strings.Join(row.base.Triggers, ", ")),
+				generate.EscapeCell(strings.Join(row.head.Triggers, ", ")),
+				generate.EscapeCell(row.base.Description),
+				generate.EscapeCell(row.head.Description)))
+		}
+	case StyleDiff:
+		sb.WriteString("```diff\n")
+		for _, row := range rows {
+			switch row.status {
+			case "unchanged":
+				sb.WriteString("  " + diffLine(row.filename, row.head) + "\n")
+			case string(diff.Added):
+				sb.WriteString("+ " + diffLine(row.filename, row.head) + "\n")
+			case string(diff.Removed):
+				sb.WriteString("- " + diffLine(row.filename, row.base) + "\n")
+			default:
+				sb.WriteString("- " + diffLine(row.filename, row.base) + "\n")
+				sb.WriteString("+ " + diffLine(row.filename, row.head) + "\n")
+			}
+		}
+		sb.WriteString("```\n")
+	default:
+		return "", fmt.Errorf("unsupported comparison style %q", style)
+	}
+
+	return sb.String(), nil
+}
+
+// row pairs the base and head versions of a workflow.
+type row struct {
+	filename string
+	status   string
+	base     generate.WorkflowInfo
+	head     generate.WorkflowInfo
+}
+
+// pair matches base and head workflows by filename, sorted by filename.
+func pair(base, head []generate.WorkflowInfo) []row {
+	statuses := make(map[string]string)
+	for _, change := range diff.Diff(base, head) {
+		statuses[change.Filename] = string(change.Kind)
+	}
+
+	rowsByName := make(map[string]*row)
+	get := func(name string) *row {
+		if rowsByName[name] == nil {
+			status, ok := statuses[name]
+			if !ok {
+				status = "unchanged"
+			}
+			rowsByName[name] = &row{filename: name, status: status}
+		}
+		return rowsByName[name]
+	}
+	for _, workflow := range base {
+		get(workflow.Filename).base = workflow
+	}
+	for _, workflow := range head {
+		get(workflow.Filename).head = workflow
+	}
+
+	var rows []row
+	for _, r := range rowsByName {
+		rows = append(rows, *r)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].filename < rows[j].filename
+	})
+	return rows
+}
+
+// summarize counts rows by status.
+func summarize(rows []row) string {
+	counts := make(map[string]int)
+	for _, r := range rows {
+		counts[r.status]++
+	}
+	return fmt.Sprintf("%d added, %d removed, %d modified, %d unchanged",
+		counts[string(diff.Added)], counts[string(diff.Removed)], counts[string(diff.Modified)], counts["unchanged"])
+}
+
+// diffLine formats a workflow as a single line of a diff-style report.
+func diffLine(filename string, workflow generate.WorkflowInfo) string {
+	return fmt.Sprintf("%s | %s | %s", filename, workflow.Description, strings.Join(workflow.Triggers, ", "))
+}
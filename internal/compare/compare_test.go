@@ -0,0 +1,113 @@
+package compare
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+var (
+	baseWorkflows = []generate.WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}},
+		{Filename: "old.yml", Description: "Old workflow.", Triggers: []string{"schedule"}},
+		{Filename: "same.yml", Description: "Unchanged.", Triggers: []string{"push"}},
+	}
+	headWorkflows = []generate.WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs CI on PRs.", Triggers: []string{"pull_request", "push"}},
+		{Filename: "new.yml", Description: "New workflow.", Triggers: []string{"workflow_dispatch"}},
+		{Filename: "same.yml", Description: "Unchanged.", Triggers: []string{"push"}},
+	}
+)
+
+// TestRenderSideBySide tests the side-by-side comparison table
+func TestRenderSideBySide(t *testing.T) {
+	report, err := Render("main", "feature", baseWorkflows, headWorkflows, StyleSideBySide)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expectedLines := []string{
+		"Comparing `main` with `feature`: 1 added, 1 removed, 1 modified, 1 unchanged.",
+		"| Workflow | Status | Triggers (`main`) | Triggers (`feature`) | Description (`main`) | Description (`feature`) |",
+		"| ci.yml | modified | push | pull_request, push | Runs CI. | Runs CI on PRs. |",
+		"| new.yml | added |  | workflow_dispatch |  | New workflow. |",
+		"| old.yml | removed | schedule |  | Old workflow. |  |",
+		"| same.yml | unchanged | push | push | Unchanged. | Unchanged. |",
+	}
+
+	for _, line := range expectedLines {
+		if !strings.Contains(report, line) {
+			t.Errorf("Expected report to contain %q, got:\n%s", line, report)
+		}
+	}
+}
+
+// TestRenderSideBySideEscaping tests escaping pipes and line breaks in the
+// cells of the side-by-side comparison table
+func TestRenderSideBySideEscaping(t *testing.T) {
+	base := []generate.WorkflowInfo{{Filename: "ci.yml", Description: "Runs lint | test.", Triggers: []string{"push"}}}
+	head := []generate.WorkflowInfo{{Filename: "ci.yml", Description: "Runs lint\nand test.", Triggers: []string{"push"}}}
+
+	report, err := Render("main", "a|b", base, head, StyleSideBySide)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expectedLines := []string{
+		"| Workflow | Status | Triggers (`main`) | Triggers (`a\\|b`) | Description (`main`) | Description (`a\\|b`) |",
+		"| ci.yml | modified | push | push | Runs lint \\| test. | Runs lint<br>and test. |",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(report, line) {
+			t.Errorf("Expected report to contain %q, got:\n%s", line, report)
+		}
+	}
+}
+
+// TestRenderDiff tests the diff-style comparison report
+func TestRenderDiff(t *testing.T) {
+	report, err := Render("main", "feature", baseWorkflows, headWorkflows, StyleDiff)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := "```diff\n" +
+		"- ci.yml | Runs CI. | push\n" +
+		"+ ci.yml | Runs CI on PRs. | pull_request, push\n" +
+		"+ new.yml | New workflow. | workflow_dispatch\n" +
+		"- old.yml | Old workflow. | schedule\n" +
+		"  same.yml | Unchanged. | push\n" +
+		"```\n"
+	if !strings.Contains(report, expected) {
+		t.Errorf("Expected report to contain:\n%s\ngot:\n%s", expected, report)
+	}
+
+	if _, err := Render("main", "feature", baseWorkflows, headWorkflows, "unknown"); err == nil {
+		t.Error("Expected error for unsupported style, got nil")
+	}
+}
+
+// TestLoadDirectory tests loading workflows from a directory source
+func TestLoadDirectory(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte("## Runs CI.\non: push\n"), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write workflow: %v", err)
+	}
+
+	workflows, err := Load(context.Background(), dir)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(workflows) != 1 || workflows[0].Filename != "ci.yml" {
+		t.Errorf("Expected ci.yml to be loaded, got %+v", workflows)
+	}
+
+	if _, err := Load(context.Background(), filepath.Join(dir, "missing")); err == nil {
+		t.Error("Expected error for a source that is neither a directory nor a ref, got nil")
+	}
+}
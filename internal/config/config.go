@@ -0,0 +1,302 @@
+package config
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultFiles are the configuration files looked for in the current
+// directory, in order.
+var DefaultFiles = []string{".ghadoc.yaml", ".ghadoc.yml"}
+
+// EnvPrefix prefixes the environment variables that set flags, e.g.
+// GHADOC_WORKFLOWS for --workflows.
+const EnvPrefix = "GHADOC_"
+
+// Config holds flag values from a configuration file. Top-level keys set the
+// flags of every command, and keys within a section named after a command,
+// such as generate or publish.wiki, set the flags of that command only:
+//
+//	workflows: .github/workflows
+//	generate:
+//	  output: docs/workflows.md
+//	  badges: true
+type Config struct {
+	Path   string
+	values map[string]interface{}
+}
+
+// Find returns the first of DefaultFiles that exists, or "" if none does.
+func Find() string {
+	for _, file := range DefaultFiles {
+		if _, err := os.Stat(file); err == nil {
+			return file
+		}
+	}
+	return ""
+}
+
+// Load reads the configuration file at path.
+func Load(path string) (*Config, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading config file: %v", err)
+	}
+
+	values := make(map[string]interface{})
+	if err := yaml.Unmarshal(content, &values); err != nil {
+		return nil, fmt.Errorf("error parsing config file %s: %v", path, err)
+	}
+	return &Config{Path: path, values: values}, nil
+}
+
+// Value returns the value of flag for the command at commandPath, e.g.
+// ["publish", "wiki"]. The most specific section setting the flag wins.
+func (c *Config) Value(commandPath []string, flag string) (interface{}, bool) {
+	sections := []map[string]interface{}{c.values}
+	for _, name := range commandPath {
+		section, ok := sections[len(sections)-1][name].(map[string]interface{})
+		if !ok {
+			break
+		}
+		sections = append(sections, section)
+	}
+
+	for i := len(sections) - 1; i >= 0; i-- {
+		if value, ok := sections[i][flag]; ok {
+			return value, true
+		}
+	}
+	return nil, false
+}
+
+// Decode decodes the value of key for the command at commandPath into
+// target, for settings too structured for a flag. It reports whether the key
+// is set. c may be nil.
+func (c *Config) Decode(commandPath []string, key string, target interface{}) (bool, error) {
+	if c == nil {
+		return false, nil
+	}
+	value, ok := c.Value(commandPath, key)
+	if !ok {
+		return false, nil
+	}
+
+	content, err := yaml.Marshal(value)
+	if err == nil {
+		err = yaml.Unmarshal(content, target)
+	}
+	if err != nil {
+		return true, fmt.Errorf("invalid value of %s in %s: %v", key, c.Path, err)
+	}
+	return true, nil
+}
+
+// Merge returns the configuration of override merged over c. Sections are
+// merged key by key, and other values of override replace those of c.
+// Either configuration may be nil.
+func (c *Config) Merge(override *Config) *Config {
+	if c == nil {
+		return override
+	}
+	if override == nil {
+		return c
+	}
+	return &Config{Path: override.Path, values: mergeValues(c.values, override.values)}
+}
+
+// mergeValues merges the values of override over base into a new map.
+func mergeValues(base, override map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{})
+	for key, value := range base {
+		merged[key] = value
+	}
+	for key, value := range override {
+		baseSection, baseOK := merged[key].(map[string]interface{})
+		section, ok := value.(map[string]interface{})
+		if baseOK && ok {
+			merged[key] = mergeValues(baseSection, section)
+		} else {
+			merged[key] = value
+		}
+	}
+	return merged
+}
+
+// DirectoryFile returns the configuration file of dir overriding the root
+// configuration, or "" if dir has none.
+func DirectoryFile(dir string) string {
+	for _, file := range DefaultFiles {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil {
+			return path
+		}
+	}
+	return ""
+}
+
+// Resolve returns the value flag of the command at commandPath will have once
+// cfg is applied, without setting it.
+func Resolve(flags *pflag.FlagSet, commandPath []string, cfg *Config, flag string) (string, error) {
+	f := flags.Lookup(flag)
+	if f == nil {
+		return "", fmt.Errorf("unknown flag %s", flag)
+	}
+	if f.Changed {
+		if slice, ok := f.Value.(pflag.SliceValue); ok {
+			return joinCSV(slice.GetSlice())
+		}
+		return f.Value.String(), nil
+	}
+	if value, ok := os.LookupEnv(EnvName(flag)); ok {
+		return value, nil
+	}
+	if cfg != nil {
+		if value, ok := cfg.Value(commandPath, flag); ok {
+			return FormatValue(value)
+		}
+	}
+	return f.DefValue, nil
+}
+
+// Source describes where the value flag of the command at commandPath will
+// have once cfg is applied comes from: the command line, an environment
+// variable, the configuration file, or the default. Call it before Apply.
+func Source(flags *pflag.FlagSet, commandPath []string, cfg *Config, flag string) string {
+	if f := flags.Lookup(flag); f != nil && f.Changed {
+		return "flag"
+	}
+	if _, ok := os.LookupEnv(EnvName(flag)); ok {
+		return EnvName(flag)
+	}
+	if cfg != nil {
+		if _, ok := cfg.Value(commandPath, flag); ok {
+			return cfg.Path
+		}
+	}
+	return "default"
+}
+
+// UnknownKeys returns the keys of c, dotted with their sections, that are
+// neither a section of a command nor a setting. isCommand reports whether a
+// path names a command, and isSetting whether a key is a setting of the
+// command at a path, the top level having an empty path.
+func (c *Config) UnknownKeys(isCommand func(path []string) bool, isSetting func(path []string, key string) bool) []string {
+	return unknownKeys(c.values, nil, isCommand, isSetting)
+}
+
+// unknownKeys returns the unknown keys of the section at path.
+func unknownKeys(section map[string]interface{}, path []string, isCommand func([]string) bool, isSetting func([]string, string) bool) []string {
+	var keys []string
+	for key := range section {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var unknown []string
+	for _, key := range keys {
+		child := append(append([]string(nil), path...), key)
+		if subsection, ok := section[key].(map[string]interface{}); ok && isCommand(child) {
+			unknown = append(unknown, unknownKeys(subsection, child, isCommand, isSetting)...)
+		} else if !isSetting(path, key) {
+			unknown = append(unknown, strings.Join(child, "."))
+		}
+	}
+	return unknown
+}
+
+// EnvName returns the environment variable that sets flag.
+func EnvName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// Apply sets the flags of the command at commandPath that were not set on
+// the command line from their environment variables, or else from cfg, which
+// may be nil. Flags thus take precedence over the environment, which takes
+// precedence over the configuration file.
+func Apply(flags *pflag.FlagSet, commandPath []string, cfg *Config) error {
+	var err error
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if err != nil || flag.Changed || flag.Name == "help" || flag.Name == "config" {
+			return
+		}
+
+		if value, ok := os.LookupEnv(EnvName(flag.Name)); ok {
+			if setErr := flags.Set(flag.Name, value); setErr != nil {
+				err = fmt.Errorf("invalid value %q of %s: %v", value, EnvName(flag.Name), setErr)
+			}
+			return
+		}
+
+		if cfg == nil {
+			return
+		}
+		value, ok := cfg.Value(commandPath, flag.Name)
+		if !ok {
+			return
+		}
+		formatted, formatErr := FormatValue(value)
+		if formatErr == nil {
+			formatErr = flags.Set(flag.Name, formatted)
+		}
+		if formatErr != nil {
+			err = fmt.Errorf("invalid value of %s in %s: %v", flag.Name, cfg.Path, formatErr)
+		}
+	})
+	return err
+}
+
+// FormatValue formats a configuration value as a flag value: lists as comma
+// separated values and maps as comma separated key=value pairs.
+func FormatValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case []interface{}:
+		var items []string
+		for _, item := range v {
+			formatted, err := FormatValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, formatted)
+		}
+		return joinCSV(items)
+	case map[string]interface{}:
+		var keys []string
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		var pairs []string
+		for _, key := range keys {
+			formatted, err := FormatValue(v[key])
+			if err != nil {
+				return "", err
+			}
+			pairs = append(pairs, key+"="+formatted)
+		}
+		return joinCSV(pairs)
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
+// joinCSV joins items as a CSV record, the format of list flags.
+func joinCSV(items []string) (string, error) {
+	var sb strings.Builder
+	writer := csv.NewWriter(&sb)
+	if err := writer.Write(items); err != nil {
+		return "", err
+	}
+	writer.Flush()
+	return strings.TrimSuffix(sb.String(), "\n"), writer.Error()
+}
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/spf13/pflag"
+)
+
+// writeConfig writes a configuration file and loads it
+func writeConfig(t *testing.T, content string) *Config {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), ".ghadoc.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write config: %v", err)
+	}
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	return cfg
+}
+
+// newFlags returns a flag set like the one of a command
+func newFlags() *pflag.FlagSet {
+	flags := pflag.NewFlagSet("generate", pflag.ContinueOnError)
+	flags.StringP("workflows", "w", ".", "")
+	flags.StringP("output", "o", "./workflows.md", "")
+	flags.String("format", "markdown", "")
+	flags.Bool("badges", false, "")
+	flags.StringSlice("exclude", nil, "")
+	flags.StringToString("rate", nil, "")
+	return flags
+}
+
+// TestApply tests the precedence of flags, environment, and config file
+func TestApply(t *testing.T) {
+	cfg := writeConfig(t, `workflows: .github/workflows
+output: global.md
+format: csv
+generate:
+  output: docs/workflows.md
+  badges: true
+  exclude: [draft.yml, "odd,name.yml"]
+  rate:
+    UBUNTU: 0.006
+`)
+	t.Setenv("GHADOC_FORMAT", "json")
+
+	flags := newFlags()
+	if err := flags.Parse([]string{"-w", "ci"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if err := Apply(flags, []string{"generate"}, cfg); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+
+	for flag, expected := range map[string]string{
+		"workflows": "ci",
+		"output":    "docs/workflows.md",
+		"format":    "json",
+	} {
+		if value, _ := flags.GetString(flag); value != expected {
+			t.Errorf("Expected %s to be %q, got %q", flag, expected, value)
+		}
+	}
+	if badges, _ := flags.GetBool("badges"); !badges {
+		t.Error("Expected badges to be set by the config file")
+	}
+	if exclude, _ := flags.GetStringSlice("exclude"); !reflect.DeepEqual(exclude, []string{"draft.yml", "odd,name.yml"}) {
+		t.Errorf("Unexpected exclude: %v", exclude)
+	}
+	if rate, _ := flags.GetStringToString("rate"); rate["UBUNTU"] != "0.006" {
+		t.Errorf("Unexpected rate: %v", rate)
+	}
+
+	// Other commands only see the top-level keys
+	flags = newFlags()
+	if err := Apply(flags, []string{"report"}, cfg); err != nil {
+		t.Fatalf("Apply failed: %v", err)
+	}
+	if output, _ := flags.GetString("output"); output != "global.md" {
+		t.Errorf("Expected output global.md, got %q", output)
+	}
+}
+
+// TestApplyInvalid tests reporting invalid values
+func TestApplyInvalid(t *testing.T) {
+	cfg := writeConfig(t, "badges: maybe\n")
+	if err := Apply(newFlags(), nil, cfg); err == nil {
+		t.Error("Expected error for invalid bool value, got nil")
+	}
+
+	t.Setenv("GHADOC_BADGES", "maybe")
+	if err := Apply(newFlags(), nil, nil); err == nil {
+		t.Error("Expected error for invalid environment value, got nil")
+	}
+}
+
+// TestValueNested tests sections of subcommands
+func TestValueNested(t *testing.T) {
+	cfg := writeConfig(t, "page: Global\npublish:\n  page: Publish\n  wiki:\n    page: Wiki\n")
+
+	for _, test := range []struct {
+		path     []string
+		expected string
+	}{
+		{[]string{"publish", "wiki"}, "Wiki"},
+		{[]string{"publish", "confluence"}, "Publish"},
+		{[]string{"generate"}, "Global"},
+	} {
+		if value, _ := cfg.Value(test.path, "page"); value != test.expected {
+			t.Errorf("Expected page %q for %v, got %v", test.expected, test.path, value)
+		}
+	}
+}
+
+// TestMerge tests merging a directory configuration over the root configuration
+func TestMerge(t *testing.T) {
+	root := writeConfig(t, "format: csv\ngenerate:\n  output: docs/workflows.md\n  badges: true\n")
+	override := writeConfig(t, "generate:\n  output: services/api/workflows.md\n")
+
+	merged := root.Merge(override)
+	for flag, expected := range map[string]interface{}{
+		"format": "csv",
+		"output": "services/api/workflows.md",
+		"badges": true,
+	} {
+		if value, _ := merged.Value([]string{"generate"}, flag); value != expected {
+			t.Errorf("Expected %s to be %v, got %v", flag, expected, value)
+		}
+	}
+
+	if root.Merge(nil) != root {
+		t.Error("Expected merging nil to keep the root configuration")
+	}
+	var none *Config
+	if none.Merge(override) != override {
+		t.Error("Expected merging into nil to yield the override")
+	}
+}
+
+// TestResolve tests resolving a flag without applying the configuration
+func TestResolve(t *testing.T) {
+	cfg := writeConfig(t, "generate:\n  workflows: .github/workflows\n")
+
+	flags := newFlags()
+	if value, _ := Resolve(flags, []string{"generate"}, cfg, "workflows"); value != ".github/workflows" {
+		t.Errorf("Expected the configured directory, got %q", value)
+	}
+	if value, _ := Resolve(flags, []string{"report"}, cfg, "workflows"); value != "." {
+		t.Errorf("Expected the default directory, got %q", value)
+	}
+
+	flags.Set("workflows", "ci")
+	if value, _ := Resolve(flags, []string{"generate"}, cfg, "workflows"); value != "ci" {
+		t.Errorf("Expected the flag value, got %q", value)
+	}
+	if flags.Changed("output") {
+		t.Error("Expected Resolve not to set flags")
+	}
+
+	flags.Set("exclude", "a.yml")
+	flags.Set("exclude", "b.yml")
+	if value, _ := Resolve(flags, []string{"generate"}, cfg, "exclude"); value != "a.yml,b.yml" {
+		t.Errorf("Expected the list flag as comma-separated values, got %q", value)
+	}
+}
+
+// TestDecode tests decoding structured settings
+func TestDecode(t *testing.T) {
+	cfg := writeConfig(t, `generate:
+  column-formats:
+    Triggers:
+      separator: " "
+      map:
+        push: up
+`)
+
+	var formats map[string]struct {
+		Separator string            `yaml:"separator"`
+		Map       map[string]string `yaml:"map"`
+	}
+	ok, err := cfg.Decode([]string{"generate"}, "column-formats", &formats)
+	if err != nil || !ok {
+		t.Fatalf("Decode failed: %v, %v", ok, err)
+	}
+	if formats["Triggers"].Separator != " " || formats["Triggers"].Map["push"] != "up" {
+		t.Errorf("Unexpected formats: %+v", formats)
+	}
+
+	if ok, err := cfg.Decode([]string{"lint"}, "column-formats", &formats); ok || err != nil {
+		t.Errorf("Expected no value for another command, got %v, %v", ok, err)
+	}
+	var nilConfig *Config
+	if ok, err := nilConfig.Decode([]string{"generate"}, "column-formats", &formats); ok || err != nil {
+		t.Errorf("Expected no value without a config, got %v, %v", ok, err)
+	}
+
+	var invalid map[string]int
+	if _, err := cfg.Decode([]string{"generate"}, "column-formats", &invalid); err == nil {
+		t.Error("Expected an error decoding into the wrong type")
+	}
+}
+
+// TestUnknownKeys tests reporting keys that are neither sections nor settings
+func TestUnknownKeys(t *testing.T) {
+	cfg := writeConfig(t, `workflows: .github/workflows
+outptu: docs.md
+generate:
+  output: docs.md
+  colums: [Runners=jobs.*.runs-on]
+publish:
+  wiki:
+    page: Home
+    bogus: true
+`)
+
+	commands := map[string]bool{"generate": true, "publish": true, "publish wiki": true}
+	settings := map[string]bool{"workflows": true, "output": true, "generate output": true, "publish wiki page": true}
+	unknown := cfg.UnknownKeys(
+		func(path []string) bool { return commands[strings.Join(path, " ")] },
+		func(path []string, key string) bool {
+			return settings[strings.Join(append(append([]string(nil), path...), key), " ")]
+		},
+	)
+
+	expected := []string{"generate.colums", "outptu", "publish.wiki.bogus"}
+	if !reflect.DeepEqual(unknown, expected) {
+		t.Errorf("Expected unknown keys %v, got %v", expected, unknown)
+	}
+}
+
+// TestSource tests reporting where the value of a flag comes from
+func TestSource(t *testing.T) {
+	cfg := writeConfig(t, "generate:\n  output: docs.md\n  badges: true\n")
+	t.Setenv("GHADOC_FORMAT", "json")
+	t.Setenv("GHADOC_BADGES", "false")
+
+	flags := newFlags()
+	if err := flags.Parse([]string{"-w", "ci"}); err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	for flag, expected := range map[string]string{
+		"workflows": "flag",
+		"format":    "GHADOC_FORMAT",
+		"badges":    "GHADOC_BADGES",
+		"output":    cfg.Path,
+		"exclude":   "default",
+	} {
+		if source := Source(flags, []string{"generate"}, cfg, flag); source != expected {
+			t.Errorf("Expected the source of %s to be %q, got %q", flag, expected, source)
+		}
+	}
+}
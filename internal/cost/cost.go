@@ -0,0 +1,147 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// NotBilled is the runner type of runs without billable time, such as runs
+// in public repositories or on self-hosted runners. Their minutes are the
+// wall-clock durations of the runs.
+const NotBilled = "not billed"
+
+// DefaultRates maps runner operating systems to the per-minute price in USD
+// of the standard GitHub-hosted runners. Prices change over time, so they can
+// be overridden.
+var DefaultRates = map[string]float64{
+	"UBUNTU":  0.008,
+	"WINDOWS": 0.016,
+	"MACOS":   0.08,
+}
+
+// Usage is the runner time used by the runs of a workflow on a runner type.
+type Usage struct {
+	Workflow string
+	Runner   string // Runner operating system, or NotBilled
+	Runs     int    // Runs that used the runner type
+	Minutes  int    // Runner minutes, with each run rounded up to a full minute
+}
+
+// Collect fetches the completed runs since the given time of every workflow
+// in owner/repo and sums the runner minutes they used per runner type.
+// Usages are sorted by workflow and runner type.
+func Collect(ctx context.Context, client *github.Client, owner, repo string, workflows []generate.WorkflowInfo, since time.Time) ([]Usage, error) {
+	var usages []Usage
+	for _, workflow := range workflows {
+		runs, err := client.ListWorkflowRunsSince(ctx, owner, repo, workflow.Filename, since)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching runs of %s: %v", workflow.Filename, err)
+		}
+
+		byRunner := make(map[string]*Usage)
+		for _, run := range runs {
+			timing, err := client.GetRunTiming(ctx, owner, repo, run.ID)
+			if err != nil {
+				return nil, fmt.Errorf("error fetching usage of run %d of %s: %v", run.ID, workflow.Filename, err)
+			}
+
+			for runner, ms := range runnerTime(timing) {
+				if byRunner[runner] == nil {
+					byRunner[runner] = &Usage{Workflow: workflow.Filename, Runner: runner}
+				}
+				byRunner[runner].Runs++
+				byRunner[runner].Minutes += minutes(ms)
+			}
+		}
+
+		var runners []string
+		for runner := range byRunner {
+			runners = append(runners, runner)
+		}
+		sort.Strings(runners)
+		for _, runner := range runners {
+			usages = append(usages, *byRunner[runner])
+		}
+	}
+	return usages, nil
+}
+
+// runnerTime returns the time in milliseconds a run used per runner type.
+// Runs without billable time are attributed to NotBilled.
+func runnerTime(timing github.RunTiming) map[string]int64 {
+	times := make(map[string]int64)
+	for runner, billable := range timing.Billable {
+		if billable.TotalMS > 0 {
+			times[runner] = billable.TotalMS
+		}
+	}
+	if len(times) == 0 {
+		times[NotBilled] = timing.RunDurationMS
+	}
+	return times
+}
+
+// minutes rounds ms up to full minutes.
+func minutes(ms int64) int {
+	return int((ms + 59999) / 60000)
+}
+
+// Render renders the usages of the runs of repo since the given time as a
+// markdown document, with the runner minutes and estimated cost per workflow
+// and per runner type. Runner types without a rate have no estimated cost.
+func Render(repo string, since time.Time, usages []Usage, rates map[string]float64) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Actions Usage of %s\n\n", repo))
+	sb.WriteString(fmt.Sprintf("Completed runs created since %s. Costs are estimates based on per-minute rates of the runner types.\n\n", since.UTC().Format("2006-01-02")))
+
+	if len(usages) == 0 {
+		sb.WriteString("_No runs._\n")
+		return sb.String()
+	}
+
+	sb.WriteString("## By workflow\n\n")
+	sb.WriteString("| Workflow | Runner | Runs | Minutes | Estimated Cost |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	runnerMinutes := make(map[string]int)
+	for _, usage := range usages {
+		runnerMinutes[usage.Runner] += usage.Minutes
+		sb.WriteString(fmt.Sprintf("| %s | %s | %d | %d | %s |\n",
+			usage.Workflow, usage.Runner, usage.Runs, usage.Minutes, costCell(usage.Runner, usage.Minutes, rates)))
+	}
+
+	var runners []string
+	for runner := range runnerMinutes {
+		runners = append(runners, runner)
+	}
+	sort.Strings(runners)
+
+	sb.WriteString("\n## By runner\n\n")
+	sb.WriteString("| Runner | Minutes | Estimated Cost |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+	var totalMinutes int
+	var totalCost float64
+	for _, runner := range runners {
+		totalMinutes += runnerMinutes[runner]
+		totalCost += float64(runnerMinutes[runner]) * rates[runner]
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", runner, runnerMinutes[runner], costCell(runner, runnerMinutes[runner], rates)))
+	}
+	sb.WriteString(fmt.Sprintf("| **Total** | %d | $%.2f |\n", totalMinutes, totalCost))
+
+	return sb.String()
+}
+
+// costCell formats the estimated cost of minutes on runner.
+func costCell(runner string, minutes int, rates map[string]float64) string {
+	rate, ok := rates[runner]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf("$%.2f", float64(minutes)*rate)
+}
@@ -0,0 +1,80 @@
+package cost
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestCollect tests summing the runner minutes of workflow runs
+func TestCollect(t *testing.T) {
+	timings := map[string]string{
+		"1": `{"billable": {"UBUNTU": {"total_ms": 61000, "jobs": 2}, "MACOS": {"total_ms": 0, "jobs": 0}}, "run_duration_ms": 40000}`,
+		"2": `{"billable": {"UBUNTU": {"total_ms": 120000, "jobs": 2}, "MACOS": {"total_ms": 300000, "jobs": 1}}, "run_duration_ms": 310000}`,
+		"3": `{"billable": {}, "run_duration_ms": 90000}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/repos/owner/repo/actions/workflows/ci.yml/runs":
+			w.Write([]byte(`{"workflow_runs": [{"id": 1}, {"id": 2}]}`))
+		case r.URL.Path == "/repos/owner/repo/actions/workflows/self-hosted.yml/runs":
+			w.Write([]byte(`{"workflow_runs": [{"id": 3}]}`))
+		case strings.HasPrefix(r.URL.Path, "/repos/owner/repo/actions/runs/"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/actions/runs/"), "/timing")
+			fmt.Fprint(w, timings[id])
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	workflows := []generate.WorkflowInfo{{Filename: "ci.yml"}, {Filename: "self-hosted.yml"}, {Filename: "new.yml"}}
+	usages, err := Collect(context.Background(), github.NewClient(server.URL, ""), "owner", "repo", workflows, time.Now())
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	expected := []Usage{
+		{Workflow: "ci.yml", Runner: "MACOS", Runs: 1, Minutes: 5},
+		{Workflow: "ci.yml", Runner: "UBUNTU", Runs: 2, Minutes: 4},
+		{Workflow: "self-hosted.yml", Runner: NotBilled, Runs: 1, Minutes: 2},
+	}
+	if !reflect.DeepEqual(usages, expected) {
+		t.Errorf("Expected usages %+v, got %+v", expected, usages)
+	}
+}
+
+// TestRender tests rendering the usage report
+func TestRender(t *testing.T) {
+	usages := []Usage{
+		{Workflow: "ci.yml", Runner: "MACOS", Runs: 1, Minutes: 5},
+		{Workflow: "ci.yml", Runner: "UBUNTU", Runs: 2, Minutes: 4},
+		{Workflow: "lint.yml", Runner: "UBUNTU", Runs: 10, Minutes: 10},
+		{Workflow: "self-hosted.yml", Runner: NotBilled, Runs: 1, Minutes: 2},
+	}
+
+	content := Render("owner/repo", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), usages, DefaultRates)
+
+	for _, expected := range []string{
+		"# Actions Usage of owner/repo\n\nCompleted runs created since 2025-01-01.",
+		"| ci.yml | MACOS | 1 | 5 | $0.40 |\n",
+		"| self-hosted.yml | not billed | 1 | 2 |  |\n",
+		"| MACOS | 5 | $0.40 |\n| UBUNTU | 14 | $0.11 |\n| not billed | 2 |  |\n| **Total** | 21 | $0.51 |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	if content := Render("owner/repo", time.Now(), nil, DefaultRates); !strings.Contains(content, "_No runs._") {
+		t.Errorf("Expected no runs note, got:\n%s", content)
+	}
+}
@@ -0,0 +1,171 @@
+package dependabot
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configLocations are the paths, relative to the repository root, of the
+// Dependabot configuration.
+var configLocations = []string{
+	".github/dependabot.yml",
+	".github/dependabot.yaml",
+}
+
+// Config stores the version updates of a Dependabot configuration.
+type Config struct {
+	Path    string // Path relative to the repository root, with forward slashes
+	Updates []Update
+}
+
+// Update stores the settings of an entry of the updates of a Dependabot
+// configuration.
+type Update struct {
+	Ecosystem   string
+	Directories []string
+	Schedule    Schedule
+	Reviewers   []string
+}
+
+// Schedule stores when Dependabot checks for updates.
+type Schedule struct {
+	Interval string
+	Day      string
+	Time     string
+	Timezone string
+	Cron     string
+}
+
+// String formats the schedule, e.g. "weekly on monday at 09:00 (Europe/Berlin)".
+func (s Schedule) String() string {
+	if s.Interval == "" {
+		return ""
+	}
+	schedule := s.Interval
+	if s.Cron != "" {
+		schedule += " `" + s.Cron + "`"
+	}
+	if s.Day != "" {
+		schedule += " on " + s.Day
+	}
+	if s.Time != "" {
+		schedule += " at " + s.Time
+	}
+	if s.Timezone != "" {
+		schedule += " (" + s.Timezone + ")"
+	}
+	return schedule
+}
+
+// configFile is the structure of .github/dependabot.yml.
+type configFile struct {
+	Updates []struct {
+		PackageEcosystem string   `yaml:"package-ecosystem"`
+		Directory        string   `yaml:"directory"`
+		Directories      []string `yaml:"directories"`
+		Schedule         struct {
+			Interval string `yaml:"interval"`
+			Day      string `yaml:"day"`
+			Time     string `yaml:"time"`
+			Timezone string `yaml:"timezone"`
+			Cronjob  string `yaml:"cronjob"`
+		} `yaml:"schedule"`
+		Reviewers []string `yaml:"reviewers"`
+	} `yaml:"updates"`
+}
+
+// Scan reads the Dependabot configuration of the repository at repoDir. It
+// returns nil if the repository has none.
+func Scan(repoDir string) (*Config, error) {
+	for _, location := range configLocations {
+		content, err := os.ReadFile(filepath.Join(repoDir, filepath.FromSlash(location)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", location, err)
+		}
+		return Parse(location, content)
+	}
+	return nil, nil
+}
+
+// Parse parses the Dependabot configuration at path with content.
+func Parse(path string, content []byte) (*Config, error) {
+	var file configFile
+	if err := yaml.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("error parsing %s: %v", path, err)
+	}
+
+	config := &Config{Path: path}
+	for _, entry := range file.Updates {
+		directories := entry.Directories
+		if entry.Directory != "" {
+			directories = append([]string{entry.Directory}, directories...)
+		}
+		config.Updates = append(config.Updates, Update{
+			Ecosystem:   entry.PackageEcosystem,
+			Directories: directories,
+			Schedule: Schedule{
+				Interval: entry.Schedule.Interval,
+				Day:      entry.Schedule.Day,
+				Time:     entry.Schedule.Time,
+				Timezone: entry.Schedule.Timezone,
+				Cron:     entry.Schedule.Cronjob,
+			},
+			Reviewers: entry.Reviewers,
+		})
+	}
+	return config, nil
+}
+
+// Render renders the version updates of config as a markdown table. The link
+// to the configuration file is relative to the output file.
+func Render(config *Config, repoDir, output string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Dependency Updates\n\n")
+	if config == nil {
+		sb.WriteString("No Dependabot configuration found.\n")
+		return sb.String()
+	}
+	sb.WriteString(fmt.Sprintf("Dependabot version updates configured in [%s](%s).\n\n", config.Path, link(repoDir, config.Path, output)))
+	if len(config.Updates) == 0 {
+		sb.WriteString("No version updates configured.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Ecosystem | Directories | Schedule | Reviewers |\n")
+	sb.WriteString("| --- | --- | --- | --- |\n")
+	for _, update := range config.Updates {
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+			update.Ecosystem,
+			code(update.Directories),
+			update.Schedule,
+			strings.Join(update.Reviewers, ", ")))
+	}
+
+	return sb.String()
+}
+
+// code formats items as a comma-separated list of code spans.
+func code(items []string) string {
+	formatted := make([]string, len(items))
+	for i, item := range items {
+		formatted[i] = "`" + item + "`"
+	}
+	return strings.Join(formatted, ", ")
+}
+
+// link returns the path of file relative to the directory of output.
+func link(repoDir, file, output string) string {
+	relativePath, err := filepath.Rel(filepath.Dir(output), filepath.Join(repoDir, filepath.FromSlash(file)))
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(relativePath)
+}
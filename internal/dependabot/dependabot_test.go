@@ -0,0 +1,97 @@
+package dependabot
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestScan tests reading the version updates of the Dependabot configuration
+func TestScan(t *testing.T) {
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".github"), 0755); err != nil {
+		t.Fatalf("Failed to create .github: %v", err)
+	}
+	content := `version: 2
+updates:
+  - package-ecosystem: gomod
+    directory: /
+    schedule:
+      interval: weekly
+      day: monday
+      time: "09:00"
+      timezone: Europe/Berlin
+    reviewers:
+      - octocat
+      - octo-org/platform
+  - package-ecosystem: npm
+    directories: [/web, /docs]
+    schedule:
+      interval: daily
+`
+	if err := os.WriteFile(filepath.Join(repo, ".github", "dependabot.yaml"), []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write dependabot.yaml: %v", err)
+	}
+
+	config, err := Scan(repo)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	expected := &Config{
+		Path: ".github/dependabot.yaml",
+		Updates: []Update{
+			{Ecosystem: "gomod", Directories: []string{"/"}, Schedule: Schedule{Interval: "weekly", Day: "monday", Time: "09:00", Timezone: "Europe/Berlin"}, Reviewers: []string{"octocat", "octo-org/platform"}},
+			{Ecosystem: "npm", Directories: []string{"/web", "/docs"}, Schedule: Schedule{Interval: "daily"}},
+		},
+	}
+	if !reflect.DeepEqual(config, expected) {
+		t.Errorf("Expected config:\n%+v\nGot:\n%+v", expected, config)
+	}
+}
+
+// TestScanNoConfig tests a repository without a Dependabot configuration
+func TestScanNoConfig(t *testing.T) {
+	repo := t.TempDir()
+
+	config, err := Scan(repo)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if config != nil {
+		t.Errorf("Expected no config, got %+v", config)
+	}
+
+	if content := Render(config, repo, ""); !strings.Contains(content, "No Dependabot configuration found.") {
+		t.Errorf("Expected no configuration message, got:\n%s", content)
+	}
+}
+
+// TestParseInvalid tests the error for a malformed configuration
+func TestParseInvalid(t *testing.T) {
+	_, err := Parse(".github/dependabot.yml", []byte("updates: [unterminated\n"))
+	if err == nil || !strings.Contains(err.Error(), "dependabot.yml") {
+		t.Errorf("Expected parse error naming the file, got %v", err)
+	}
+}
+
+// TestRender tests rendering the version updates table
+func TestRender(t *testing.T) {
+	content := Render(&Config{
+		Path: ".github/dependabot.yml",
+		Updates: []Update{
+			{Ecosystem: "github-actions", Directories: []string{"/"}, Schedule: Schedule{Interval: "weekly", Day: "monday", Time: "09:00", Timezone: "UTC"}, Reviewers: []string{"octocat"}},
+		},
+	}, "repo", "repo/docs/dependencies.md")
+
+	for _, expected := range []string{
+		"configured in [.github/dependabot.yml](../.github/dependabot.yml).",
+		"| github-actions | `/` | weekly on monday at 09:00 (UTC) | octocat |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
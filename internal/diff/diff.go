@@ -0,0 +1,105 @@
+package diff
+
+import (
+	"sort"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// Kind describes how a workflow changed between two sets of workflows.
+type Kind string
+
+const (
+	Added    Kind = "added"
+	Removed  Kind = "removed"
+	Modified Kind = "modified"
+)
+
+// Change describes the difference in a single workflow between two sets of
+// workflows.
+type Change struct {
+	Filename        string
+	Kind            Kind
+	AddedTriggers   []string
+	RemovedTriggers []string
+	OldDescription  string
+	NewDescription  string
+}
+
+// DescriptionChanged reports whether the description differs between the
+// old and new workflow.
+func (c Change) DescriptionChanged() bool {
+	return c.OldDescription != c.NewDescription
+}
+
+// Diff compares two sets of workflows by filename and returns the changes
+// sorted by filename. Workflows that are identical in both sets are omitted.
+func Diff(old, new []generate.WorkflowInfo) []Change {
+	oldByName := make(map[string]generate.WorkflowInfo)
+	for _, workflow := range old {
+		oldByName[workflow.Filename] = workflow
+	}
+	newByName := make(map[string]generate.WorkflowInfo)
+	for _, workflow := range new {
+		newByName[workflow.Filename] = workflow
+	}
+
+	var changes []Change
+	for name, oldWorkflow := range oldByName {
+		newWorkflow, ok := newByName[name]
+		if !ok {
+			changes = append(changes, Change{
+				Filename:        name,
+				Kind:            Removed,
+				RemovedTriggers: oldWorkflow.Triggers,
+				OldDescription:  oldWorkflow.Description,
+			})
+			continue
+		}
+
+		change := Change{
+			Filename:        name,
+			Kind:            Modified,
+			AddedTriggers:   subtract(newWorkflow.Triggers, oldWorkflow.Triggers),
+			RemovedTriggers: subtract(oldWorkflow.Triggers, newWorkflow.Triggers),
+			OldDescription:  oldWorkflow.Description,
+			NewDescription:  newWorkflow.Description,
+		}
+		if len(change.AddedTriggers) > 0 || len(change.RemovedTriggers) > 0 || change.DescriptionChanged() {
+			changes = append(changes, change)
+		}
+	}
+
+	for name, newWorkflow := range newByName {
+		if _, ok := oldByName[name]; !ok {
+			changes = append(changes, Change{
+				Filename:       name,
+				Kind:           Added,
+				AddedTriggers:  newWorkflow.Triggers,
+				NewDescription: newWorkflow.Description,
+			})
+		}
+	}
+
+	sort.Slice(changes, func(i, j int) bool {
+		return changes[i].Filename < changes[j].Filename
+	})
+
+	return changes
+}
+
+// subtract returns the items in a that are not in b, preserving order.
+func subtract(a, b []string) []string {
+	seen := make(map[string]bool)
+	for _, item := range b {
+		seen[item] = true
+	}
+
+	var result []string
+	for _, item := range a {
+		if !seen[item] {
+			result = append(result, item)
+		}
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// TestDiff tests detection of added, removed, and modified workflows
+func TestDiff(t *testing.T) {
+	old := []generate.WorkflowInfo{
+		{Filename: "removed.yml", Description: "Removed", Triggers: []string{"push"}},
+		{Filename: "unchanged.yml", Description: "Same", Triggers: []string{"push"}},
+		{Filename: "triggers.yml", Description: "Triggers", Triggers: []string{"pull_request", "push"}},
+		{Filename: "description.yml", Description: "Old", Triggers: []string{"push"}},
+	}
+	new := []generate.WorkflowInfo{
+		{Filename: "unchanged.yml", Description: "Same", Triggers: []string{"push"}},
+		{Filename: "triggers.yml", Description: "Triggers", Triggers: []string{"push", "schedule"}},
+		{Filename: "description.yml", Description: "New", Triggers: []string{"push"}},
+		{Filename: "added.yml", Description: "Added", Triggers: []string{"workflow_dispatch"}},
+	}
+
+	expected := []Change{
+		{Filename: "added.yml", Kind: Added, AddedTriggers: []string{"workflow_dispatch"}, NewDescription: "Added"},
+		{Filename: "description.yml", Kind: Modified, OldDescription: "Old", NewDescription: "New"},
+		{Filename: "removed.yml", Kind: Removed, RemovedTriggers: []string{"push"}, OldDescription: "Removed"},
+		{Filename: "triggers.yml", Kind: Modified, AddedTriggers: []string{"schedule"}, RemovedTriggers: []string{"pull_request"}, OldDescription: "Triggers", NewDescription: "Triggers"},
+	}
+
+	changes := Diff(old, new)
+	if !reflect.DeepEqual(changes, expected) {
+		t.Errorf("Expected changes:\n%+v\ngot:\n%+v", expected, changes)
+	}
+}
+
+// TestDiffIdentical tests that identical workflow sets produce no changes
+func TestDiffIdentical(t *testing.T) {
+	workflows := []generate.WorkflowInfo{
+		{Filename: "ci.yml", Description: "CI", Triggers: []string{"push"}},
+	}
+
+	if changes := Diff(workflows, workflows); len(changes) != 0 {
+		t.Errorf("Expected no changes, got %+v", changes)
+	}
+}
+
+// TestLines tests the line diff of two texts
+func TestLines(t *testing.T) {
+	old := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\n"
+	new := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\n"
+
+	expected := " a\n-b\n+B\n c\n d\n e\n@@\n i\n j\n k\n+l\n"
+	if lines := Lines(old, new); lines != expected {
+		t.Errorf("Expected diff:\n%s\nGot:\n%s", expected, lines)
+	}
+
+	if lines := Lines("", "x\n"); lines != "+x\n" {
+		t.Errorf("Expected an added line, got %q", lines)
+	}
+	if lines := Lines(old, old); lines != "" {
+		t.Errorf("Expected no diff for identical texts, got %q", lines)
+	}
+}
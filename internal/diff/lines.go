@@ -0,0 +1,88 @@
+package diff
+
+import "strings"
+
+// contextLines is the number of unchanged lines shown around each change by
+// Lines.
+const contextLines = 3
+
+// Lines returns a line diff of old and new in the format of a unified diff
+// body: added lines start with "+", removed lines with "-", and unchanged
+// lines with a space. Only the unchanged lines near changes are included;
+// skipped lines are marked by a "@@" line. Identical texts yield "".
+func Lines(old, new string) string {
+	a := splitLines(old)
+	b := splitLines(new)
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:]
+	// and b[j:]
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	changed := false
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			lines = append(lines, "-"+a[i])
+			changed = true
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			changed = true
+			j++
+		}
+	}
+	if !changed {
+		return ""
+	}
+
+	return strings.Join(trimContext(lines), "\n") + "\n"
+}
+
+// trimContext drops the unchanged lines that are not within contextLines of
+// a change, marking each gap with "@@".
+func trimContext(lines []string) []string {
+	keep := make([]bool, len(lines))
+	for i, line := range lines {
+		if line[0] == ' ' {
+			continue
+		}
+		for j := max(0, i-contextLines); j <= min(len(lines)-1, i+contextLines); j++ {
+			keep[j] = true
+		}
+	}
+
+	var trimmed []string
+	for i, line := range lines {
+		if keep[i] {
+			trimmed = append(trimmed, line)
+		} else if i == 0 || keep[i-1] {
+			trimmed = append(trimmed, "@@")
+		}
+	}
+	return trimmed
+}
+
+// splitLines splits text into lines, ignoring a final newline.
+func splitLines(text string) []string {
+	if text == "" {
+		return nil
+	}
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n")
+}
@@ -0,0 +1,121 @@
+package environments
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// Render renders the deployment environments of repo ("owner/name") as a
+// markdown document. Every environment configured in GitHub or used by a job
+// of the workflows is listed with its protection rules and the workflows that
+// deploy to it.
+func Render(repo string, environments []github.Environment, workflows []generate.WorkflowInfo) string {
+	owner, _, _ := strings.Cut(repo, "/")
+
+	deployers := make(map[string][]string)
+	for _, workflow := range workflows {
+		for _, environment := range workflow.Environments {
+			deployers[environment] = append(deployers[environment], workflow.Filename)
+		}
+	}
+
+	configured := make(map[string]github.Environment)
+	for _, environment := range environments {
+		configured[environment.Name] = environment
+	}
+
+	var names []string
+	for name := range configured {
+		names = append(names, name)
+	}
+	for name := range deployers {
+		if _, ok := configured[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# Deployment Environments of %s\n\n", repo))
+	if len(names) == 0 {
+		sb.WriteString("_No environments._\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Environment | Reviewers | Wait Timer | Deployment Branches | Workflows |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, name := range names {
+		workflowsCell := strings.Join(deployers[name], ", ")
+		if workflowsCell == "" {
+			workflowsCell = "_none_"
+		}
+
+		environment, ok := configured[name]
+		switch {
+		case strings.Contains(name, "${{"):
+			sb.WriteString(fmt.Sprintf("| `%s` | _chosen at runtime_ | | | %s |\n", name, workflowsCell))
+		case !ok:
+			sb.WriteString(fmt.Sprintf("| %s | _not configured, created without protection on first deployment_ | | | %s |\n", name, workflowsCell))
+		default:
+			reviewers, waitTimer := protection(environment, owner)
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n", link(environment), reviewers, waitTimer, branchPolicy(environment), workflowsCell))
+		}
+	}
+
+	return sb.String()
+}
+
+// link links the name of environment to its settings, if their URL is known.
+func link(environment github.Environment) string {
+	if environment.HTMLURL == "" {
+		return environment.Name
+	}
+	return fmt.Sprintf("[%s](%s)", environment.Name, environment.HTMLURL)
+}
+
+// protection formats the required reviewers and wait timer of environment.
+// Teams are mentioned as teams of owner.
+func protection(environment github.Environment, owner string) (string, string) {
+	var reviewers []string
+	waitTimer := "none"
+	for _, rule := range environment.ProtectionRules {
+		switch rule.Type {
+		case "required_reviewers":
+			for _, reviewer := range rule.Reviewers {
+				if reviewer.Type == "Team" {
+					reviewers = append(reviewers, fmt.Sprintf("@%s/%s", owner, reviewer.Reviewer.Slug))
+				} else {
+					reviewers = append(reviewers, "@"+reviewer.Reviewer.Login)
+				}
+			}
+		case "wait_timer":
+			if rule.WaitTimer > 0 {
+				waitTimer = fmt.Sprintf("%d min", rule.WaitTimer)
+			}
+		}
+	}
+
+	if len(reviewers) == 0 {
+		return "none", waitTimer
+	}
+	return strings.Join(reviewers, ", "), waitTimer
+}
+
+// branchPolicy describes which branches can deploy to environment.
+func branchPolicy(environment github.Environment) string {
+	policy := environment.DeploymentBranchPolicy
+	switch {
+	case policy == nil:
+		return "all branches"
+	case policy.ProtectedBranches:
+		return "protected branches"
+	case policy.CustomBranchPolicies:
+		return "selected branches"
+	default:
+		return "all branches"
+	}
+}
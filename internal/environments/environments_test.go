@@ -0,0 +1,54 @@
+package environments
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestRender tests documenting environments and the workflows deploying to them
+func TestRender(t *testing.T) {
+	var environments []github.Environment
+	err := json.Unmarshal([]byte(`[
+		{"name": "production", "html_url": "https://github.com/owner/repo/deployments/activity_log?environments_filter=production",
+		 "protection_rules": [
+			{"type": "wait_timer", "wait_timer": 30},
+			{"type": "required_reviewers", "reviewers": [
+				{"type": "User", "reviewer": {"login": "octocat"}},
+				{"type": "Team", "reviewer": {"slug": "ops"}}
+			]}
+		 ],
+		 "deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}},
+		{"name": "sandbox"}
+	]`), &environments)
+	if err != nil {
+		t.Fatalf("Failed to decode environments: %v", err)
+	}
+
+	workflows := []generate.WorkflowInfo{
+		{Filename: "deploy.yml", Environments: []string{"production", "staging"}},
+		{Filename: "preview.yml", Environments: []string{"${{ inputs.environment }}"}},
+		{Filename: "release.yml", Environments: []string{"production"}},
+	}
+
+	content := Render("owner/repo", environments, workflows)
+
+	for _, expected := range []string{
+		"| Environment | Reviewers | Wait Timer | Deployment Branches | Workflows |\n",
+		"| `${{ inputs.environment }}` | _chosen at runtime_ | | | preview.yml |\n",
+		"| [production](https://github.com/owner/repo/deployments/activity_log?environments_filter=production) | @octocat, @owner/ops | 30 min | protected branches | deploy.yml, release.yml |\n",
+		"| sandbox | none | none | all branches | _none_ |\n",
+		"| staging | _not configured, created without protection on first deployment_ | | | deploy.yml |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	if content := Render("owner/repo", nil, nil); !strings.Contains(content, "_No environments._") {
+		t.Errorf("Expected no environments note, got:\n%s", content)
+	}
+}
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Supported badge styles.
+const (
+	BadgeStyleGitHub  = "github"
+	BadgeStyleShields = "shields"
+)
+
+// BadgeMarkdown returns the markdown for a status badge of the workflow file
+// filename in the repository at repoURL (e.g. https://github.com/owner/repo),
+// linking to the workflow's runs. If branch is set, the badge reports the
+// status of that branch.
+func BadgeMarkdown(repoURL, filename, style, branch string) (string, error) {
+	repoURL = strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git")
+	parsed, err := url.Parse(repoURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return "", fmt.Errorf("invalid repository URL %q", repoURL)
+	}
+
+	runsURL := fmt.Sprintf("%s/actions/workflows/%s", repoURL, url.PathEscape(filename))
+
+	query := ""
+	if branch != "" {
+		query = "?branch=" + url.QueryEscape(branch)
+	}
+
+	var imageURL string
+	switch style {
+	case "", BadgeStyleGitHub:
+		imageURL = runsURL + "/badge.svg" + query
+	case BadgeStyleShields:
+		repoPath := strings.Trim(parsed.Path, "/")
+		imageURL = fmt.Sprintf("https://img.shields.io/github/actions/workflow/status/%s/%s%s",
+			repoPath, url.PathEscape(filename), query)
+	default:
+		return "", fmt.Errorf("unsupported badge style %q", style)
+	}
+
+	return fmt.Sprintf("[![%s](%s)](%s)", filename, imageURL, runsURL), nil
+}
+
+// GenerateBadges creates a markdown table listing the badge of every
+// workflow together with the markdown snippet to embed it.
+func GenerateBadges(workflows []WorkflowInfo, repoURL, style, branch string) (string, error) {
+	var sb strings.Builder
+
+	sb.WriteString("# GitHub Workflow Badges\n\n")
+	sb.WriteString("| Workflow | Badge | Markdown |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+
+	for _, workflow := range workflows {
+		badge, err := BadgeMarkdown(repoURL, workflow.Filename, style, branch)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteString(fmt.Sprintf("| %s | %s | `%s` |\n", workflow.Filename, badge, badge))
+	}
+
+	return sb.String(), nil
+}
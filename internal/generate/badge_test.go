@@ -0,0 +1,87 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestBadgeMarkdown tests badge snippets for the supported styles
+func TestBadgeMarkdown(t *testing.T) {
+	testCases := []struct {
+		name     string
+		repoURL  string
+		style    string
+		branch   string
+		expected string
+	}{
+		{
+			name:     "GitHub badge",
+			repoURL:  "https://github.com/owner/repo",
+			style:    BadgeStyleGitHub,
+			expected: "[![ci.yml](https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg)](https://github.com/owner/repo/actions/workflows/ci.yml)",
+		},
+		{
+			name:     "GitHub badge for a branch with trailing slash and .git",
+			repoURL:  "https://github.com/owner/repo.git/",
+			branch:   "release/v1",
+			expected: "[![ci.yml](https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg?branch=release%2Fv1)](https://github.com/owner/repo/actions/workflows/ci.yml)",
+		},
+		{
+			name:     "Shields badge",
+			repoURL:  "https://github.com/owner/repo",
+			style:    BadgeStyleShields,
+			branch:   "main",
+			expected: "[![ci.yml](https://img.shields.io/github/actions/workflow/status/owner/repo/ci.yml?branch=main)](https://github.com/owner/repo/actions/workflows/ci.yml)",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			badge, err := BadgeMarkdown(tc.repoURL, "ci.yml", tc.style, tc.branch)
+			if err != nil {
+				t.Fatalf("BadgeMarkdown failed: %v", err)
+			}
+			if badge != tc.expected {
+				t.Errorf("Expected %q, got %q", tc.expected, badge)
+			}
+		})
+	}
+
+	if _, err := BadgeMarkdown("owner/repo", "ci.yml", "", ""); err == nil {
+		t.Error("Expected error for repository URL without scheme, got nil")
+	}
+	if _, err := BadgeMarkdown("https://github.com/owner/repo", "ci.yml", "flat", ""); err == nil {
+		t.Error("Expected error for unsupported style, got nil")
+	}
+}
+
+// TestMarkdownTableBadgeColumn tests the optional badge column
+func TestMarkdownTableBadgeColumn(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}}}
+
+	table, err := generateMarkdownTable(workflows, Options{
+		WorkflowsDir: ".github/workflows",
+		Output:       "workflows.md",
+		RepoURL:      "https://github.com/owner/repo",
+		Badges:       true,
+	})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	expectedLines := []string{
+		"| Filename | Description | Triggers | Badge |",
+		"| --- | --- | --- | --- |",
+		"| [ci.yml](.github/workflows/ci.yml) | Runs CI. | push | [![ci.yml](https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg)](https://github.com/owner/repo/actions/workflows/ci.yml) |",
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(table, line) {
+			t.Errorf("Expected table to contain %q, got:\n%s", line, table)
+		}
+	}
+
+	_, err = GenerateWithOptions(Options{WorkflowsDir: ".", Output: "workflows.md", Badges: true})
+	if err == nil {
+		t.Error("Expected error for badges without a repository URL, got nil")
+	}
+}
@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// cacheVersion is part of every cache key. Bump it whenever parsing changes
+// the information extracted from a workflow file, so that workflows cached
+// by an earlier version are parsed again.
+const cacheVersion = "2"
+
+// Cache stores parsed workflows on disk keyed by the hash of their content,
+// so that workflow files that have not changed since an earlier scan are not
+// parsed again.
+type Cache struct {
+	Dir string
+}
+
+// DefaultCacheDir returns the directory parsed workflows are cached in by
+// default, within the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ghadoc", "workflows"), nil
+}
+
+// Clear removes every cached workflow.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return fmt.Errorf("error clearing cache: %v", err)
+	}
+	return nil
+}
+
+// cachedWorkflow is a workflow stored in the cache. Its metadata and
+// document are kept as YAML, which decodes to the same values as the
+// workflow file, where JSON would turn integers into floats. Its warnings
+// are kept too, to be reported again on every scan.
+type cachedWorkflow struct {
+	Workflow WorkflowInfo `json:"workflow"`
+	Metadata string       `json:"metadata,omitempty"`
+	Document string       `json:"document,omitempty"`
+	Warnings []string     `json:"warnings,omitempty"`
+}
+
+// key returns the cache key of content parsed with parseOpts.
+func (c *Cache) key(content []byte, parseOpts ParseOptions) (string, error) {
+	options, err := json.Marshal(parseOpts)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", cacheVersion, options)
+	hash.Write(content)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// get returns the workflow cached under key, if any.
+func (c *Cache) get(key string) (WorkflowInfo, bool) {
+	content, err := os.ReadFile(filepath.Join(c.Dir, key+".json"))
+	if err != nil {
+		return WorkflowInfo{}, false
+	}
+	var cached cachedWorkflow
+	if err := json.Unmarshal(content, &cached); err != nil {
+		return WorkflowInfo{}, false
+	}
+
+	workflow := cached.Workflow
+	workflow.Metadata = nil
+	workflow.warnings = cached.Warnings
+	if cached.Metadata != "" {
+		if err := yaml.Unmarshal([]byte(cached.Metadata), &workflow.Metadata); err != nil {
+			return WorkflowInfo{}, false
+		}
+	}
+	if cached.Document != "" {
+		if err := yaml.Unmarshal([]byte(cached.Document), &workflow.document); err != nil {
+			return WorkflowInfo{}, false
+		}
+	}
+	return workflow, true
+}
+
+// put stores workflow under key. The file is written atomically, so that
+// concurrent scans never read a partially written workflow.
+func (c *Cache) put(key string, workflow WorkflowInfo) error {
+	cached := cachedWorkflow{Workflow: workflow, Warnings: workflow.warnings}
+	cached.Workflow.Metadata = nil
+	if workflow.Metadata != nil {
+		metadata, err := yaml.Marshal(workflow.Metadata)
+		if err != nil {
+			return err
+		}
+		cached.Metadata = string(metadata)
+	}
+	if workflow.document != nil {
+		document, err := yaml.Marshal(workflow.document)
+		if err != nil {
+			return err
+		}
+		cached.Document = string(document)
+	}
+
+	content, err := json.Marshal(cached)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return fmt.Errorf("error creating cache directory: %v", err)
+	}
+
+	file, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error writing cache: %v", err)
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("error writing cache: %v", err)
+	}
+	return os.Rename(file.Name(), filepath.Join(c.Dir, key+".json"))
+}
+
+// ParseWorkflow extracts information from the content of a workflow file
+// as configured by scanOpts, like ParseWorkflowWithOptions, reusing the
+// workflow cached for the same content in scanOpts.Cache. Workflows that
+// parse are added to the cache; failing to write it is not an error.
+// Content larger than scanOpts.MaxFileSize is not parsed.
+func (scanOpts ScanOptions) ParseWorkflow(content []byte) (WorkflowInfo, error) {
+	if err := scanOpts.checkSize(int64(len(content))); err != nil {
+		return WorkflowInfo{}, err
+	}
+	if scanOpts.Cache == nil {
+		return ParseWorkflowWithOptions(content, scanOpts.Parse)
+	}
+
+	key, err := scanOpts.Cache.key(content, scanOpts.Parse)
+	if err != nil {
+		return ParseWorkflowWithOptions(content, scanOpts.Parse)
+	}
+	if workflow, ok := scanOpts.Cache.get(key); ok {
+		return workflow, nil
+	}
+	workflow, err := ParseWorkflowWithOptions(content, scanOpts.Parse)
+	if err == nil {
+		scanOpts.Cache.put(key, workflow)
+	}
+	return workflow, err
+}
@@ -0,0 +1,89 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestCache tests reusing cached workflows for unchanged content
+func TestCache(t *testing.T) {
+	content := []byte(`## Deploys the app
+## --- metadata
+## owner: platform
+## priority: 1
+## ---
+name: Deploy
+on: push
+jobs:
+  deploy:
+    runs-on: ubuntu-latest
+    timeout-minutes: 30
+`)
+	cache := &Cache{Dir: t.TempDir()}
+	scanOpts := ScanOptions{Cache: cache}
+
+	expected, err := ParseWorkflowWithOptions(content, scanOpts.Parse)
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if _, err := scanOpts.ParseWorkflow(content); err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	entries, err := os.ReadDir(cache.Dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("Expected 1 cached workflow, got %d: %v", len(entries), err)
+	}
+
+	// The cached workflow is returned as parsed, with the same types
+	cached, err := scanOpts.ParseWorkflow(content)
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if !reflect.DeepEqual(cached, expected) {
+		t.Errorf("Expected cached workflow %+v, got %+v", expected, cached)
+	}
+
+	// Changed content and parse options are parsed again
+	scanOpts.Parse.CommentPrefix = "#!"
+	if _, err := scanOpts.ParseWorkflow(append(content, '\n')); err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if _, err := scanOpts.ParseWorkflow(content); err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if entries, _ := os.ReadDir(cache.Dir); len(entries) != 3 {
+		t.Errorf("Expected 3 cached workflows, got %d", len(entries))
+	}
+
+	// Workflows that fail to parse are not cached, and corrupt entries are
+	// parsed again
+	if _, err := scanOpts.ParseWorkflow([]byte("on: [push\n")); err == nil {
+		t.Error("Expected error for invalid YAML, got nil")
+	}
+	for _, entry := range entries {
+		os.WriteFile(filepath.Join(cache.Dir, entry.Name()), []byte("{"), 0600)
+	}
+	scanOpts.Parse.CommentPrefix = ""
+	workflow, err := scanOpts.ParseWorkflow(content)
+	if err != nil || !reflect.DeepEqual(workflow, expected) {
+		t.Errorf("Expected %+v for a corrupt entry, got %+v: %v", expected, workflow, err)
+	}
+}
+
+// TestCacheWarnings tests reporting the warnings of cached workflows again
+func TestCacheWarnings(t *testing.T) {
+	content := []byte("## --- metadata\n## owner: platform\non: push\njobs: {}\n")
+	scanOpts := ScanOptions{Cache: &Cache{Dir: t.TempDir()}}
+
+	for i := 0; i < 2; i++ {
+		workflow, err := scanOpts.ParseWorkflow(content)
+		if err != nil {
+			t.Fatalf("ParseWorkflow failed: %v", err)
+		}
+		if len(workflow.warnings) != 1 {
+			t.Errorf("Expected 1 warning on scan %d, got %v", i+1, workflow.warnings)
+		}
+	}
+}
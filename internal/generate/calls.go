@@ -0,0 +1,150 @@
+package generate
+
+import (
+	"context"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/git"
+)
+
+// calls holds the local reusable workflows called by the jobs of the
+// documented workflows, resolved among them, in both directions. Workflows
+// are keyed by workflowKey.
+type calls struct {
+	callees map[string][]WorkflowInfo // Workflows called by the workflow of the key
+	callers map[string][]WorkflowInfo // Workflows calling the workflow of the key
+}
+
+// resolveCalls resolves the local reusable workflows called by the jobs of
+// workflows, e.g. "uses: ./.github/workflows/build.yml", to the workflows
+// themselves, wherever they are among the workflows directories, so that all
+// cross-references are known before anything is written.
+func resolveCalls(workflows []WorkflowInfo, opts Options) calls {
+	resolved := calls{callees: make(map[string][]WorkflowInfo), callers: make(map[string][]WorkflowInfo)}
+	if !callsLocalWorkflow(workflows) {
+		return resolved
+	}
+
+	// Calls name the workflow file relative to the root of the repository
+	byPath := make(map[string]WorkflowInfo)
+	for i, repoPath := range opts.repoPaths(workflows) {
+		byPath[repoPath] = workflows[i]
+	}
+
+	for _, workflow := range workflows {
+		seen := make(map[string]bool)
+		for _, job := range workflow.Jobs {
+			calledPath, ok := localWorkflowPath(job.Uses)
+			if !ok || seen[calledPath] {
+				continue
+			}
+			called, ok := byPath[calledPath]
+			if !ok {
+				continue
+			}
+			seen[calledPath] = true
+			resolved.callees[workflowKey(workflow)] = append(resolved.callees[workflowKey(workflow)], called)
+			resolved.callers[workflowKey(called)] = append(resolved.callers[workflowKey(called)], workflow)
+		}
+	}
+	return resolved
+}
+
+// callsLocalWorkflow reports whether any job of workflows calls a reusable
+// workflow of the same repository.
+func callsLocalWorkflow(workflows []WorkflowInfo) bool {
+	for _, workflow := range workflows {
+		for _, job := range workflow.Jobs {
+			if _, ok := localWorkflowPath(job.Uses); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// repoPaths returns the paths of workflows relative to the root of their
+// repository, like repoPath, but locating the root once per workflows
+// directory rather than once per workflow.
+func (opts Options) repoPaths(workflows []WorkflowInfo) []string {
+	dirPaths := make(map[string]string) // Workflows directories relative to the root
+	paths := make([]string, len(workflows))
+	for i, workflow := range workflows {
+		if opts.Scan != nil || workflow.target != "" {
+			paths[i] = opts.repoPath(workflow)
+			continue
+		}
+
+		dir := opts.workflowsDir(workflow)
+		dirPath, ok := dirPaths[dir]
+		if !ok {
+			dirPath = filepath.ToSlash(dir)
+			// Rendering is not cancelled, and rev-parse is local and quick
+			if repoDir, err := git.TopLevel(context.Background(), dir); err == nil {
+				if relativePath, err := git.RelativePath(repoDir, dir); err == nil {
+					dirPath = relativePath
+				}
+			}
+			dirPaths[dir] = dirPath
+		}
+		paths[i] = path.Join(dirPath, workflow.Filename)
+	}
+	return paths
+}
+
+// localWorkflowPath returns the path, relative to the root of the
+// repository, of the reusable workflow of the same repository called by
+// uses, or false if uses refers to another repository.
+func localWorkflowPath(uses string) (string, bool) {
+	if !strings.HasPrefix(uses, "./") {
+		return "", false
+	}
+	return path.Clean(uses), true
+}
+
+// orderByCalls orders workflows by directory so that the directories of
+// reusable workflows come before those of the workflows calling them, and
+// otherwise in the order of dirs. Workflows keep their order within each
+// directory. Directories calling each other come after the others they call,
+// the first of them in dirs last.
+func orderByCalls(workflows []WorkflowInfo, dirs []string, opts Options) []WorkflowInfo {
+	resolved := resolveCalls(workflows, opts)
+	dependencies := make(map[string][]string)
+	for _, workflow := range workflows {
+		for _, called := range resolved.callees[workflowKey(workflow)] {
+			if called.Dir != workflow.Dir {
+				dependencies[workflow.Dir] = append(dependencies[workflow.Dir], called.Dir)
+			}
+		}
+	}
+
+	// Depth-first, emitting the directories a directory depends on first
+	var ordered []string
+	visited := make(map[string]bool)
+	var visit func(dir string)
+	visit = func(dir string) {
+		if visited[dir] {
+			return
+		}
+		visited[dir] = true
+		for _, dependency := range dependencies[dir] {
+			visit(dependency)
+		}
+		ordered = append(ordered, dir)
+	}
+	for _, dir := range dirs {
+		visit(dir)
+	}
+
+	byDir := make(map[string][]WorkflowInfo)
+	for _, workflow := range workflows {
+		byDir[workflow.Dir] = append(byDir[workflow.Dir], workflow)
+	}
+	result := make([]WorkflowInfo, 0, len(workflows))
+	for _, dir := range ordered {
+		result = append(result, byDir[dir]...)
+	}
+	return result
+}
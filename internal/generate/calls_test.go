@@ -0,0 +1,174 @@
+package generate
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCallsAcrossDirectories tests generating the workflows of several
+// directories in the order of their calls to each other's reusable
+// workflows, with the pages linking callers and callees on the first run
+func TestCallsAcrossDirectories(t *testing.T) {
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	appDir := filepath.Join(repo, ".github", "app")
+	sharedDir := filepath.Join(repo, ".github", "shared")
+	for _, dir := range []string{appDir, sharedDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create workflows dir: %v", err)
+		}
+	}
+	createTempWorkflowFile(t, appDir, "deploy.yml", `## Deploys the app.
+on: push
+jobs:
+  build:
+    uses: ./.github/shared/build.yml
+  remote:
+    uses: octo/workflows/.github/workflows/scan.yml@v1
+`)
+	createTempWorkflowFile(t, sharedDir, "build.yml", `## Builds the app.
+on: workflow_call
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make
+`)
+
+	outputDir := t.TempDir()
+	outputFile := filepath.Join(outputDir, "workflows.md")
+	pagesDir := filepath.Join(outputDir, "workflows")
+	_, err := GenerateWithOptions(Options{
+		WorkflowsDirs: []string{appDir, sharedDir},
+		Output:        outputFile,
+		PagesDir:      pagesDir,
+		GroupBy:       GroupByDirectory,
+		Sort:          SortFilename,
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	shared, app := strings.Index(string(content), "build.yml"), strings.Index(string(content), "deploy.yml")
+	if shared < 0 || app < 0 || shared > app {
+		t.Errorf("Expected the called directory before the calling one, got:\n%s", content)
+	}
+
+	page, err := os.ReadFile(filepath.Join(pagesDir, "app", "deploy.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if expected := "## Calls\n\n- [build.yml](../shared/build.md)\n"; !strings.Contains(string(page), expected) {
+		t.Errorf("Expected page to contain %q, got:\n%s", expected, page)
+	}
+
+	page, err = os.ReadFile(filepath.Join(pagesDir, "shared", "build.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if expected := "## Called by\n\n- [deploy.yml](../app/deploy.md)\n"; !strings.Contains(string(page), expected) {
+		t.Errorf("Expected page to contain %q, got:\n%s", expected, page)
+	}
+}
+
+// TestOrderByCalls tests ordering the directories of workflows calling each
+// other, keeping the given order otherwise
+func TestOrderByCalls(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "a.yml", Dir: "a", Jobs: []JobInfo{{ID: "call", Uses: "./b/b.yml"}}},
+		{Filename: "b.yml", Dir: "b", Jobs: []JobInfo{{ID: "call", Uses: "./a/a.yml"}}},
+		{Filename: "c.yml", Dir: "c", Jobs: []JobInfo{{ID: "call", Uses: "./d/d.yml"}}},
+		{Filename: "d.yml", Dir: "d"},
+	}
+
+	var filenames []string
+	for _, workflow := range orderByCalls(workflows, []string{"a", "b", "c", "d"}, Options{Scan: func(string) ([]WorkflowInfo, []ParseError, error) { return nil, nil, nil }}) {
+		filenames = append(filenames, workflow.Filename)
+	}
+	// a and b call each other, so the first given comes after the other
+	expected := "b.yml a.yml d.yml c.yml"
+	if strings.Join(filenames, " ") != expected {
+		t.Errorf("Expected order %q, got %q", expected, strings.Join(filenames, " "))
+	}
+}
+
+// TestCallsSorted tests that the directories of reusable workflows stay
+// before those calling them when the workflows are sorted, as by default
+func TestCallsSorted(t *testing.T) {
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	ciDir, sharedDir := filepath.Join(repo, "ci"), filepath.Join(repo, "shared")
+	for _, dir := range []string{ciDir, sharedDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create workflows dir: %v", err)
+		}
+	}
+	createTempWorkflowFile(t, ciDir, "ci.yml", "on: push\njobs:\n  build:\n    uses: ./shared/build.yml\n")
+	createTempWorkflowFile(t, sharedDir, "build.yml", "on: workflow_call\njobs: {}\n")
+	createTempWorkflowFile(t, sharedDir, "a.yml", "on: push\njobs: {}\n")
+
+	for _, sortBy := range []string{SortFilename, SortName} {
+		outputFile := filepath.Join(t.TempDir(), "workflows.md")
+		_, err := GenerateWithOptions(Options{
+			WorkflowsDirs: []string{ciDir, sharedDir},
+			Output:        outputFile,
+			Sort:          sortBy,
+		})
+		if err != nil {
+			t.Fatalf("GenerateWithOptions failed: %v", err)
+		}
+
+		content, err := os.ReadFile(outputFile)
+		if err != nil {
+			t.Fatalf("Failed to read output file: %v", err)
+		}
+		a, build, ci := strings.Index(string(content), "/a.yml"), strings.Index(string(content), "/build.yml"), strings.Index(string(content), "/ci.yml")
+		if a < 0 || build < a || ci < build {
+			t.Errorf("Expected shared workflows sorted before ci with sort %s, got:\n%s", sortBy, content)
+		}
+	}
+}
+
+// TestRepoPaths tests the paths of workflows relative to the root of their
+// repository, matching those of repoPath
+func TestRepoPaths(t *testing.T) {
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	outside := t.TempDir()
+	for _, dir := range []string{filepath.Join(repo, ".github", "workflows", "deploy"), filepath.Join(repo, "shared")} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create workflows dir: %v", err)
+		}
+	}
+	opts := Options{WorkflowsDir: filepath.Join(repo, ".github", "workflows")}
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml"},
+		{Filename: "deploy/prod.yml"},
+		{Filename: "build.yml", Dir: filepath.Join(repo, "shared")},
+		{Filename: "other.yml", Dir: outside},
+	}
+
+	expected := []string{".github/workflows/ci.yml", ".github/workflows/deploy/prod.yml", "shared/build.yml", filepath.ToSlash(outside) + "/other.yml"}
+	paths := opts.repoPaths(workflows)
+	if strings.Join(paths, " ") != strings.Join(expected, " ") {
+		t.Errorf("Expected paths %v, got %v", expected, paths)
+	}
+	for i, workflow := range workflows {
+		if repoPath := opts.repoPath(workflow); paths[i] != repoPath {
+			t.Errorf("Expected path %q like repoPath, got %q", repoPath, paths[i])
+		}
+	}
+}
@@ -0,0 +1,221 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Column is a user-defined column of the summary table, extracted from the
+// workflow document or provided by a command.
+type Column struct {
+	Name string // Header of the column
+	Path string // Dot-separated path into the workflow document, e.g. jobs.*.runs-on
+	Exec string // Command providing the values instead, see ColumnRequest and ColumnResponse
+}
+
+// ParseColumn parses a column given as NAME=PATH, e.g.
+// "Runners=jobs.*.runs-on", or NAME=exec:COMMAND, e.g.
+// "Owner=exec:./scripts/owners --team-only".
+func ParseColumn(spec string) (Column, error) {
+	name, path, ok := strings.Cut(spec, "=")
+	name, path = strings.TrimSpace(name), strings.TrimSpace(path)
+	if command, isExec := strings.CutPrefix(path, execPrefix); isExec {
+		path = strings.TrimSpace(command)
+		if !ok || name == "" || path == "" {
+			return Column{}, fmt.Errorf("invalid column %q, expected NAME=exec:COMMAND", spec)
+		}
+		return Column{Name: name, Exec: path}, nil
+	}
+	if !ok || name == "" || path == "" {
+		return Column{}, fmt.Errorf("invalid column %q, expected NAME=PATH", spec)
+	}
+	return Column{Name: name, Path: path}, nil
+}
+
+// ReadsDocument reports whether any of columns reads the workflow document,
+// which must then be parsed without ParseOptions.Partial.
+func ReadsDocument(columns []Column) bool {
+	for _, column := range columns {
+		if column.Exec == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// Extract returns the values at path in the workflow document, without
+// duplicates. Path segments are map keys or list indexes, and * matches every
+// entry of a map or list. Lists found at the end of the path contribute their
+// items and maps their keys, so "on" yields the triggers and "jobs" the job
+// IDs.
+func Extract(document interface{}, path string) []string {
+	values := []interface{}{document}
+	for _, segment := range strings.Split(path, ".") {
+		var next []interface{}
+		for _, value := range values {
+			next = append(next, descend(value, segment)...)
+		}
+		values = next
+	}
+
+	var extracted []string
+	seen := make(map[string]bool)
+	add := func(item string) {
+		if !seen[item] {
+			seen[item] = true
+			extracted = append(extracted, item)
+		}
+	}
+	for _, value := range values {
+		switch v := value.(type) {
+		case nil:
+		case map[string]interface{}:
+			for _, key := range sortedKeys(v) {
+				add(key)
+			}
+		case []interface{}:
+			for _, item := range v {
+				if item != nil {
+					add(fmt.Sprint(item))
+				}
+			}
+		default:
+			add(fmt.Sprint(v))
+		}
+	}
+	return extracted
+}
+
+// descend returns the entries of value selected by the path segment.
+func descend(value interface{}, segment string) []interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		if segment == "*" {
+			var entries []interface{}
+			for _, key := range sortedKeys(v) {
+				entries = append(entries, v[key])
+			}
+			return entries
+		}
+		if entry, ok := v[segment]; ok {
+			return []interface{}{entry}
+		}
+	case []interface{}:
+		if segment == "*" {
+			return v
+		}
+		if index, err := strconv.Atoi(segment); err == nil && index >= 0 && index < len(v) {
+			return []interface{}{v[index]}
+		}
+	}
+	return nil
+}
+
+// sortedKeys returns the keys of m in sorted order.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// columnValues returns the values of column for workflow, with line breaks
+// suitable for the summary table.
+func (opts Options) columnValues(workflow WorkflowInfo, column Column) []string {
+	var values []string
+	if column.Exec != "" {
+		values = append(values, opts.execValues[column.Name][workflowKey(workflow)]...)
+	} else {
+		values = Extract(workflow.document, column.Path)
+	}
+	for i, value := range values {
+		values[i] = strings.ReplaceAll(strings.TrimSpace(value), "\n", "<br>")
+	}
+	return values
+}
+
+// ColumnFormat configures how the values of a column are formatted.
+type ColumnFormat struct {
+	Map       map[string]string `yaml:"map"`       // Replacements of values, e.g. push: 📤
+	Separator string            `yaml:"separator"` // Separator between values; defaults to ", "
+	Truncate  int               `yaml:"truncate"`  // Maximum length of the cell in characters; unlimited if zero
+}
+
+// format formats the values of a cell.
+func (f ColumnFormat) format(values []string) string {
+	mapped := make([]string, len(values))
+	for i, value := range values {
+		if replacement, ok := f.Map[value]; ok {
+			value = replacement
+		}
+		mapped[i] = value
+	}
+
+	separator := f.Separator
+	if separator == "" {
+		separator = ", "
+	}
+	cell := strings.Join(mapped, separator)
+
+	if runes := []rune(cell); f.Truncate > 0 && len(runes) > f.Truncate {
+		kept := string(runes[:f.Truncate-1])
+		// Do not leave half of a <br> behind
+		if open := strings.LastIndex(kept, "<"); open > strings.LastIndex(kept, ">") {
+			kept = kept[:open]
+		}
+		cell = kept + "…"
+	}
+	return cell
+}
+
+// cell formats the values of the column with the given header as configured
+// by opts.ColumnFormats, escaped for a markdown table cell.
+func (opts Options) cell(header string, values ...string) string {
+	return escapeCell(opts.ColumnFormats[header].format(values))
+}
+
+// EscapeCell escapes text for a markdown table cell the way the generated
+// tables do, for other packages rendering markdown tables of workflows.
+func EscapeCell(text string) string {
+	return escapeCell(text)
+}
+
+// escapeCell escapes text for a markdown table cell: pipes would end the
+// cell and line breaks the row, so pipes are escaped and line breaks turned
+// into <br>. An unpaired backtick would start a code span swallowing the rest
+// of the row on some renderers, so it is escaped too.
+func escapeCell(text string) string {
+	text = strings.NewReplacer("\r\n", "<br>", "\r", "<br>", "\n", "<br>").Replace(text)
+
+	// Find the last backtick if they are unpaired
+	unpaired, backticks := -1, 0
+	for i := 0; i < len(text); i++ {
+		if text[i] == '`' && (i == 0 || text[i-1] != '\\') {
+			backticks++
+			unpaired = i
+		}
+	}
+	if backticks%2 == 0 {
+		unpaired = -1
+	}
+
+	var sb strings.Builder
+	for i := 0; i < len(text); i++ {
+		switch {
+		case text[i] == '\\' && i+1 < len(text) && (text[i+1] == '|' || text[i+1] == '`'):
+			// Already escaped
+			sb.WriteString(text[i : i+2])
+			i++
+		case text[i] == '|', i == unpaired:
+			sb.WriteByte('\\')
+			sb.WriteByte(text[i])
+		default:
+			sb.WriteByte(text[i])
+		}
+	}
+	return sb.String()
+}
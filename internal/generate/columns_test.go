@@ -0,0 +1,131 @@
+package generate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestExtract tests extracting values from a workflow document
+func TestExtract(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`on:
+  push:
+  workflow_dispatch:
+jobs:
+  build:
+    runs-on: [self-hosted, linux]
+    timeout-minutes: 30
+    steps:
+      - uses: actions/checkout@v4
+      - run: make
+  test:
+    runs-on: ubuntu-latest
+  lint:
+    runs-on: ubuntu-latest
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	tests := map[string][]string{
+		"jobs.*.runs-on":          {"self-hosted", "linux", "ubuntu-latest"},
+		"jobs.build.steps.0.uses": {"actions/checkout@v4"},
+		"jobs.*.steps.*.run":      {"make"},
+		"jobs.*.timeout-minutes":  {"30"},
+		"on":                      {"push", "workflow_dispatch"},
+		"jobs.build.steps.5.run":  nil,
+		"concurrency":             nil,
+	}
+	for path, expected := range tests {
+		if got := Extract(workflow.document, path); !reflect.DeepEqual(got, expected) {
+			t.Errorf("Extract(%q) = %v, expected %v", path, got, expected)
+		}
+	}
+}
+
+// TestCustomColumns tests adding user-defined columns to the table
+func TestCustomColumns(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte("on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n"))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	workflow.Filename = "ci.yml"
+
+	column, err := ParseColumn("Runners = jobs.*.runs-on")
+	if err != nil {
+		t.Fatalf("ParseColumn failed: %v", err)
+	}
+
+	content, err := generateMarkdownTable([]WorkflowInfo{workflow}, Options{Output: "workflows.md", Columns: []Column{column}})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	for _, expected := range []string{
+		"| Filename | Description | Triggers | Runners |\n",
+		"| [ci.yml](ci.yml) |  | push | ubuntu-latest |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	for _, spec := range []string{"Runners", "=jobs", "Runners="} {
+		if _, err := ParseColumn(spec); err == nil {
+			t.Errorf("Expected an error parsing %q", spec)
+		}
+	}
+}
+
+// TestColumnFormats tests mapping, joining, and truncating column values
+func TestColumnFormats(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs the tests.<br>Then builds.", Triggers: []string{"pull_request", "push"}},
+		{Filename: "nightly.yml", Description: "Nightly.", Triggers: []string{"schedule"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{
+		Output: "workflows.md",
+		ColumnFormats: map[string]ColumnFormat{
+			"Triggers":    {Map: map[string]string{"push": "📤", "schedule": "⏰"}, Separator: " "},
+			"Description": {Truncate: 17},
+		},
+	})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"| [ci.yml](ci.yml) | Runs the tests.… | pull_request 📤 |\n",
+		"| [nightly.yml](nightly.yml) | Nightly. | ⏰ |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// TestEscapeCell tests escaping text for markdown table cells
+func TestEscapeCell(t *testing.T) {
+	for text, expected := range map[string]string{
+		"Runs a | b":                   `Runs a \| b`,
+		`Already \| escaped`:           `Already \| escaped`,
+		"First\nsecond\r\nthird":       "First<br>second<br>third",
+		"Uses `make test`":             "Uses `make test`",
+		"Unpaired ` and `code`":        "Unpaired ` and `code\\`",
+		"Pipe in `a | b` code":         "Pipe in `a \\| b` code",
+		"Escaped \\` does not count `": "Escaped \\` does not count \\`",
+	} {
+		if escaped := escapeCell(text); escaped != expected {
+			t.Errorf("escapeCell(%q) = %q, expected %q", text, escaped, expected)
+		}
+	}
+
+	var sb strings.Builder
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Description: "Checks a|b", Triggers: []string{"push"}}}
+	if err := writeTable(&sb, workflows, Options{Output: "workflows.md"}); err != nil {
+		t.Fatalf("writeTable failed: %v", err)
+	}
+	if !strings.Contains(sb.String(), `| Checks a\|b | push |`) {
+		t.Errorf("Expected the escaped description in the table, got:\n%s", sb.String())
+	}
+}
@@ -0,0 +1,162 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Sources of the description of a workflow.
+const (
+	DescriptionComments = "comments" // Leading doc comments, "##" by default
+	DescriptionKey      = "key"      // x-description key, or description key of the metadata block
+	DescriptionMarker   = "marker"   // "# ghadoc:description" comments anywhere in the file
+	DescriptionName     = "name"     // Top-level name field
+	DescriptionJob      = "job"      // Name, or else ID, of the first job
+)
+
+// DescriptionSources are the supported values of
+// ParseOptions.DescriptionFrom.
+var DescriptionSources = []string{DescriptionComments, DescriptionKey, DescriptionMarker, DescriptionName, DescriptionJob}
+
+// DefaultDescriptionFrom are the sources of the description unless
+// configured otherwise. The name and job sources are fallbacks to opt into,
+// as they describe what a workflow is called rather than what it does.
+var DefaultDescriptionFrom = []string{DescriptionComments, DescriptionKey, DescriptionMarker}
+
+// DescriptionKeyName is the top-level key holding the description of a
+// workflow with DescriptionKey.
+const DescriptionKeyName = "x-description"
+
+// DefaultCommentPrefix starts the lines of the doc comments holding the
+// description of a workflow with DescriptionComments.
+const DefaultCommentPrefix = "##"
+
+// descriptionMarkerPattern matches the comments holding the description of a
+// workflow with DescriptionMarker, e.g. "# ghadoc:description Runs CI.".
+var descriptionMarkerPattern = regexp.MustCompile(`(?m)^\s*#\s*ghadoc:description:?[ \t]*(.*?)\s*$`)
+
+// ParseOptions configures how workflow files are parsed.
+type ParseOptions struct {
+	// DescriptionFrom lists the sources of the description in order of
+	// preference: the first one a workflow has is used, and the description
+	// is empty if it has none. Defaults to DefaultDescriptionFrom.
+	DescriptionFrom []string
+
+	// PreambleLines is the number of blank lines, comments such as a
+	// license header, and "---" document markers that may precede the "##"
+	// doc comments. They must be on the very first line if zero.
+	PreambleLines int
+
+	// CommentPrefix starts the lines of the doc comments, e.g. "#!" or
+	// "# @doc". Defaults to DefaultCommentPrefix.
+	CommentPrefix string
+
+	// Partial only decodes the top-level keys of the workflow that are
+	// documented by default, such as name and jobs, which is faster for
+	// large workflows, but leaves the custom columns reading other keys
+	// empty; see ReadsDocument.
+	Partial bool
+}
+
+// validate reports unsupported settings of opts.
+func (opts ParseOptions) validate() error {
+	for _, source := range opts.DescriptionFrom {
+		if !hasAny(DescriptionSources, []string{source}) {
+			return fmt.Errorf("unsupported description source %q, expected one of %s", source, strings.Join(DescriptionSources, ", "))
+		}
+	}
+	if opts.CommentPrefix != "" && !strings.HasPrefix(strings.TrimSpace(opts.CommentPrefix), "#") {
+		return fmt.Errorf("invalid comment prefix %q, expected a YAML comment starting with #", opts.CommentPrefix)
+	}
+	if opts.PreambleLines < 0 {
+		return fmt.Errorf("invalid preamble lines %d, expected 0 or more", opts.PreambleLines)
+	}
+	return nil
+}
+
+// commentPrefix returns the prefix of the doc comment lines.
+func (opts ParseOptions) commentPrefix() string {
+	if prefix := strings.TrimSpace(opts.CommentPrefix); prefix != "" {
+		return prefix
+	}
+	return DefaultCommentPrefix
+}
+
+// descriptionFrom returns the sources of the description in order of
+// preference.
+func (opts ParseOptions) descriptionFrom() []string {
+	if len(opts.DescriptionFrom) == 0 {
+		return DefaultDescriptionFrom
+	}
+	return opts.DescriptionFrom
+}
+
+// describe sets the description of workflow from the first of the sources
+// of opts it has. root is the YAML document node of content, and comments
+// the description from the leading comments.
+func (opts ParseOptions) describe(workflow *WorkflowInfo, content []byte, root *yaml.Node, comments string) {
+	for _, source := range opts.descriptionFrom() {
+		var description string
+		switch source {
+		case DescriptionComments:
+			description = comments
+		case DescriptionKey:
+			description = keyDescription(workflow.Metadata, workflow.document)
+		case DescriptionMarker:
+			description = markerDescription(content)
+		case DescriptionName:
+			description = strings.TrimSpace(workflow.Name)
+		case DescriptionJob:
+			description = firstJobName(workflow.Jobs, root)
+		}
+		if description != "" {
+			workflow.Description = description
+			return
+		}
+	}
+	workflow.Description = ""
+}
+
+// keyDescription returns the description of the x-description key of the
+// workflow document, or else of the description key of its metadata block.
+func keyDescription(metadata, document map[string]interface{}) string {
+	for _, value := range []interface{}{document[DescriptionKeyName], metadata["description"]} {
+		if text, ok := value.(string); ok && strings.TrimSpace(text) != "" {
+			lines := strings.Split(strings.TrimSpace(text), "\n")
+			for i, line := range lines {
+				lines[i] = strings.TrimSpace(line)
+			}
+			return strings.Join(lines, "<br>")
+		}
+	}
+	return ""
+}
+
+// markerDescription returns the description of the "# ghadoc:description"
+// comments of content, one line per comment.
+func markerDescription(content []byte) string {
+	var lines []string
+	for _, match := range descriptionMarkerPattern.FindAllSubmatch(content, -1) {
+		lines = append(lines, string(match[1]))
+	}
+	return strings.Join(lines, "<br>")
+}
+
+// firstJobName returns the name, or else the ID, of the first job of the
+// workflow document node root, in file order.
+func firstJobName(jobs []JobInfo, root *yaml.Node) string {
+	_, jobsNode := topLevel(root, "jobs")
+	if jobsNode == nil || jobsNode.Kind != yaml.MappingNode || len(jobsNode.Content) == 0 {
+		return ""
+	}
+	id := jobsNode.Content[0].Value
+	for _, job := range jobs {
+		if job.ID == id && job.Name != "" {
+			return job.Name
+		}
+	}
+	return id
+}
@@ -0,0 +1,163 @@
+package generate
+
+import "testing"
+
+// TestDescriptionSources tests reading the description from each source in
+// the configured order
+func TestDescriptionSources(t *testing.T) {
+	content := []byte(`## From the comments.
+## --- metadata
+## description: From the metadata block.
+## ---
+name: Deploy
+# ghadoc:description From the marker,
+#   ghadoc:description: on two lines.
+on: workflow_dispatch
+jobs: {}
+`)
+
+	for _, test := range []struct {
+		from     []string
+		expected string
+	}{
+		{nil, "From the comments."},
+		{[]string{DescriptionKey, DescriptionComments}, "From the metadata block."},
+		{[]string{DescriptionMarker}, "From the marker,<br>on two lines."},
+	} {
+		workflow, err := ParseWorkflowWithOptions(content, ParseOptions{DescriptionFrom: test.from})
+		if err != nil {
+			t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+		}
+		if workflow.Description != test.expected {
+			t.Errorf("Expected description %q from %v, got %q", test.expected, test.from, workflow.Description)
+		}
+	}
+
+	// The x-description key is preferred over the metadata block, and a
+	// missing source falls through to the next one
+	workflow, err := ParseWorkflowWithOptions([]byte("x-description: |\n  First line\n  second line\non: push\n"), ParseOptions{DescriptionFrom: []string{DescriptionMarker, DescriptionKey}})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "First line<br>second line" {
+		t.Errorf("Expected the x-description key, got %q", workflow.Description)
+	}
+
+	if _, err := ParseWorkflowWithOptions(content, ParseOptions{DescriptionFrom: []string{"readme"}}); err == nil {
+		t.Error("Expected error for unsupported description source, got nil")
+	}
+}
+
+// TestDescriptionFallback tests falling back to the workflow and job names
+func TestDescriptionFallback(t *testing.T) {
+	content := []byte("name: Release\non: push\njobs:\n  publish:\n    name: Publish packages\n  build:\n    runs-on: ubuntu-latest\n")
+	from := []string{DescriptionComments, DescriptionName, DescriptionJob}
+
+	workflow, err := ParseWorkflowWithOptions(content, ParseOptions{DescriptionFrom: from})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "Release" {
+		t.Errorf("Expected the workflow name, got %q", workflow.Description)
+	}
+
+	// The first job in file order, not alphabetically
+	workflow, err = ParseWorkflowWithOptions(content[len("name: Release\n"):], ParseOptions{DescriptionFrom: from})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "Publish packages" {
+		t.Errorf("Expected the name of the first job, got %q", workflow.Description)
+	}
+
+	workflow, err = ParseWorkflow(content)
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if workflow.Description != "" {
+		t.Errorf("Expected no fallback by default, got %q", workflow.Description)
+	}
+}
+
+// TestPreambleLines tests reading doc comments that follow a license header
+// and a document marker
+func TestPreambleLines(t *testing.T) {
+	content := []byte(`# Copyright 2026 Example Corp.
+# SPDX-License-Identifier: Apache-2.0
+
+---
+## Builds every pull request.
+name: CI
+on: pull_request
+`)
+
+	for _, test := range []struct {
+		preambleLines int
+		expected      string
+	}{
+		{0, ""},
+		{3, ""},
+		{4, "Builds every pull request."},
+		{20, "Builds every pull request."},
+	} {
+		workflow, err := ParseWorkflowWithOptions(content, ParseOptions{PreambleLines: test.preambleLines})
+		if err != nil {
+			t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+		}
+		if workflow.Description != test.expected {
+			t.Errorf("Expected description %q with %d preamble lines, got %q", test.expected, test.preambleLines, workflow.Description)
+		}
+	}
+
+	// Doc comments after the first YAML key are not descriptions
+	workflow, err := ParseWorkflowWithOptions([]byte("name: CI\n## Not a description.\non: push\n"), ParseOptions{PreambleLines: 20})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "" {
+		t.Errorf("Expected no description, got %q", workflow.Description)
+	}
+
+	if _, err := ParseWorkflowWithOptions(content, ParseOptions{PreambleLines: -1}); err == nil {
+		t.Error("Expected error for negative preamble lines, got nil")
+	}
+}
+
+// TestCommentPrefix tests reading doc comments with another prefix
+func TestCommentPrefix(t *testing.T) {
+	content := []byte(`# @doc Deploys the site.
+# @doc @tags deploy
+# @doc --- metadata
+# @doc owner: web
+# @doc ---
+## Not read with the custom prefix.
+on: push
+`)
+
+	workflow, err := ParseWorkflowWithOptions(content, ParseOptions{CommentPrefix: "# @doc"})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "Deploys the site." {
+		t.Errorf("Expected description %q, got %q", "Deploys the site.", workflow.Description)
+	}
+	if workflow.Metadata["owner"] != "web" {
+		t.Errorf("Expected owner web, got %v", workflow.Metadata["owner"])
+	}
+	if tags := metadataValues(workflow.Metadata, "tags"); len(tags) != 1 || tags[0] != "deploy" {
+		t.Errorf("Expected tags [deploy], got %v", tags)
+	}
+
+	// The default prefix does not match the custom comments
+	workflow, err = ParseWorkflowWithOptions(content, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	if workflow.Description != "" {
+		t.Errorf("Expected no description with the default prefix, got %q", workflow.Description)
+	}
+
+	if _, err := ParseWorkflowWithOptions(content, ParseOptions{CommentPrefix: "//"}); err == nil {
+		t.Error("Expected error for a prefix that is not a YAML comment, got nil")
+	}
+}
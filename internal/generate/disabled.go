@@ -0,0 +1,48 @@
+package generate
+
+import (
+	"regexp"
+	"strings"
+)
+
+// DisabledSuffix marks a workflow file disabled by convention, e.g.
+// ci.yml.disabled, which GitHub does not run.
+const DisabledSuffix = ".disabled"
+
+// disabledPattern matches the comment marking a workflow disabled by
+// convention.
+var disabledPattern = regexp.MustCompile(`(?m)^\s*#\s*ghadoc:disabled\s*$`)
+
+// IsDisabledFile reports whether name is a workflow file disabled by the
+// DisabledSuffix convention.
+func (scanOpts ScanOptions) IsDisabledFile(name string) bool {
+	if len(name) <= len(DisabledSuffix) || !strings.EqualFold(name[len(name)-len(DisabledSuffix):], DisabledSuffix) {
+		return false
+	}
+	return scanOpts.IsWorkflowFile(name[:len(name)-len(DisabledSuffix)])
+}
+
+// splitDisabled separates the workflows disabled by convention from the
+// others, keeping their order.
+func splitDisabled(workflows []WorkflowInfo) ([]WorkflowInfo, []WorkflowInfo) {
+	var enabled, disabled []WorkflowInfo
+	for _, workflow := range workflows {
+		if workflow.Disabled {
+			disabled = append(disabled, workflow)
+		} else {
+			enabled = append(enabled, workflow)
+		}
+	}
+	return enabled, disabled
+}
+
+// writeDisabled writes a section with the table of the workflows disabled by
+// convention, headed in the language of opts.
+func writeDisabled(sb *strings.Builder, disabled []WorkflowInfo, opts Options) error {
+	if len(disabled) == 0 {
+		return nil
+	}
+
+	sb.WriteString("\n## " + opts.t("Disabled") + "\n\n")
+	return writeTable(sb, disabled, opts)
+}
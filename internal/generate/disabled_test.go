@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDisabled tests listing the workflows disabled by convention in a
+// section of their own
+func TestDisabled(t *testing.T) {
+	tempDir := createTempDir(t, "disabled")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "## Runs CI.\non: push\n")
+	createTempWorkflowFile(t, tempDir, "legacy.yml.disabled", "## Old deploys.\non: push\n")
+	createTempWorkflowFile(t, tempDir, "nightly.yml", "## Nightly build.\n# ghadoc:disabled\non: schedule\n")
+	createTempWorkflowFile(t, tempDir, "notes.txt.disabled", "Not a workflow.\n")
+
+	workflows, err := ScanDir(tempDir)
+	if err != nil {
+		t.Fatalf("ScanDir failed: %v", err)
+	}
+	if len(workflows) != 3 {
+		t.Fatalf("Expected 3 workflows, got %d", len(workflows))
+	}
+	for _, workflow := range workflows {
+		if expected := workflow.Filename != "ci.yml"; workflow.Disabled != expected {
+			t.Errorf("Expected %s disabled %v, got %v", workflow.Filename, expected, workflow.Disabled)
+		}
+	}
+
+	table, err := generateMarkdownTable(workflows, Options{})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	index := strings.Index(table, "\n## Disabled\n\n")
+	if index == -1 {
+		t.Fatalf("Expected a Disabled section, got:\n%s", table)
+	}
+	if !strings.Contains(table[:index], "[ci.yml](ci.yml)") {
+		t.Errorf("Expected ci.yml before the Disabled section, got:\n%s", table)
+	}
+	for _, filename := range []string{"legacy.yml.disabled", "nightly.yml"} {
+		if !strings.Contains(table[index:], "["+filename+"]") {
+			t.Errorf("Expected %s in the Disabled section, got:\n%s", filename, table)
+		}
+	}
+
+	// Without disabled workflows there is no section
+	table, err = generateMarkdownTable(workflows[:1], Options{})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	if strings.Contains(table, "Disabled") {
+		t.Errorf("Expected no Disabled section, got:\n%s", table)
+	}
+}
@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Formats of Dump.
+const (
+	DumpYAML = "yaml"
+	DumpJSON = "json"
+)
+
+// Dump renders everything parsed from a single workflow, to debug how it is
+// documented, as YAML or JSON. Both use the field names of the JSON output.
+func Dump(workflow WorkflowInfo, format string) (string, error) {
+	content, err := json.MarshalIndent(workflow, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	switch format {
+	case DumpJSON:
+		return string(content) + "\n", nil
+	case "", DumpYAML:
+		// JSON is YAML, so decoding it into a node keeps the order of the
+		// fields, and clearing the styles turns it into block YAML
+		var document yaml.Node
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return "", err
+		}
+		clearStyle(&document)
+		var sb strings.Builder
+		encoder := yaml.NewEncoder(&sb)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(&document); err != nil {
+			return "", err
+		}
+		return sb.String(), encoder.Close()
+	default:
+		return "", fmt.Errorf("unsupported dump format %q, expected %s or %s", format, DumpYAML, DumpJSON)
+	}
+}
+
+// clearStyle resets the style of node and its descendants to the default
+// block style and plain scalars.
+func clearStyle(node *yaml.Node) {
+	node.Style = 0
+	for _, child := range node.Content {
+		clearStyle(child)
+	}
+}
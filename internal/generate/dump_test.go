@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// TestDump tests dumping a parsed workflow as YAML and JSON
+func TestDump(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte("## Builds: everything.\nname: CI\non:\n  push:\n    branches: [main]\njobs:\n  build:\n    runs-on: ubuntu-latest\n"))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	workflow.Filename = "ci.yml"
+
+	dump, err := Dump(workflow, DumpYAML)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+	expected := `filename: ci.yml
+name: CI
+description: 'Builds: everything.'
+triggers:
+  - push
+filters:
+  push:
+    branches:
+      - main
+jobs:
+  - id: build
+    runs_on:
+      - ubuntu-latest
+on_line: 3
+`
+	if dump != expected {
+		t.Errorf("Expected YAML:\n%s\ngot:\n%s", expected, dump)
+	}
+
+	dump, err = Dump(workflow, DumpJSON)
+	if err != nil {
+		t.Fatalf("Dump failed: %v", err)
+	}
+	var decoded WorkflowInfo
+	if err := json.Unmarshal([]byte(dump), &decoded); err != nil || decoded.Description != "Builds: everything." {
+		t.Errorf("Expected the workflow as JSON, got %v:\n%s", err, dump)
+	}
+
+	if _, err := Dump(workflow, "xml"); err == nil || !strings.Contains(err.Error(), "xml") {
+		t.Errorf("Expected error for unsupported format, got %v", err)
+	}
+}
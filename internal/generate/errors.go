@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Classes of errors, to tell them apart with errors.Is.
+var (
+	// ErrNotAWorkflow is returned for YAML files that are not workflows,
+	// such as lists or mappings without triggers or jobs.
+	ErrNotAWorkflow = errors.New("not a workflow")
+
+	// ErrParse is matched by the errors of workflow files that fail to
+	// parse, including ParseError and ParseErrors.
+	ErrParse = errors.New("error parsing workflow")
+
+	// ErrOutputWrite is matched by the errors writing the generated
+	// documentation to files.
+	ErrOutputWrite = errors.New("error writing output")
+
+	// ErrLimit is matched by the ParseError of workflow files and
+	// directories skipped for exceeding ScanOptions.MaxFileSize or
+	// ScanOptions.MaxDepth.
+	ErrLimit = errors.New("scan limit exceeded")
+)
+
+// classError is an error of one of the classes above, keeping the message of
+// the error it wraps.
+type classError struct {
+	class error
+	err   error
+}
+
+// Error implements the error interface.
+func (e classError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap returns the class and the wrapped error, for errors.Is and
+// errors.As.
+func (e classError) Unwrap() []error {
+	return []error{e.class, e.err}
+}
+
+// outputError classifies err as an ErrOutputWrite.
+func outputError(err error) error {
+	return classError{ErrOutputWrite, err}
+}
+
+// ParseErrors aggregates the workflow files of a scan that failed to parse.
+type ParseErrors []ParseError
+
+// Error implements the error interface, listing every file on one line.
+func (e ParseErrors) Error() string {
+	if len(e) == 1 {
+		return e[0].Error()
+	}
+
+	messages := make([]string, len(e))
+	for i, parseError := range e {
+		messages[i] = parseError.Error()
+	}
+	return fmt.Sprintf("%d workflow files failed to parse: %s", len(e), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the error of every file, for errors.Is and errors.As.
+func (e ParseErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, parseError := range e {
+		errs[i] = parseError
+	}
+	return errs
+}
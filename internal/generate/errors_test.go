@@ -0,0 +1,70 @@
+package generate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestErrorClasses tests telling apart the errors of files that are not
+// workflows, that fail to parse, and of writing the output
+func TestErrorClasses(t *testing.T) {
+	for _, content := range []string{"- push\n", "name: Settings\nversion: 2\n"} {
+		_, err := ParseWorkflow([]byte(content))
+		if !errors.Is(err, ErrNotAWorkflow) || errors.Is(err, ErrParse) {
+			t.Errorf("Expected only ErrNotAWorkflow for %q, got %v", content, err)
+		}
+	}
+
+	_, err := ParseWorkflow([]byte("on: [push\n"))
+	if !errors.Is(err, ErrParse) || errors.Is(err, ErrNotAWorkflow) {
+		t.Errorf("Expected only ErrParse, got %v", err)
+	}
+	if strings.HasPrefix(err.Error(), ErrParse.Error()) {
+		t.Errorf("Expected the message of the YAML error, got %q", err)
+	}
+
+	// Invalid options are neither
+	_, err = ParseWorkflowWithOptions([]byte("on: push\n"), ParseOptions{CommentPrefix: "//"})
+	if err == nil || errors.Is(err, ErrParse) || errors.Is(err, ErrNotAWorkflow) {
+		t.Errorf("Expected an unclassified options error, got %v", err)
+	}
+
+	tempDir := createTempDir(t, "error-classes")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+	_, err = GenerateWithOptions(Options{WorkflowsDir: tempDir, Output: filepath.Join(tempDir, "missing", "workflows.md")})
+	if !errors.Is(err, ErrOutputWrite) {
+		t.Errorf("Expected ErrOutputWrite, got %v", err)
+	}
+	if _, statErr := os.Stat(filepath.Join(tempDir, "missing")); !os.IsNotExist(statErr) {
+		t.Errorf("Expected no output directory, got %v", statErr)
+	}
+}
+
+// TestStrictParseErrors tests failing a strict scan with every file that
+// fails to parse
+func TestStrictParseErrors(t *testing.T) {
+	tempDir := createTempDir(t, "parse-errors-strict")
+	createTempWorkflowFile(t, tempDir, "a.yml", "on: [push\n")
+	createTempWorkflowFile(t, tempDir, "b.yml", "- push\n")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+
+	_, _, err := ScanDirWithErrors(tempDir, ScanOptions{Strict: true})
+	var parseErrors ParseErrors
+	if !errors.As(err, &parseErrors) || len(parseErrors) != 2 {
+		t.Fatalf("Expected 2 parse errors, got %v", err)
+	}
+	if !errors.Is(err, ErrParse) || !errors.Is(err, ErrNotAWorkflow) {
+		t.Errorf("Expected the scan error to match the errors of both files, got %v", err)
+	}
+	if !strings.HasPrefix(err.Error(), "2 workflow files failed to parse: ") || !strings.Contains(err.Error(), "a.yml") || !strings.Contains(err.Error(), "b.yml") {
+		t.Errorf("Unexpected message: %v", err)
+	}
+
+	// A single file is reported as it is
+	if message := parseErrors[:1].Error(); message != parseErrors[0].Error() {
+		t.Errorf("Expected %q, got %q", parseErrors[0].Error(), message)
+	}
+}
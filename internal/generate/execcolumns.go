@@ -0,0 +1,102 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// execPrefix marks the path of a column given as NAME=exec:COMMAND, whose
+// values are provided by running COMMAND.
+const execPrefix = "exec:"
+
+// execColumnTimeout is the time allowed for the command of a column.
+const execColumnTimeout = time.Minute
+
+// ColumnRequest is written as JSON to the standard input of the command of
+// a column.
+type ColumnRequest struct {
+	Column    string         `json:"column"`    // Name of the column
+	Workflows []WorkflowInfo `json:"workflows"` // Workflows to provide values for
+}
+
+// ColumnResponse is read as JSON from the standard output of the command of
+// a column.
+type ColumnResponse struct {
+	// Values holds the values of the column for every workflow of the
+	// request, in order.
+	Values [][]string `json:"values"`
+}
+
+// runColumnCommand runs the command of column once for all workflows and
+// returns the values of every workflow by workflowKey. The command is killed
+// once ctx is done or after execColumnTimeout.
+func runColumnCommand(ctx context.Context, column Column, workflows []WorkflowInfo) (map[string][]string, error) {
+	args := strings.Fields(column.Exec)
+	if len(args) == 0 {
+		return nil, fmt.Errorf("column %s has no command", column.Name)
+	}
+
+	request, err := json.Marshal(ColumnRequest{Column: column.Name, Workflows: append([]WorkflowInfo{}, workflows...)})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, execColumnTimeout)
+	defer cancel()
+	var stdout, stderr bytes.Buffer
+	command := exec.CommandContext(ctx, args[0], args[1:]...)
+	command.Stdin = bytes.NewReader(request)
+	command.Stdout, command.Stderr = &stdout, &stderr
+	if err := command.Run(); err != nil {
+		if message := strings.TrimSpace(stderr.String()); message != "" {
+			return nil, fmt.Errorf("error running the command of column %s: %v: %s", column.Name, err, message)
+		}
+		return nil, fmt.Errorf("error running the command of column %s: %v", column.Name, err)
+	}
+
+	var response ColumnResponse
+	if err := json.Unmarshal(stdout.Bytes(), &response); err != nil {
+		return nil, fmt.Errorf("invalid output of the command of column %s: %v", column.Name, err)
+	}
+	if len(response.Values) != len(workflows) {
+		return nil, fmt.Errorf("invalid output of the command of column %s: %d values for %d workflows", column.Name, len(response.Values), len(workflows))
+	}
+
+	values := make(map[string][]string, len(workflows))
+	for i, workflow := range workflows {
+		values[workflowKey(workflow)] = response.Values[i]
+	}
+	return values, nil
+}
+
+// resolveExecColumns runs the commands of the exec columns of opts for
+// workflows, keeping their values in opts for the table. The commands are
+// killed once ctx is done.
+func resolveExecColumns(ctx context.Context, workflows []WorkflowInfo, opts Options) (Options, error) {
+	for _, column := range opts.Columns {
+		if column.Exec == "" {
+			continue
+		}
+		values, err := runColumnCommand(ctx, column, workflows)
+		if err != nil {
+			return opts, err
+		}
+		if opts.execValues == nil {
+			opts.execValues = make(map[string]map[string][]string)
+		}
+		opts.execValues[column.Name] = values
+	}
+	return opts, nil
+}
+
+// workflowKey identifies workflow among those documented together.
+func workflowKey(workflow WorkflowInfo) string {
+	return path.Join(filepath.ToSlash(workflow.Dir), workflow.Filename)
+}
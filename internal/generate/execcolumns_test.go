@@ -0,0 +1,91 @@
+package generate
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeColumnCommand writes a shell script that saves its standard input to
+// request.json in dir and prints output
+func writeColumnCommand(t *testing.T, dir, output string) string {
+	t.Helper()
+	script := filepath.Join(dir, "column.sh")
+	content := "#!/bin/sh\ncat > " + filepath.Join(dir, "request.json") + "\nprintf '%s' '" + output + "'\n"
+	if err := os.WriteFile(script, []byte(content), 0755); err != nil {
+		t.Fatalf("Failed to write column command: %v", err)
+	}
+	return script
+}
+
+// TestExecColumns tests columns whose values are provided by a command
+func TestExecColumns(t *testing.T) {
+	column, err := ParseColumn("Owner = exec: ./owners --team")
+	if err != nil || column != (Column{Name: "Owner", Exec: "./owners --team"}) {
+		t.Errorf("Unexpected column %+v (%v)", column, err)
+	}
+	if _, err := ParseColumn("Owner=exec:"); err == nil {
+		t.Error("Expected error for a column without command, got nil")
+	}
+
+	dir := t.TempDir()
+	script := writeColumnCommand(t, dir, `{"values": [["platform"], ["release", "sre"]]}`)
+	workflows := []WorkflowInfo{{Filename: "ci.yml"}, {Filename: "deploy.yml"}}
+	opts := Options{Columns: []Column{{Name: "Owner", Exec: script}}}
+
+	table, err := render(context.Background(), workflows, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, row := range []string{"| platform |", "| release, sre |"} {
+		if !strings.Contains(table, row) {
+			t.Errorf("Expected %q in the table, got:\n%s", row, table)
+		}
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, "request.json"))
+	if err != nil {
+		t.Fatalf("Failed to read request: %v", err)
+	}
+	var request ColumnRequest
+	if err := json.Unmarshal(content, &request); err != nil {
+		t.Fatalf("Request is not valid JSON: %v", err)
+	}
+	if request.Column != "Owner" || len(request.Workflows) != 2 || request.Workflows[1].Filename != "deploy.yml" {
+		t.Errorf("Unexpected request: %+v", request)
+	}
+
+	// Responses that don't match the workflows fail rendering
+	writeColumnCommand(t, dir, `{"values": [["platform"]]}`)
+	if _, err := render(context.Background(), workflows, opts); err == nil || !strings.Contains(err.Error(), "1 values for 2 workflows") {
+		t.Errorf("Expected error for missing values, got %v", err)
+	}
+	opts.Columns[0].Exec = filepath.Join(dir, "missing")
+	if _, err := render(context.Background(), workflows, opts); err == nil || !strings.Contains(err.Error(), "column Owner") {
+		t.Errorf("Expected error for a missing command, got %v", err)
+	}
+}
+
+// TestExecColumnsCanceled tests killing the command of a column once the
+// context of rendering is done
+func TestExecColumnsCanceled(t *testing.T) {
+	script := filepath.Join(t.TempDir(), "column.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\nexec sleep 30\n"), 0755); err != nil {
+		t.Fatalf("Failed to write column command: %v", err)
+	}
+	opts := Options{Columns: []Column{{Name: "Owner", Exec: script}}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	if _, err := render(ctx, []WorkflowInfo{{Filename: "ci.yml"}}, opts); err == nil {
+		t.Error("Expected error for a canceled command, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the command to be killed, took %v", elapsed)
+	}
+}
@@ -0,0 +1,32 @@
+package generate
+
+// filterWorkflows returns the workflows selected by all the filters of opts.
+func filterWorkflows(workflows []WorkflowInfo, opts Options) []WorkflowInfo {
+	if len(opts.FilterTriggers) == 0 && len(opts.FilterTags) == 0 {
+		return workflows
+	}
+
+	var filtered []WorkflowInfo
+	for _, workflow := range workflows {
+		if len(opts.FilterTriggers) > 0 && !hasAny(workflow.Triggers, opts.FilterTriggers) {
+			continue
+		}
+		if len(opts.FilterTags) > 0 && !hasAny(metadataValues(workflow.Metadata, "tags", "tag"), opts.FilterTags) {
+			continue
+		}
+		filtered = append(filtered, workflow)
+	}
+	return filtered
+}
+
+// hasAny reports whether values contains any of wanted.
+func hasAny(values, wanted []string) bool {
+	for _, value := range values {
+		for _, w := range wanted {
+			if value == w {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package generate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestFilterTriggers tests documenting only the workflows with given triggers
+func TestFilterTriggers(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"pull_request", "push"}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}},
+		{Filename: "release.yml", Triggers: []string{"push", "workflow_dispatch"}},
+	}
+
+	for _, test := range []struct {
+		triggers []string
+		expected []string
+	}{
+		{nil, []string{"ci.yml", "nightly.yml", "release.yml"}},
+		{[]string{"schedule", "workflow_dispatch"}, []string{"nightly.yml", "release.yml"}},
+		{[]string{"merge_group"}, nil},
+	} {
+		var filenames []string
+		for _, workflow := range filterWorkflows(workflows, Options{FilterTriggers: test.triggers}) {
+			filenames = append(filenames, workflow.Filename)
+		}
+		if !reflect.DeepEqual(filenames, test.expected) {
+			t.Errorf("Expected filter %v to select %v, got %v", test.triggers, test.expected, filenames)
+		}
+	}
+}
+
+// TestFilterTags tests documenting only the workflows with given tags
+func TestFilterTags(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "deploy.yml", Triggers: []string{"push"}, Metadata: map[string]interface{}{"tags": []interface{}{"deploy", "production"}}},
+		{Filename: "scan.yml", Triggers: []string{"schedule"}, Metadata: map[string]interface{}{"tags": []interface{}{"security"}}},
+		{Filename: "ci.yml", Triggers: []string{"push"}},
+	}
+
+	for _, test := range []struct {
+		opts     Options
+		expected []string
+	}{
+		{Options{FilterTags: []string{"deploy", "security"}}, []string{"deploy.yml", "scan.yml"}},
+		{Options{FilterTags: []string{"security"}, FilterTriggers: []string{"push"}}, nil},
+		{Options{FilterTags: []string{"production"}, FilterTriggers: []string{"push"}}, []string{"deploy.yml"}},
+	} {
+		var filenames []string
+		for _, workflow := range filterWorkflows(workflows, test.opts) {
+			filenames = append(filenames, workflow.Filename)
+		}
+		if !reflect.DeepEqual(filenames, test.expected) {
+			t.Errorf("Expected tags %v and triggers %v to select %v, got %v", test.opts.FilterTags, test.opts.FilterTriggers, test.expected, filenames)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package generate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strings"
+)
+
+// Document is the JSON representation of the workflows of a source
+// directory.
+type Document struct {
+	Source    string         `json:"source"`
+	Workflows []WorkflowInfo `json:"workflows"`
+	Warnings  []Warning      `json:"warnings,omitempty"`
+}
+
+// writeCSV writes a CSV document with the columns of the summary table to w.
+// Multi-line descriptions keep their line breaks within the quoted field.
+func writeCSV(w io.Writer, workflows []WorkflowInfo) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"Filename", "Description", "Triggers"})
+	for _, workflow := range workflows {
+		writer.Write([]string{
+			workflow.Filename,
+			strings.ReplaceAll(workflow.Description, "<br>", "\n"),
+			strings.Join(workflow.Triggers, ", "),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeJSON writes a JSON document with everything known about the workflows
+// of the directories described by source, and the warnings about them, to w.
+func writeJSON(w io.Writer, workflows []WorkflowInfo, source string, warnings []Warning) error {
+	document := Document{
+		Source:    source,
+		Workflows: []WorkflowInfo{},
+		Warnings:  warnings,
+	}
+	for _, workflow := range workflows {
+		if workflow.Triggers == nil {
+			workflow.Triggers = []string{}
+		}
+		document.Workflows = append(document.Workflows, workflow)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(document)
+}
@@ -0,0 +1,96 @@
+package generate
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestParseJobs tests extraction of jobs, runners, and steps
+func TestParseJobs(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`on: push
+jobs:
+  test:
+    name: Test
+    runs-on: [self-hosted, linux]
+    permissions:
+      contents: read
+    steps:
+      - uses: actions/checkout@v4
+        with:
+          fetch-depth: 0
+      - id: test
+        run: go test ./...
+  build:
+    runs-on:
+      group: large-runners
+      labels: ubuntu-latest
+  release:
+    uses: octo-org/workflows/.github/workflows/release.yml@main
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	expected := []JobInfo{
+		{ID: "build", RunsOn: []string{"group:large-runners", "ubuntu-latest"}},
+		{ID: "release", Uses: "octo-org/workflows/.github/workflows/release.yml@main"},
+		{ID: "test", Name: "Test", RunsOn: []string{"self-hosted", "linux"}, Permissions: []string{"contents: read"}, Steps: []StepInfo{
+			{Uses: "actions/checkout@v4", With: map[string]string{"fetch-depth": "0"}},
+			{ID: "test", Run: "go test ./..."},
+		}},
+	}
+	if !reflect.DeepEqual(workflow.Jobs, expected) {
+		t.Errorf("Expected jobs %+v, got %+v", expected, workflow.Jobs)
+	}
+}
+
+// TestWriteCSV tests the CSV export of the summary table
+func TestWriteCSV(t *testing.T) {
+	var sb strings.Builder
+	err := writeCSV(&sb, []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs tests.<br>Lints, too.", Triggers: []string{"pull_request", "push"}},
+		{Filename: "empty.yml"},
+	})
+	if err != nil {
+		t.Fatalf("writeCSV failed: %v", err)
+	}
+	content := sb.String()
+
+	expected := "Filename,Description,Triggers\n" +
+		"ci.yml,\"Runs tests.\nLints, too.\",\"pull_request, push\"\n" +
+		"empty.yml,,\n"
+	if content != expected {
+		t.Errorf("Expected CSV:\n%s\nGot:\n%s", expected, content)
+	}
+}
+
+// TestWriteJSON tests the JSON export of the workflows
+func TestWriteJSON(t *testing.T) {
+	var sb strings.Builder
+	err := writeJSON(&sb, []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs tests.", Triggers: []string{"push"}, Secrets: []string{"TOKEN"}},
+		{Filename: "empty.yml"},
+	}, ".github/workflows", nil)
+	if err != nil {
+		t.Fatalf("writeJSON failed: %v", err)
+	}
+
+	var document Document
+	if err := json.Unmarshal([]byte(sb.String()), &document); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if document.Source != ".github/workflows" {
+		t.Errorf("Expected source %q, got %q", ".github/workflows", document.Source)
+	}
+	if len(document.Workflows) != 2 || document.Workflows[0].Secrets[0] != "TOKEN" {
+		t.Errorf("Unexpected workflows: %+v", document.Workflows)
+	}
+
+	// Workflows without triggers are exported with an empty list
+	if !strings.Contains(sb.String(), `"triggers": []`) {
+		t.Errorf("Expected empty triggers list in output:\n%s", sb.String())
+	}
+}
@@ -2,118 +2,1086 @@ package generate
 
 import (
 	"bufio"
+	"bytes"
+	"context"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
+	"log/slog"
 	"os"
+	"path"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"runtime"
 	"sort"
 	"strings"
+	"sync"
+	"time"
 
+	"github.com/droctothorpe/gha-docs/internal/git"
 	"gopkg.in/yaml.v3"
 )
 
 // WorkflowInfo stores information about a GitHub workflow
 type WorkflowInfo struct {
-	Filename    string
-	Description string
-	Triggers    []string // List of all triggers (e.g., push, pull_request, workflow_dispatch, etc.)
+	Filename     string                   `json:"filename"`
+	Name         string                   `json:"name,omitempty"` // Value of the top-level "name" field
+	Description  string                   `json:"description"`
+	Triggers     []string                 `json:"triggers"`               // List of all triggers (e.g., push, pull_request, workflow_dispatch, etc.)
+	Filters      map[string]TriggerFilter `json:"filters,omitempty"`      // Filters configured for each trigger, keyed by trigger
+	Schedules    []string                 `json:"schedules,omitempty"`    // Cron expressions of the schedule trigger
+	Secrets      []string                 `json:"secrets,omitempty"`      // Names of the secrets referenced by the workflow
+	Environments []string                 `json:"environments,omitempty"` // Deployment environments used by the workflow's jobs
+	Permissions  []string                 `json:"permissions,omitempty"`  // Top-level GITHUB_TOKEN permissions, e.g. "contents: read" or "read-all"
+	Jobs         []JobInfo                `json:"jobs,omitempty"`
+	Metadata     map[string]interface{}   `json:"metadata,omitempty"` // Key/values from the metadata block in the leading comments
+	Dir          string                   `json:"dir,omitempty"`      // Workflows directory of the file, when several are documented together
+	OnLine       int                      `json:"on_line,omitempty"`  // Line of the "on" key in the workflow file, 0 if it has none
+	Disabled     bool                     `json:"disabled,omitempty"` // Disabled by convention with DisabledSuffix or a "# ghadoc:disabled" comment
+
+	document map[string]interface{} // Parsed workflow file, which custom columns are extracted from
+	modified time.Time              // Modification time of the local workflow file
+	target   string                 // Path of the file a symlinked workflow file points to, with SymlinksResolve
+	warnings []string               // Problems that did not stop parsing, such as a malformed metadata block
+}
+
+// TriggerFilter holds the filters configured for a trigger.
+type TriggerFilter struct {
+	Branches       []string `json:"branches,omitempty"`
+	BranchesIgnore []string `json:"branches_ignore,omitempty"`
+	Tags           []string `json:"tags,omitempty"`
+	TagsIgnore     []string `json:"tags_ignore,omitempty"`
+	Paths          []string `json:"paths,omitempty"`
+	PathsIgnore    []string `json:"paths_ignore,omitempty"`
+	Types          []string `json:"types,omitempty"`
+}
+
+// secretPattern matches references to secrets in expressions.
+var secretPattern = regexp.MustCompile(`secrets\.([A-Za-z_][A-Za-z0-9_]*)|secrets\[\s*'([A-Za-z_][A-Za-z0-9_]*)'\s*\]`)
+
+// metadataMarker closes the metadata block in the leading comments, and
+// metadataOpener opens it, so that decorative "## ---" lines in existing
+// descriptions are not taken for a block.
+const (
+	metadataMarker = "---"
+	metadataOpener = metadataMarker + " metadata"
+)
+
+// tagsAnnotation starts a leading comment listing tags of the workflow,
+// e.g. "## @tags deploy, production", as a shorthand for the tags key of the
+// metadata block.
+const tagsAnnotation = "@tags"
+
+// Built-in output formats; Formats lists every registered format.
+const (
+	FormatMarkdown = "markdown"
+	FormatHTML     = "html"
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+)
+
+// StdoutOutput as Options.Output writes the documentation to stdout. Links
+// to the workflow files are then relative to the current directory.
+const StdoutOutput = "-"
+
+// Options configures documentation generation.
+type Options struct {
+	WorkflowsDir string // Directory containing the workflow files
+	Output       string // Path of the generated file, or StdoutOutput
+	Inject       string // Path of a file to inject into between InjectStart and InjectEnd instead of writing Output
+	Format       string // Output format, one of Formats(); defaults to FormatMarkdown
+	PagesDir     string // Optional directory to write one page per workflow into
+	RepoURL      string // URL of the repository on GitHub, e.g. https://github.com/owner/repo
+	Badges       bool   // Add a status badge column; requires RepoURL
+	BadgeStyle   string // Badge style; defaults to BadgeStyleGitHub
+	Branch       string // Branch reported by status badges; defaults to the default branch
+	Ref          string // Git ref to link workflow files at on RepoURL; links are relative if empty
+	TriggerHints bool   // Add notes on the context and payload each trigger provides
+	TriggerLinks bool   // Link the Triggers cells to the line of the "on" block
+	Security     bool   // Add security notes for workflows that run on pull requests from forks
+
+	// Status holds the latest run of each workflow, keyed by filename. When
+	// set, Status and Last Run columns are added to the table.
+	Status map[string]RunStatus
+
+	// Metrics holds the success rate and median duration of the recent runs
+	// of each workflow, keyed by filename. When set, Success Rate and Median
+	// Duration columns are added to the table.
+	Metrics map[string]RunMetrics
+
+	// State holds the state of each workflow in GitHub as reported by the
+	// API, e.g. active or disabled_inactivity, keyed by filename. When set, a
+	// State column is added to the table.
+	State map[string]string
+
+	// ParseErrors lists the workflow files skipped because they failed to
+	// parse. When set, a Parse errors section is added to the markdown
+	// table unless HideParseErrors is set, and a warnings list to JSON
+	// output. Generating documentation sets it from the scan.
+	ParseErrors     []ParseError
+	HideParseErrors bool
+
+	// StepSummary is the path of a GitHub Actions job summary file, usually
+	// the value of StepSummaryEnv. When set, the markdown table is appended
+	// to it.
+	StepSummary string
+
+	// Template is the path of a text/template to render the workflows
+	// through instead of the built-in markdown table. It is executed with
+	// TemplateData.
+	Template string
+
+	// Theme is the name of a built-in template to render the workflows
+	// through instead of the built-in table; see Themes.
+	Theme string
+
+	// Header and Footer are markdown prepended and appended to markdown
+	// output, e.g. an introduction or a notice not to edit the file.
+	Header string
+	Footer string
+
+	// Provenance adds a banner to the top of markdown output naming the tool
+	// and Version, the time of generation, and the source directory, with a
+	// warning not to edit the file by hand. The time is left out if
+	// Timestamp is zero, for reproducible output.
+	Provenance bool
+	Version    string
+	Timestamp  time.Time
+
+	// Reproducible guarantees identical output for identical workflows on
+	// any system: workflows are sorted by filename unless sorted otherwise,
+	// the time is left out of the provenance banner, and line endings are
+	// normalized. Sorting by modification time is an error.
+	Reproducible bool
+
+	// TOC adds a table of contents linking to the sections of markdown
+	// output, such as the groups or the workflow sections of the detailed theme.
+	TOC bool
+
+	// Lang is the language of the headings and boilerplate text, e.g. de;
+	// defaults to DefaultLanguage. See Languages.
+	Lang string
+
+	// Columns are user-defined columns added to the table after Triggers.
+	Columns []Column
+
+	// ColumnFormats configures how the values of the columns of the table
+	// are formatted, keyed by column header, e.g. Triggers.
+	ColumnFormats map[string]ColumnFormat
+
+	// WorkflowsDirs are several directories containing workflow files,
+	// documented together in place of WorkflowsDir.
+	WorkflowsDirs []string
+
+	// FilterTriggers limits the workflows to those run by any of the given
+	// triggers, e.g. schedule. All workflows are documented if empty.
+	FilterTriggers []string
+
+	// FilterTags limits the workflows to those with any of the given tags in
+	// their metadata, e.g. deploy. All workflows are documented if empty.
+	FilterTags []string
+
+	// Sort orders the workflows; see Sort constants. Workflows keep the order
+	// they are scanned in if empty. Desc reverses the order.
+	Sort string
+	Desc bool
+
+	// GroupBy splits the table into a section per group; see GroupBy
+	// constants. The table is not split if empty.
+	GroupBy string
+
+	// DirReadmes writes a README documenting the workflows of each scanned
+	// directory into that directory, e.g. of every subdirectory with
+	// ScanOptions.Recursive.
+	DirReadmes bool
+
+	// ScanOptions configures how the local WorkflowsDir is scanned.
+	ScanOptions ScanOptions
+
+	// Scan loads the workflows of WorkflowsDir; defaults to scanning the
+	// local directory with ScanOptions. Set it to document workflows that
+	// are not in a local directory. It also returns the files skipped
+	// because they failed to parse.
+	Scan func(workflowsDir string) ([]WorkflowInfo, []ParseError, error)
+
+	// DryRun, if set, is called with the path and content of every file
+	// that would be written, instead of writing it, to preview generation.
+	// Files that would be left unchanged are not reported.
+	DryRun func(path string, content []byte)
+
+	messages   map[string]string              // Translation bundle of Lang
+	execValues map[string]map[string][]string // Values of the exec columns by column and workflowKey
+	scanned    map[scanKey]scanResult         // Directories already scanned, with ReuseScans
+}
+
+// scanKey identifies a scan of a directory: partial scans only decode the
+// parts of the workflows the built-in columns read.
+type scanKey struct {
+	dir     string
+	partial bool
+}
+
+// scanResult is the outcome of scanning a directory.
+type scanResult struct {
+	workflows   []WorkflowInfo
+	parseErrors []ParseError
+}
+
+// ReuseScans makes opts, and the options copied from it, keep the workflows
+// of every directory they scan, so that generating after the caller scanned
+// the workflows, e.g. to fetch their runs, does not scan the directories
+// again.
+func (opts *Options) ReuseScans() {
+	opts.scanned = make(map[scanKey]scanResult)
 }
 
 // Generate generates the workflows.md file from the workflow files in the
 // specified workflowsDir.
-func Generate(workflowsDir string, output string) error {
-	// Get all workflow files
-	files, err := os.ReadDir(workflowsDir)
+func Generate(workflowsDir string, output string) ([]Warning, error) {
+	return GenerateWithOptions(Options{WorkflowsDir: workflowsDir, Output: output})
+}
+
+// GenerateWithOptions generates documentation for the workflow files in
+// opts.WorkflowsDir and writes it to opts.Output in the requested format, or
+// injects it into opts.Inject. It returns warnings about problems that did
+// not stop generation, such as skipped workflow files, for the caller to
+// report.
+func GenerateWithOptions(opts Options) ([]Warning, error) {
+	return GenerateContext(context.Background(), opts)
+}
+
+// GenerateContext is GenerateWithOptions, giving up scanning the workflows
+// once ctx is done.
+func GenerateContext(ctx context.Context, opts Options) ([]Warning, error) {
+	if err := opts.loadLanguage(); err != nil {
+		return nil, err
+	}
+	if opts.Badges && opts.RepoURL == "" {
+		return nil, fmt.Errorf("a repository URL is required to add badges")
+	}
+	if opts.DirReadmes && opts.Scan != nil {
+		return nil, fmt.Errorf("per-directory READMEs require local workflows directories")
+	}
+
+	if opts.Inject != "" {
+		// Links are relative to the file the documentation ends up in
+		opts.Output = opts.Inject
+	}
+
+	workflows, parseErrors, err := opts.ScanWorkflows(ctx)
 	if err != nil {
-		return fmt.Errorf("error reading workflows directory: %v", err)
+		return nil, err
+	}
+	opts.ParseErrors = append(opts.ParseErrors, parseErrors...)
+
+	if opts.Inject != "" {
+		content, err := render(ctx, workflows, opts)
+		if err != nil {
+			return nil, err
+		}
+		err = opts.injectFile(opts.Inject, content)
+		if err != nil {
+			return nil, err
+		}
+		if opts.DryRun == nil {
+			slog.Info("Successfully injected", "path", opts.Inject)
+		}
+	} else if opts.Output == StdoutOutput {
+		if err := RenderToContext(ctx, os.Stdout, workflows, opts); err != nil {
+			return nil, err
+		}
+	} else if opts.DryRun != nil {
+		var content bytes.Buffer
+		if err := RenderToContext(ctx, &content, workflows, opts); err != nil {
+			return nil, err
+		}
+		opts.DryRun(opts.Output, content.Bytes())
+	} else {
+		// Stream to the output file
+		output := &fileWriter{path: opts.Output}
+		err = RenderToContext(ctx, output, workflows, opts)
+		if err == nil {
+			// Create the file even if the output is empty
+			_, err = output.Write(nil)
+		}
+		if closeErr := output.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			return nil, err
+		}
+		slog.Info("Successfully generated", "path", opts.Output)
+	}
+
+	return warnings(opts.ParseErrors), writeExtras(ctx, workflows, opts)
+}
+
+// fileWriter writes to the file at path, created on the first write so that
+// rendering that fails before writing anything leaves no file behind.
+type fileWriter struct {
+	path string
+	file *os.File
+}
+
+// Write implements io.Writer.
+func (w *fileWriter) Write(p []byte) (int, error) {
+	if w.file == nil {
+		file, err := os.OpenFile(w.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+		if err != nil {
+			return 0, outputError(fmt.Errorf("error writing to output file: %v", err))
+		}
+		w.file = file
+	}
+	n, err := w.file.Write(p)
+	if err != nil {
+		err = outputError(fmt.Errorf("error writing to output file: %v", err))
+	}
+	return n, err
+}
+
+// Close closes the file if it was created.
+func (w *fileWriter) Close() error {
+	if w.file == nil {
+		return nil
+	}
+	if err := w.file.Close(); err != nil {
+		return outputError(fmt.Errorf("error writing to output file: %v", err))
+	}
+	return nil
+}
+
+// writeExtras writes the pages, per-directory READMEs, and job summary
+// requested by opts. The commands of exec columns are killed once ctx is
+// done.
+func writeExtras(ctx context.Context, workflows []WorkflowInfo, opts Options) error {
+	if opts.DirReadmes {
+		err := writeDirReadmes(ctx, workflows, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if opts.PagesDir != "" {
+		err := generatePages(workflows, opts)
+		if err != nil {
+			return err
+		}
+		if opts.DryRun == nil {
+			slog.Info("Successfully generated pages", "dir", opts.PagesDir)
+		}
+	}
+
+	if opts.StepSummary != "" {
+		err := writeStepSummary(workflows, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Render scans the workflows of opts and renders them in the format of opts,
+// without writing any files.
+func Render(opts Options) (string, error) {
+	return RenderContext(context.Background(), opts)
+}
+
+// RenderContext is Render, giving up scanning the workflows and killing the
+// commands of exec columns once ctx is done.
+func RenderContext(ctx context.Context, opts Options) (string, error) {
+	workflows, parseErrors, err := opts.ScanWorkflows(ctx)
+	if err != nil {
+		return "", err
+	}
+	opts.ParseErrors = append(opts.ParseErrors, parseErrors...)
+	return render(ctx, workflows, opts)
+}
+
+// RenderWorkflows renders workflows, loaded by the caller, in the format of
+// opts without writing any files.
+func RenderWorkflows(workflows []WorkflowInfo, opts Options) (string, error) {
+	return render(context.Background(), workflows, opts)
+}
+
+// ScanWorkflows loads the workflows of opts.WorkflowsDir, or of every
+// directory of opts.WorkflowsDirs, with opts.Scan, or else by scanning the
+// local directories with opts.ScanOptions. Only the workflows selected by the
+// filters of opts are returned, sorted by opts.Sort, along with the files
+// skipped because they failed to parse. Local directories are scanned until
+// ctx is done.
+func (opts Options) ScanWorkflows(ctx context.Context) ([]WorkflowInfo, []ParseError, error) {
+	if len(opts.WorkflowsDirs) == 0 {
+		workflows, parseErrors, err := opts.scan(ctx, opts.WorkflowsDir)
+		if err != nil {
+			return nil, nil, err
+		}
+		workflows = filterWorkflows(workflows, opts)
+		return workflows, parseErrors, sortWorkflows(workflows, opts)
+	}
+
+	var all []WorkflowInfo
+	var allParseErrors []ParseError
+	for _, dir := range opts.WorkflowsDirs {
+		workflows, parseErrors, err := opts.scan(ctx, dir)
+		if err != nil {
+			return nil, nil, err
+		}
+		for i := range workflows {
+			workflows[i].Dir = dir
+		}
+		for i := range parseErrors {
+			parseErrors[i].Dir = dir
+		}
+		all = append(all, workflows...)
+		allParseErrors = append(allParseErrors, parseErrors...)
+	}
+	all = orderByCalls(all, opts.WorkflowsDirs, opts)
+	all = filterWorkflows(all, opts)
+	return all, allParseErrors, sortWorkflows(all, opts)
+}
+
+// scan loads the workflows of dir, only decoding the whole workflow
+// documents if the columns of opts read them.
+func (opts Options) scan(ctx context.Context, dir string) ([]WorkflowInfo, []ParseError, error) {
+	key := scanKey{dir: dir, partial: !ReadsDocument(opts.Columns)}
+	if result, ok := opts.scanned[key]; ok {
+		// Copies, as the workflows are filtered and sorted in place
+		return append([]WorkflowInfo(nil), result.workflows...), append([]ParseError(nil), result.parseErrors...), nil
 	}
 
-	// Store workflow information
 	var workflows []WorkflowInfo
+	var parseErrors []ParseError
+	var err error
+	if opts.Scan != nil {
+		workflows, parseErrors, err = opts.Scan(dir)
+	} else {
+		scanOpts := opts.ScanOptions
+		scanOpts.Parse.Partial = key.partial
+		workflows, parseErrors, err = ScanDirContext(ctx, dir, scanOpts)
+	}
+	if err == nil && opts.scanned != nil {
+		opts.scanned[key] = scanResult{
+			workflows:   append([]WorkflowInfo(nil), workflows...),
+			parseErrors: append([]ParseError(nil), parseErrors...),
+		}
+	}
+	return workflows, parseErrors, err
+}
 
-	// Process each workflow file
-	for _, file := range files {
-		ext := filepath.Ext(file.Name())
-		if !file.IsDir() && (ext == ".yml" || ext == ".yaml") {
-			filePath := filepath.Join(workflowsDir, file.Name())
-			workflow, err := parseWorkflowFile(filePath)
-			if err != nil {
-				fmt.Printf("Error parsing workflow file %s: %v\n", file.Name(), err)
+// workflowsDir returns the workflows directory of workflow.
+func (opts Options) workflowsDir(workflow WorkflowInfo) string {
+	if workflow.Dir != "" {
+		return workflow.Dir
+	}
+	return opts.WorkflowsDir
+}
+
+// displayName returns the name of workflow in the table: its filename,
+// prefixed by its directory when several are documented together unless
+// the table is grouped by directory.
+func (opts Options) displayName(workflow WorkflowInfo) string {
+	if workflow.Dir == "" || opts.GroupBy == GroupByDirectory {
+		return workflow.Filename
+	}
+	return path.Join(filepath.ToSlash(workflow.Dir), workflow.Filename)
+}
+
+// source describes the workflows directories of opts.
+func (opts Options) source() string {
+	if len(opts.WorkflowsDirs) == 0 {
+		return filepath.ToSlash(opts.WorkflowsDir)
+	}
+	dirs := make([]string, len(opts.WorkflowsDirs))
+	for i, dir := range opts.WorkflowsDirs {
+		dirs[i] = filepath.ToSlash(dir)
+	}
+	return strings.Join(dirs, ", ")
+}
+
+// render renders workflows in the format requested by opts, with a table of
+// contents if requested, surrounded by the header and footer of opts and
+// below the provenance banner if requested. The commands of exec columns are
+// killed once ctx is done.
+func render(ctx context.Context, workflows []WorkflowInfo, opts Options) (string, error) {
+	var sb strings.Builder
+	if err := RenderToContext(ctx, &sb, workflows, opts); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// RenderTo renders workflows, loaded by the caller, in the format of opts to
+// w, e.g. standard output or an HTTP response. Output that is not
+// post-processed with a table of contents, header, footer, or provenance
+// banner is streamed as it is rendered.
+func RenderTo(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	return RenderToContext(context.Background(), w, workflows, opts)
+}
+
+// RenderToContext is RenderTo, killing the commands of exec columns once ctx
+// is done, e.g. when the request of an HTTP response is canceled.
+func RenderToContext(ctx context.Context, w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	if err := opts.loadLanguage(); err != nil {
+		return err
+	}
+
+	markdown := opts.Template != "" || opts.Theme != "" || opts.Format == "" || opts.Format == FormatMarkdown
+	if (opts.Header != "" || opts.Footer != "") && !markdown {
+		return fmt.Errorf("a header or footer can only be added to markdown output")
+	}
+	if opts.TOC && !markdown {
+		return fmt.Errorf("a table of contents can only be added to markdown output")
+	}
+	if opts.Provenance && !markdown {
+		return fmt.Errorf("a provenance header can only be added to markdown output")
+	}
+
+	opts, err := resolveExecColumns(ctx, workflows, opts)
+	if err != nil {
+		return err
+	}
+
+	if !opts.TOC && opts.Header == "" && opts.Footer == "" && !opts.Provenance && !(opts.Reproducible && markdown) {
+		return renderBody(w, workflows, opts)
+	}
+
+	var sb strings.Builder
+	if err := renderBody(&sb, workflows, opts); err != nil {
+		return err
+	}
+	content := sb.String()
+	if opts.TOC {
+		content = addTOC(content)
+	}
+	content = addHeaderFooter(content, opts.Header, opts.Footer)
+	if opts.Provenance {
+		content = provenance(opts) + "\n" + content
+	}
+	if opts.Reproducible {
+		// Headers, footers, and templates edited on Windows may have CRLF
+		// line endings
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+	_, err = io.WriteString(w, content)
+	return err
+}
+
+// addHeaderFooter separates header and footer from content by a blank line.
+func addHeaderFooter(content, header, footer string) string {
+	if header != "" {
+		content = strings.TrimRight(header, "\n") + "\n\n" + content
+	}
+	if footer != "" {
+		content = strings.TrimRight(content, "\n") + "\n\n" + strings.TrimRight(footer, "\n") + "\n"
+	}
+	return content
+}
+
+// renderBody renders workflows in the format requested by opts to w.
+func renderBody(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	if opts.Template != "" && opts.Theme != "" {
+		return fmt.Errorf("a custom template and a theme cannot be used together")
+	}
+	if opts.Template != "" {
+		return renderTemplate(w, workflows, opts)
+	}
+	if opts.Theme != "" {
+		return renderTheme(w, workflows, opts)
+	}
+
+	return renderFormat(w, workflows, opts)
+}
+
+// ScanOptions configures how a directory is scanned for workflow files.
+type ScanOptions struct {
+	// RespectIgnore skips hidden files and files ignored by the .gitignore
+	// and .ghadocignore files of the directory and its parents up to the
+	// root of the git repository.
+	RespectIgnore bool
+
+	// Recursive also scans the subdirectories of the directory. The
+	// filenames of their workflows are relative to the directory, and YAML
+	// files in them without jobs are not documented.
+	Recursive bool
+
+	// Parse configures how the workflow files are parsed.
+	Parse ParseOptions
+
+	// Strict fails the scan with ParseErrors, listing every workflow file
+	// that fails to parse, instead of reporting and skipping them.
+	Strict bool
+
+	// Symlinks is the policy for symlinked workflow files and directories;
+	// see SymlinkPolicies. Defaults to SymlinksFollow.
+	Symlinks string
+
+	// Extensions are the extensions of the workflow files, matched in any
+	// case, e.g. ".yml" or "yml.tmpl". Defaults to DefaultExtensions.
+	Extensions []string
+
+	// Hooks run in order on every parsed workflow, to enrich, annotate, or
+	// leave it out; see Hook.
+	Hooks []Hook
+
+	// Workers is the number of workflow files parsed, or fetched and parsed
+	// by remote scans, at once. Defaults to the number of CPUs.
+	Workers int
+
+	// MaxFileSize is the size in bytes above which workflow files are
+	// skipped with a ParseError matching ErrLimit rather than parsed.
+	// Defaults to DefaultMaxFileSize; negative for no limit.
+	MaxFileSize int64
+
+	// MaxDepth is the number of levels of subdirectories scanned with
+	// Recursive. Deeper directories are skipped with a ParseError matching
+	// ErrLimit. Zero for no limit.
+	MaxDepth int
+
+	// Cache optionally keeps the parsed workflows by the hash of their
+	// content, so that unchanged workflow files are not parsed again.
+	Cache *Cache
+}
+
+// ScanDir parses every workflow file in workflowsDir. Files that fail to
+// parse are reported and skipped.
+func ScanDir(workflowsDir string) ([]WorkflowInfo, error) {
+	return ScanDirWithOptions(workflowsDir, ScanOptions{})
+}
+
+// ScanDirWithOptions parses the workflow files in workflowsDir as configured
+// by scanOpts. Files that fail to parse are reported and skipped unless
+// scanOpts.Strict is set.
+func ScanDirWithOptions(workflowsDir string, scanOpts ScanOptions) ([]WorkflowInfo, error) {
+	workflows, parseErrors, err := ScanDirWithErrors(workflowsDir, scanOpts)
+	for _, parseError := range parseErrors {
+		slog.Warn("Error parsing workflow file", "file", parseError.Filename, "error", parseError.Err)
+	}
+	return workflows, err
+}
+
+// ScanDirWithErrors is ScanDirWithOptions, returning the workflow files
+// skipped because they failed to parse rather than reporting them.
+func ScanDirWithErrors(workflowsDir string, scanOpts ScanOptions) ([]WorkflowInfo, []ParseError, error) {
+	return ScanDirContext(context.Background(), workflowsDir, scanOpts)
+}
+
+// ScanDirContext is ScanDirWithErrors, returning the error of ctx if it is
+// done before every file is parsed.
+func ScanDirContext(ctx context.Context, workflowsDir string, scanOpts ScanOptions) ([]WorkflowInfo, []ParseError, error) {
+	if err := scanOpts.Parse.validate(); err != nil {
+		return nil, nil, err
+	}
+	if err := validateSymlinks(scanOpts.Symlinks); err != nil {
+		return nil, nil, err
+	}
+
+	var ignore *ignorer
+	if scanOpts.RespectIgnore {
+		var err error
+		ignore, err = newIgnorer(workflowsDir)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	start := time.Now()
+	files, err := findFiles(ctx, workflowsDir, "", scanOpts, ignore)
+	if err != nil {
+		return nil, nil, err
+	}
+	workflows, parseErrors, err := parseFiles(ctx, workflowsDir, files, scanOpts)
+	slog.Debug("Scanned workflows directory", "dir", workflowsDir, "files", len(files), "workflows", len(workflows), "parse_errors", len(parseErrors), "duration", time.Since(start))
+	if err == nil && scanOpts.Strict && len(parseErrors) > 0 {
+		return nil, nil, ParseErrors(parseErrors)
+	}
+	return workflows, parseErrors, err
+}
+
+// workflowFile is a workflow file found by a scan.
+type workflowFile struct {
+	path     string      // Path of the file
+	filename string      // Path relative to the workflows directory
+	nested   bool        // In a subdirectory of the workflows directory
+	entry    fs.DirEntry // Directory entry of the file
+	err      error       // Reason the file or directory is skipped without parsing
+}
+
+// findFiles lists the workflow files in the subdirectory subdir of
+// workflowsDir, and with scanOpts.Recursive those of its subdirectories, in
+// the order they are documented.
+func findFiles(ctx context.Context, workflowsDir, subdir string, scanOpts ScanOptions, ignore *ignorer) ([]workflowFile, error) {
+	dir := filepath.Join(workflowsDir, subdir)
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading workflows directory: %v", err)
+	}
+
+	var files []workflowFile
+	for _, entry := range entries {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		filePath := filepath.Join(dir, entry.Name())
+		filename := path.Join(filepath.ToSlash(subdir), entry.Name())
+		if ignore != nil && ((strings.HasPrefix(entry.Name(), ".") && entry.Name() != ".github") || ignore.ignored(filePath)) {
+			continue
+		}
+
+		isDir := entry.IsDir()
+		if entry.Type()&fs.ModeSymlink != 0 {
+			if scanOpts.Symlinks == SymlinksSkip {
 				continue
 			}
-			workflow.Filename = file.Name()
-			workflows = append(workflows, workflow)
+			isDir = symlinkedDir(dir, filePath)
+		}
+
+		if isDir {
+			if !scanOpts.Recursive || entry.Name() == ".git" {
+				continue
+			}
+			if scanOpts.MaxDepth > 0 && strings.Count(filename, "/") >= scanOpts.MaxDepth {
+				files = append(files, workflowFile{filename: filename + "/", nested: true, err: classError{ErrLimit, fmt.Errorf("directory deeper than the maximum depth of %d", scanOpts.MaxDepth)}})
+				continue
+			}
+			if ignore != nil {
+				if err := ignore.load(filePath); err != nil {
+					return nil, err
+				}
+			}
+			nested, err := findFiles(ctx, workflowsDir, filename, scanOpts, ignore)
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, nested...)
+			continue
+		}
+
+		if scanOpts.IsWorkflowFile(entry.Name()) || scanOpts.IsDisabledFile(entry.Name()) {
+			files = append(files, workflowFile{path: filePath, filename: filename, nested: subdir != "", entry: entry})
 		}
 	}
+	return files, nil
+}
 
-	// Generate markdown table
-	markdownTable := generateMarkdownTable(workflows, workflowsDir, output)
+// parseFiles parses files on up to scanOpts.Workers goroutines at once, then
+// runs the hooks of scanOpts on the workflows in order. Workflows and parse
+// errors are returned in the order of files.
+func parseFiles(ctx context.Context, workflowsDir string, files []workflowFile, scanOpts ScanOptions) ([]WorkflowInfo, []ParseError, error) {
+	type parsed struct {
+		workflow WorkflowInfo
+		err      error
+		duration time.Duration
+	}
+	results := make([]parsed, len(files))
+	semaphore := make(chan struct{}, scanOpts.NumWorkers())
 
-	// Write to output file
-	err = os.WriteFile(output, []byte(markdownTable), 0644)
-	if err != nil {
-		return fmt.Errorf("error writing to output file: %v", err)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file workflowFile) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = parsed{err: ctx.Err()}
+				return
+			}
+			defer func() { <-semaphore }()
+
+			if file.err != nil {
+				results[i] = parsed{err: file.err}
+				return
+			}
+			start := time.Now()
+			workflow, err := parseWorkflowFile(file.path, scanOpts)
+			results[i] = parsed{workflow, err, time.Since(start)}
+		}(i, file)
+	}
+	wg.Wait()
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
 	}
 
-	fmt.Println("Successfully generated", output)
+	var workflows []WorkflowInfo
+	var parseErrors []ParseError
+	for i, file := range files {
+		workflow, err := results[i].workflow, results[i].err
+		// Nested YAML files other than workflows, such as configuration
+		// of the tools of a project, are not documented
+		if _, ok := workflow.document["jobs"]; file.nested && (errors.Is(err, ErrNotAWorkflow) || err == nil && !ok) {
+			continue
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, ParseError{Filename: file.filename, Err: err})
+			continue
+		}
+		slog.Debug("Parsed workflow file", "file", file.filename, "triggers", len(workflow.Triggers), "jobs", len(workflow.Jobs), "duration", results[i].duration)
+		workflow.Filename = file.filename
+		if scanOpts.IsDisabledFile(file.entry.Name()) {
+			workflow.Disabled = true
+		}
+		if scanOpts.Symlinks == SymlinksResolve {
+			workflow.target = resolvedPath(workflowsDir, file.path)
+		}
+		if info, err := file.entry.Info(); err == nil {
+			workflow.modified = info.ModTime()
+		}
+		keep, err := scanOpts.ApplyHooks(ctx, &workflow)
+		if err != nil {
+			return nil, nil, err
+		}
+		if keep {
+			workflows = append(workflows, workflow)
+		}
+	}
+	return workflows, parseErrors, nil
+}
+
+// DefaultMaxFileSize is the size in bytes above which workflow files are
+// skipped unless configured otherwise. Real workflows are far smaller; larger
+// YAML files are generated data that would stall the scan.
+const DefaultMaxFileSize = 1 << 20
+
+// checkSize returns an error matching ErrLimit if a workflow file of size
+// bytes exceeds the maximum size of scanOpts.
+func (scanOpts ScanOptions) checkSize(size int64) error {
+	limit := scanOpts.MaxFileSize
+	if limit == 0 {
+		limit = DefaultMaxFileSize
+	}
+	if limit > 0 && size > limit {
+		return classError{ErrLimit, fmt.Errorf("file size of %d bytes exceeds the maximum of %d", size, limit)}
+	}
 	return nil
 }
 
-// parseWorkflowFile extracts information from a GitHub workflow file
-func parseWorkflowFile(filePath string) (WorkflowInfo, error) {
-	workflow := WorkflowInfo{}
+// NumWorkers returns the number of workflow files parsed at once.
+func (scanOpts ScanOptions) NumWorkers() int {
+	if scanOpts.Workers > 0 {
+		return scanOpts.Workers
+	}
+	return runtime.NumCPU()
+}
+
+// DefaultExtensions are the extensions of workflow files unless configured
+// otherwise.
+var DefaultExtensions = []string{".yml", ".yaml"}
+
+// IsWorkflowFile reports whether name has a YAML extension and should be
+// treated as a workflow file. Configuration files overriding the settings of
+// a directory are not workflows.
+func IsWorkflowFile(name string) bool {
+	return ScanOptions{}.IsWorkflowFile(name)
+}
+
+// IsWorkflowFile reports whether name has one of the extensions of
+// scanOpts, in any case as on case-insensitive filesystems, and should be
+// treated as a workflow file.
+func (scanOpts ScanOptions) IsWorkflowFile(name string) bool {
+	if name == ".ghadoc.yaml" || name == ".ghadoc.yml" {
+		return false
+	}
+	extensions := scanOpts.Extensions
+	if len(extensions) == 0 {
+		extensions = DefaultExtensions
+	}
+	for _, ext := range extensions {
+		if ext = "." + strings.TrimPrefix(ext, "."); len(name) >= len(ext) && strings.EqualFold(name[len(name)-len(ext):], ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseWorkflowFile extracts information from a GitHub workflow file, read
+// once for both the doc comments and the YAML document.
+func parseWorkflowFile(filePath string, scanOpts ScanOptions) (WorkflowInfo, error) {
+	// Oversized files are skipped before they are read
+	if info, err := os.Stat(filePath); err == nil {
+		if err := scanOpts.checkSize(info.Size()); err != nil {
+			return WorkflowInfo{}, err
+		}
+	}
 
-	// Read file content for YAML parsing
 	content, err := os.ReadFile(filePath)
 	if err != nil {
-		return workflow, err
+		return WorkflowInfo{}, err
 	}
 
-	// Extract description from lines starting with "##", but only if the first line starts with ##
-	file, err := os.Open(filePath)
-	if err != nil {
-		return workflow, err
+	workflow, err := scanOpts.ParseWorkflow(content)
+	for _, warning := range workflow.warnings {
+		slog.Warn("Problem in workflow file", "file", filePath, "warning", warning)
+	}
+	return workflow, err
+}
+
+// ParseWorkflow extracts information from the content of a GitHub workflow
+// file. The Filename field is left for the caller to populate.
+func ParseWorkflow(content []byte) (WorkflowInfo, error) {
+	return ParseWorkflowWithOptions(content, ParseOptions{})
+}
+
+// ParseWorkflowWithOptions extracts information from the content of a GitHub
+// workflow file as configured by parseOpts.
+// Content that fails to parse is reported with an error matching ErrParse,
+// and YAML that is not a workflow with one matching ErrNotAWorkflow.
+func ParseWorkflowWithOptions(content []byte, parseOpts ParseOptions) (WorkflowInfo, error) {
+	if err := parseOpts.validate(); err != nil {
+		return WorkflowInfo{}, err
 	}
-	defer file.Close()
 
-	// Read the file line by line to find the description
-	scanner := bufio.NewScanner(file)
+	workflow, err := parseWorkflow(content, parseOpts)
+	if err != nil && !errors.Is(err, ErrNotAWorkflow) {
+		err = classError{ErrParse, err}
+	}
+	return workflow, err
+}
+
+// parseWorkflow extracts information from the content of a workflow file
+// with valid parseOpts.
+func parseWorkflow(content []byte, parseOpts ParseOptions) (WorkflowInfo, error) {
+	workflow := WorkflowInfo{}
+
+	// Extract description from lines starting with the comment prefix, "##"
+	// by default, but only if the first line, or the first after up to
+	// parseOpts.PreambleLines preamble lines, starts with it
+	prefix := parseOpts.commentPrefix()
+	scanner := bufio.NewScanner(bytes.NewReader(content))
 	var descriptionLines []string
+	var metadataLines []string
+	var tags []interface{}
+	inMetadata := false
+	inComments := false
+	preamble := 0
 
 	for scanner.Scan() {
 		line := scanner.Text()
 		trimmedLine := strings.TrimSpace(line)
 
-		if !strings.HasPrefix(trimmedLine, "##") {
+		if !strings.HasPrefix(trimmedLine, prefix) {
+			if !inComments && preamble < parseOpts.PreambleLines && isPreamble(trimmedLine) {
+				preamble++
+				continue
+			}
 			break
 		}
+		inComments = true
 
-		// Extract the description by removing the ## prefix
-		descriptionLine := strings.TrimSpace(strings.TrimPrefix(trimmedLine, "##"))
+		// Lines between "## --- metadata" and "## ---" form a YAML metadata
+		// block rather than part of the description
+		marker := strings.Join(strings.Fields(strings.TrimPrefix(trimmedLine, prefix)), " ")
+		if !inMetadata && marker == metadataOpener {
+			inMetadata = true
+			continue
+		}
+		if inMetadata {
+			if marker == metadataMarker {
+				inMetadata = false
+				continue
+			}
+			// Keep indentation so that nested YAML survives, dropping only
+			// the prefix and the single space that conventionally follows it
+			metadataLine := strings.TrimPrefix(strings.TrimPrefix(trimmedLine, prefix), " ")
+			metadataLines = append(metadataLines, metadataLine)
+			continue
+		}
+
+		// Extract the description by removing the prefix
+		descriptionLine := strings.TrimSpace(strings.TrimPrefix(trimmedLine, prefix))
+		if fields := strings.Fields(descriptionLine); len(fields) > 0 && fields[0] == tagsAnnotation {
+			for _, tag := range strings.Split(strings.TrimPrefix(descriptionLine, tagsAnnotation), ",") {
+				if tag = strings.TrimSpace(tag); tag != "" {
+					tags = append(tags, tag)
+				}
+			}
+			continue
+		}
 		descriptionLines = append(descriptionLines, descriptionLine)
 	}
 
-	// Join description lines with line breaks for markdown
-	if len(descriptionLines) > 0 {
-		workflow.Description = strings.Join(descriptionLines, "<br>")
-	} else {
-		workflow.Description = ""
+	// A malformed metadata block is ignored with a warning rather than
+	// failing the whole workflow
+	if inMetadata {
+		workflow.warnings = append(workflow.warnings, fmt.Sprintf("ignoring unterminated metadata block: missing closing \"%s %s\"", prefix, metadataMarker))
+	} else if len(metadataLines) > 0 {
+		err := yaml.Unmarshal([]byte(strings.Join(metadataLines, "\n")), &workflow.Metadata)
+		if err != nil {
+			workflow.Metadata = nil
+			workflow.warnings = append(workflow.warnings, fmt.Sprintf("ignoring invalid metadata block: %v", err))
+		}
+	}
+	if len(tags) > 0 {
+		// Annotated tags add to those of the metadata block
+		var all []interface{}
+		for _, tag := range metadataValues(workflow.Metadata, "tags", "tag") {
+			all = append(all, tag)
+		}
+		tags = append(all, tags...)
+		if workflow.Metadata == nil {
+			workflow.Metadata = make(map[string]interface{})
+		}
+		delete(workflow.Metadata, "tag")
+		workflow.Metadata["tags"] = tags
 	}
 
-	// Parse YAML to extract all triggers from the "on" field
-	var yamlData map[string]interface{}
-	err = yaml.Unmarshal(content, &yamlData)
-	if err != nil {
+	var root yaml.Node
+	if err := yaml.Unmarshal(content, &root); err != nil {
+		return workflow, err
+	}
+	if err := checkWorkflow(&root); err != nil {
 		return workflow, err
 	}
 
+	// Decode the parsed document rather than parsing the content again, to
+	// extract all triggers from the "on" field
+	var yamlData map[string]interface{}
+	if parseOpts.Partial {
+		decoded, err := decodeKeys(&root, partialKeys)
+		if err != nil {
+			return workflow, err
+		}
+		yamlData = decoded
+	} else if len(root.Content) > 0 {
+		if err := root.Decode(&yamlData); err != nil {
+			return workflow, err
+		}
+	}
+
+	if key, value := onKey(&root); key != nil {
+		workflow.OnLine = key.Line
+		// Store the triggers under "on" whatever the key was written as,
+		// so that custom columns find them too
+		var onField interface{}
+		if value.Decode(&onField) == nil {
+			delete(yamlData, key.Value)
+			yamlData["on"] = onField
+		}
+	}
+
 	// Check if "on" field exists
 	if onField, ok := yamlData["on"]; ok {
 		// Extract triggers based on the type of the "on" field
 		switch v := onField.(type) {
 		case map[string]interface{}:
 			// If "on" is a map, each key is a trigger type
-			for key := range v {
+			for key, config := range v {
 				workflow.Triggers = append(workflow.Triggers, key)
+
+				if filter, ok := parseTriggerFilter(config); ok {
+					if workflow.Filters == nil {
+						workflow.Filters = make(map[string]TriggerFilter)
+					}
+					workflow.Filters[key] = filter
+				}
+
+				if key == "schedule" {
+					workflow.Schedules = parseSchedules(config)
+				}
 			}
 		case []interface{}:
 			// If "on" is an array, each item is a trigger type
@@ -131,45 +1099,387 @@ func parseWorkflowFile(filePath string) (WorkflowInfo, error) {
 	// Sort triggers alphabetically to ensure consistent ordering.
 	sort.Strings(workflow.Triggers)
 
+	if name, ok := yamlData["name"].(string); ok {
+		workflow.Name = name
+	}
+	workflow.Permissions = parsePermissions(yamlData["permissions"])
+	workflow.Environments = parseEnvironments(yamlData["jobs"])
+	workflow.Jobs = parseJobs(yamlData["jobs"])
+	workflow.Secrets = parseSecrets(content)
+	workflow.Disabled = disabledPattern.Match(content)
+	workflow.document = yamlData
+
+	// Description lines are joined with line breaks for markdown
+	parseOpts.describe(&workflow, content, &root, strings.Join(descriptionLines, "<br>"))
+
 	return workflow, nil
 }
 
+// partialKeys are the top-level keys decoded by partial parses besides the
+// "on" key, which is decoded on its own.
+var partialKeys = []string{"name", "permissions", "jobs", DescriptionKeyName}
+
+// decodeKeys decodes the values of keys in the mapping of the YAML document
+// node, leaving the rest of the document undecoded.
+func decodeKeys(document *yaml.Node, keys []string) (map[string]interface{}, error) {
+	if len(document.Content) == 0 {
+		return nil, nil
+	}
+	mapping := document.Content[0]
+	decoded := make(map[string]interface{})
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		key := mapping.Content[i].Value
+		if !hasAny(keys, []string{key}) {
+			continue
+		}
+		var value interface{}
+		if err := mapping.Content[i+1].Decode(&value); err != nil {
+			return nil, err
+		}
+		decoded[key] = value
+	}
+	return decoded, nil
+}
+
+// checkWorkflow returns an error matching ErrNotAWorkflow if the parsed
+// document is not a mapping with triggers or jobs. Empty documents are
+// documented as they are.
+func checkWorkflow(document *yaml.Node) error {
+	if len(document.Content) == 0 {
+		return nil
+	}
+	switch document.Content[0].Kind {
+	case yaml.MappingNode:
+	case yaml.SequenceNode:
+		return fmt.Errorf("%w: expected a mapping, got a list", ErrNotAWorkflow)
+	default:
+		return fmt.Errorf("%w: expected a mapping, got a scalar", ErrNotAWorkflow)
+	}
+	if key, _ := onKey(document); key != nil {
+		return nil
+	}
+	if key, _ := topLevel(document, "jobs"); key != nil {
+		return nil
+	}
+	return fmt.Errorf("%w: no on or jobs key", ErrNotAWorkflow)
+}
+
+// isPreamble reports whether the trimmed line may precede the doc comments:
+// a blank line, a comment such as a license header, or a document marker.
+func isPreamble(trimmedLine string) bool {
+	return trimmedLine == "" || trimmedLine == "---" || strings.HasPrefix(trimmedLine, "#")
+}
+
+// onKey returns the key and value nodes of the "on" block of the YAML
+// document node, or nil if it has none. YAML 1.1 tools resolve an unquoted
+// on to the boolean true, so a top-level true key written back by them also
+// counts as the "on" key.
+func onKey(document *yaml.Node) (*yaml.Node, *yaml.Node) {
+	if key, value := topLevel(document, "on"); key != nil {
+		return key, value
+	}
+	if key, value := topLevel(document, "true"); key != nil && key.ShortTag() == "!!bool" {
+		return key, value
+	}
+	return nil, nil
+}
+
+// topLevel returns the key and value nodes of the top-level key of the YAML
+// document node, or nil if it has none.
+func topLevel(document *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if document.Kind != yaml.DocumentNode || len(document.Content) == 0 {
+		return nil, nil
+	}
+	mapping := document.Content[0]
+	if mapping.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i], mapping.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// parseTriggerFilter extracts the filters from the configuration of a
+// trigger, reporting whether any were present.
+func parseTriggerFilter(config interface{}) (TriggerFilter, bool) {
+	configMap, ok := config.(map[string]interface{})
+	if !ok {
+		return TriggerFilter{}, false
+	}
+
+	filter := TriggerFilter{
+		Branches:       stringList(configMap["branches"]),
+		BranchesIgnore: stringList(configMap["branches-ignore"]),
+		Tags:           stringList(configMap["tags"]),
+		TagsIgnore:     stringList(configMap["tags-ignore"]),
+		Paths:          stringList(configMap["paths"]),
+		PathsIgnore:    stringList(configMap["paths-ignore"]),
+		Types:          stringList(configMap["types"]),
+	}
+	return filter, !reflect.DeepEqual(filter, TriggerFilter{})
+}
+
+// parseSchedules extracts the cron expressions of a schedule trigger.
+func parseSchedules(config interface{}) []string {
+	entries, ok := config.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var schedules []string
+	for _, entry := range entries {
+		if entryMap, ok := entry.(map[string]interface{}); ok {
+			if cron, ok := entryMap["cron"].(string); ok {
+				schedules = append(schedules, cron)
+			}
+		}
+	}
+	return schedules
+}
+
+// parseEnvironments extracts the sorted, unique deployment environments of
+// the jobs, which are either a name or a map with a name.
+func parseEnvironments(jobs interface{}) []string {
+	jobsMap, ok := jobs.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var environments []string
+	for _, job := range jobsMap {
+		jobMap, ok := job.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch environment := jobMap["environment"].(type) {
+		case string:
+			environments = append(environments, environment)
+		case map[string]interface{}:
+			if name, ok := environment["name"].(string); ok {
+				environments = append(environments, name)
+			}
+		}
+	}
+	return uniqueSorted(environments)
+}
+
+// parseSecrets returns the sorted, unique names of the secrets referenced in
+// content.
+func parseSecrets(content []byte) []string {
+	var secrets []string
+	for _, match := range secretPattern.FindAllSubmatch(content, -1) {
+		if len(match[1]) > 0 {
+			secrets = append(secrets, string(match[1]))
+		} else {
+			secrets = append(secrets, string(match[2]))
+		}
+	}
+	return uniqueSorted(secrets)
+}
+
+// uniqueSorted sorts items and removes duplicates.
+func uniqueSorted(items []string) []string {
+	sort.Strings(items)
+
+	var unique []string
+	for i, item := range items {
+		if i == 0 || item != items[i-1] {
+			unique = append(unique, item)
+		}
+	}
+	return unique
+}
+
+// stringList converts a YAML sequence or scalar into a list of strings.
+func stringList(value interface{}) []string {
+	switch v := value.(type) {
+	case []interface{}:
+		var list []string
+		for _, item := range v {
+			if item != nil {
+				list = append(list, fmt.Sprint(item))
+			}
+		}
+		return list
+	case nil:
+		return nil
+	default:
+		return []string{fmt.Sprint(v)}
+	}
+}
+
 // generateMarkdownTable creates a markdown table from workflow information
-func generateMarkdownTable(workflows []WorkflowInfo, workflowsDir string, outputPath string) string {
+func generateMarkdownTable(workflows []WorkflowInfo, opts Options) (string, error) {
 	var sb strings.Builder
 
+	sb.WriteString("# " + opts.t("GitHub Workflows Summary") + "\n\n")
+	// Workflows disabled by convention get a table of their own
+	enabled, disabled := splitDisabled(workflows)
+	if opts.GroupBy == "" {
+		if err := writeTable(&sb, enabled, opts); err != nil {
+			return "", err
+		}
+	} else {
+		groups, err := groupWorkflows(enabled, opts)
+		if err != nil {
+			return "", err
+		}
+		for i, group := range groups {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			sb.WriteString("## " + group.Name + "\n\n")
+			if err := writeTable(&sb, group.Workflows, opts); err != nil {
+				return "", err
+			}
+		}
+	}
+	if err := writeDisabled(&sb, disabled, opts); err != nil {
+		return "", err
+	}
+
+	if opts.TriggerHints {
+		writeTriggerHints(&sb, workflows, opts)
+	}
+	if opts.Security {
+		writeSecurityNotes(&sb, workflows, opts)
+	}
+	if !opts.HideParseErrors {
+		writeParseErrors(&sb, opts.ParseErrors, opts)
+	}
+
+	return sb.String(), nil
+}
+
+// writeTable writes the summary table of workflows.
+func writeTable(sb *strings.Builder, workflows []WorkflowInfo, opts Options) error {
 	// Write table header
-	sb.WriteString("# GitHub Workflows Summary\n\n")
-	sb.WriteString("| Filename | Description | Triggers |\n")
-	sb.WriteString("| --- | --- | --- |\n")
+	headers := []string{opts.t("Filename"), opts.t("Description"), opts.t("Triggers")}
+	for _, column := range opts.Columns {
+		headers = append(headers, column.Name)
+	}
+	if opts.Badges {
+		headers = append(headers, opts.t("Badge"))
+	}
+	if opts.Status != nil {
+		headers = append(headers, opts.t("Status"), opts.t("Last Run"))
+	}
+	if opts.Metrics != nil {
+		headers = append(headers, opts.t("Success Rate"), opts.t("Median Duration"))
+	}
+	if opts.State != nil {
+		headers = append(headers, opts.t("State"))
+	}
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	writeTableRow(sb, headers)
+	writeTableRow(sb, separators)
 
 	// Write table rows
 	for _, workflow := range workflows {
-		// Create relative link to the file
 		// Create link to workflow file with relative path from the markdown file
-		workflowFullPath := filepath.Join(workflowsDir, workflow.Filename)
-		outputDir := filepath.Dir(outputPath)
+		fileLink := fmt.Sprintf("[%s](%s)", opts.cell("Filename", opts.displayName(workflow)), opts.link(workflow, opts.Output))
 
-		// Calculate relative path from output directory to workflow file
-		relativePath, err := filepath.Rel(outputDir, workflowFullPath)
-		if err != nil {
-			// Fallback to just the filename if there's an error
-			relativePath = workflow.Filename
+		triggers := opts.cell("Triggers", workflow.Triggers...)
+		if opts.TriggerLinks && workflow.OnLine > 0 && triggers != "" {
+			triggers = fmt.Sprintf("[%s](%s#L%d)", triggers, opts.link(workflow, opts.Output), workflow.OnLine)
 		}
 
-		// Use forward slashes for URLs even on Windows
-		relativePath = filepath.ToSlash(relativePath)
-		fileLink := fmt.Sprintf("[%s](%s)", workflow.Filename, relativePath)
+		cells := []string{fileLink, opts.cell("Description", workflow.Description), triggers}
+		for _, column := range opts.Columns {
+			cells = append(cells, opts.cell(column.Name, opts.columnValues(workflow, column)...))
+		}
 
-		// Format triggers as a comma-separated list
-		triggers := strings.Join(workflow.Triggers, ", ")
+		if opts.Badges {
+			badge, err := BadgeMarkdown(opts.RepoURL, workflow.Filename, opts.BadgeStyle, opts.Branch)
+			if err != nil {
+				return err
+			}
+			cells = append(cells, badge)
+		}
+		if opts.Status != nil {
+			status := opts.Status[workflow.Filename]
+			cells = append(cells, opts.cell("Status", opts.t(status.statusCell())), opts.cell("Last Run", status.lastRunCell()))
+		}
+		if opts.Metrics != nil {
+			metrics := opts.Metrics[workflow.Filename]
+			cells = append(cells, opts.cell("Success Rate", metrics.successRateCell(opts.t)), opts.cell("Median Duration", metrics.medianDurationCell()))
+		}
+		if opts.State != nil {
+			cells = append(cells, opts.cell("State", opts.t(stateCell(opts.State[workflow.Filename]))))
+		}
 
 		// Write row
-		sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
-			fileLink,
-			workflow.Description,
-			triggers))
+		writeTableRow(sb, cells)
+	}
+
+	return nil
+}
+
+// writeTableRow writes a markdown table row with the given cells.
+func writeTableRow(sb *strings.Builder, cells []string) {
+	sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+}
+
+// link returns the link to the workflow file from the file at fromPath: a
+// blob URL if opts.RepoURL and opts.Ref are set, or a relative path.
+func (opts Options) link(workflow WorkflowInfo, fromPath string) string {
+	if opts.RepoURL != "" && opts.Ref != "" {
+		return fmt.Sprintf("%s/blob/%s/%s", strings.TrimSuffix(opts.RepoURL, "/"), opts.Ref, opts.repoPath(workflow))
+	}
+	return workflowLink(workflow, opts.workflowsDir(workflow), fromPath)
+}
+
+// repoPath returns the path of the workflow file relative to the root of
+// its repository. Local workflows directories may be given relative to any
+// directory of the repository, so their path is resolved with git, falling
+// back to the given path outside of a git repository.
+func (opts Options) repoPath(workflow WorkflowInfo) string {
+	workflowPath := path.Join(filepath.ToSlash(opts.workflowsDir(workflow)), workflow.Filename)
+	if opts.Scan != nil {
+		return workflowPath
+	}
+
+	filePath := filepath.Join(opts.workflowsDir(workflow), filepath.FromSlash(workflow.Filename))
+	if workflow.target != "" {
+		filePath = workflow.target
+		workflowPath = filepath.ToSlash(workflow.target)
+	}
+	// Rendering is not cancelled, and rev-parse is local and quick
+	repoDir, err := git.TopLevel(context.Background(), filepath.Dir(filePath))
+	if err != nil {
+		return workflowPath
+	}
+	relativePath, err := git.RelativePath(repoDir, filePath)
+	if err != nil {
+		return workflowPath
+	}
+	return relativePath
+}
+
+// workflowLink returns the path of the workflow file relative to the
+// directory of the output file, suitable for use in a link.
+func workflowLink(workflow WorkflowInfo, workflowsDir string, outputPath string) string {
+	workflowFullPath := filepath.Join(workflowsDir, workflow.Filename)
+	if workflow.target != "" {
+		// Symlinks are resolved to the file they point to
+		workflowFullPath = workflow.target
+	}
+	outputDir := filepath.Dir(outputPath)
+
+	// Calculate relative path from output directory to workflow file
+	relativePath, err := filepath.Rel(outputDir, workflowFullPath)
+	if err != nil {
+		// Fallback to just the filename if there's an error
+		relativePath = workflow.Filename
 	}
 
-	return sb.String()
+	// Use forward slashes for URLs even on Windows
+	return filepath.ToSlash(relativePath)
 }
@@ -1,9 +1,14 @@
 package generate
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -146,7 +151,7 @@ on: [push, pull_request]
 			filePath := createTempWorkflowFile(t, tempDir, "workflow.yml", tc.content)
 
 			// Parse the workflow file
-			workflow, err := parseWorkflowFile(filePath)
+			workflow, err := parseWorkflowFile(filePath, ScanOptions{})
 			if err != nil {
 				t.Fatalf("parseWorkflowFile failed: %v", err)
 			}
@@ -192,7 +197,7 @@ on: [push, pull_request]
 // TestParseWorkflowFileErrors tests error handling in parseWorkflowFile
 func TestParseWorkflowFileErrors(t *testing.T) {
 	// Test non-existent file
-	_, err := parseWorkflowFile("/non/existent/file.yml")
+	_, err := parseWorkflowFile("/non/existent/file.yml", ScanOptions{})
 	if err == nil {
 		t.Error("Expected error for non-existent file, got nil")
 	}
@@ -209,7 +214,7 @@ on: {
     invalid yaml content
 `)
 
-	_, err = parseWorkflowFile(invalidYamlPath)
+	_, err = parseWorkflowFile(invalidYamlPath, ScanOptions{})
 	if err == nil {
 		t.Error("Expected error for invalid YAML, got nil")
 	}
@@ -221,7 +226,7 @@ on: {
 		t.Fatalf("Failed to create unreadable file: %v", err)
 	}
 
-	_, err = parseWorkflowFile(unreadablePath)
+	_, err = parseWorkflowFile(unreadablePath, ScanOptions{})
 	if err == nil {
 		t.Error("Expected error for unreadable file, got nil")
 	}
@@ -256,7 +261,10 @@ func TestGenerateMarkdownTable(t *testing.T) {
 	// Generate markdown table
 	workflowsPath := "test/workflows"
 	outputPath := "test/output.md"
-	markdownTable := generateMarkdownTable(workflows, workflowsPath, outputPath)
+	markdownTable, err := generateMarkdownTable(workflows, Options{WorkflowsDir: workflowsPath, Output: outputPath})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
 
 	// Verify the table contains expected content
 	expectedLines := []string{
@@ -318,7 +326,7 @@ on:
 	outputFile := filepath.Join(tempDir, "output.md")
 
 	// Call Generate function
-	err = Generate(workflowsDir, outputFile)
+	_, err = Generate(workflowsDir, outputFile)
 	if err != nil {
 		t.Fatalf("Generate failed: %v", err)
 	}
@@ -356,10 +364,169 @@ on:
 	}
 }
 
+// TestGenerateStdout tests writing the documentation to stdout with an
+// output of "-"
+func TestGenerateStdout(t *testing.T) {
+	tempDir := createTempDir(t, "stdout")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "## Builds\non: push\n")
+
+	reader, writer, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("Failed to create pipe: %v", err)
+	}
+	stdout := os.Stdout
+	os.Stdout = writer
+	_, err = GenerateWithOptions(Options{WorkflowsDir: tempDir, Output: StdoutOutput})
+	os.Stdout = stdout
+	writer.Close()
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("Failed to read stdout: %v", err)
+	}
+	if !strings.Contains(string(content), "| Builds | push |") {
+		t.Errorf("Expected the table on stdout, got %q", content)
+	}
+	if _, err := os.Stat(StdoutOutput); !os.IsNotExist(err) {
+		t.Errorf("Expected no file named %s, got %v", StdoutOutput, err)
+	}
+}
+
+// TestDryRun tests reporting the files generation would write without
+// writing them
+func TestDryRun(t *testing.T) {
+	tempDir := createTempDir(t, "dry-run")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "## Builds\non: push\n")
+	readme := createTempWorkflowFile(t, tempDir, "README.md", "# Project\n"+InjectStart+"\n"+InjectEnd+"\n")
+
+	written := make(map[string]string)
+	dryRun := func(path string, content []byte) {
+		written[path] = string(content)
+	}
+	output := filepath.Join(tempDir, "workflows.md")
+	pagesDir := filepath.Join(tempDir, "pages")
+	_, err := GenerateWithOptions(Options{WorkflowsDir: tempDir, Output: output, PagesDir: pagesDir, DryRun: dryRun})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	_, err = GenerateWithOptions(Options{WorkflowsDir: tempDir, Inject: readme, DryRun: dryRun})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	for _, path := range []string{output, filepath.Join(pagesDir, "ci.md"), readme} {
+		if !strings.Contains(written[path], "Builds") {
+			t.Errorf("Expected the dry run to report %s with the description, got %q", path, written[path])
+		}
+	}
+	for _, path := range []string{output, pagesDir} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("Expected %s not to be written, got %v", path, err)
+		}
+	}
+	if content, _ := os.ReadFile(readme); strings.Contains(string(content), "Builds") {
+		t.Errorf("Expected %s not to be injected into, got %q", readme, content)
+	}
+}
+
+// TestGenerateWithScan tests generating documentation for workflows loaded
+// by a custom scan function, linked on GitHub
+func TestGenerateWithScan(t *testing.T) {
+	tempDir := createTempDir(t, "gha-docs-test")
+	outputFile := filepath.Join(tempDir, "output.md")
+
+	var scannedDir string
+	_, err := GenerateWithOptions(Options{
+		WorkflowsDir: ".github/workflows",
+		Output:       outputFile,
+		RepoURL:      "https://github.com/owner/repo",
+		Ref:          "main",
+		Scan: func(workflowsDir string) ([]WorkflowInfo, []ParseError, error) {
+			scannedDir = workflowsDir
+			return []WorkflowInfo{{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}}}, nil, nil
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	if scannedDir != ".github/workflows" {
+		t.Errorf("Expected scan of %q, got %q", ".github/workflows", scannedDir)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	expected := "| [ci.yml](https://github.com/owner/repo/blob/main/.github/workflows/ci.yml) | Runs CI. | push |"
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+	}
+}
+
+// TestLocalBlobLinks tests links to local workflow files on GitHub
+func TestLocalBlobLinks(t *testing.T) {
+	repo := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", repo).CombinedOutput(); err != nil {
+		t.Fatalf("git init failed: %v\n%s", err, out)
+	}
+	workflowsDir := filepath.Join(repo, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows dir: %v", err)
+	}
+	createTempWorkflowFile(t, workflowsDir, "ci.yml", "## Runs CI.\non: push\n")
+
+	outputFile := filepath.Join(t.TempDir(), "workflows.md")
+	_, err := GenerateWithOptions(Options{
+		WorkflowsDir: workflowsDir,
+		Output:       outputFile,
+		RepoURL:      "https://github.com/owner/repo",
+		Ref:          "main",
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	content, err := os.ReadFile(outputFile)
+	if err != nil {
+		t.Fatalf("Failed to read output file: %v", err)
+	}
+	// The absolute workflows directory is linked relative to the repository root
+	expected := "| [ci.yml](https://github.com/owner/repo/blob/main/.github/workflows/ci.yml) | Runs CI. | push |"
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+	}
+}
+
+// TestTriggerLinks tests linking the Triggers cells to the on block
+func TestTriggerLinks(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte("## Runs CI.\nname: CI\n\non:\n  push:\njobs: {}\n"))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if workflow.OnLine != 4 {
+		t.Errorf("Expected the on block on line 4, got %d", workflow.OnLine)
+	}
+	workflow.Filename = "ci.yml"
+
+	var sb strings.Builder
+	opts := Options{WorkflowsDir: ".github/workflows", Output: "workflows.md", TriggerLinks: true}
+	if err := writeTable(&sb, []WorkflowInfo{workflow, {Filename: "empty.yml"}}, opts); err != nil {
+		t.Fatalf("writeTable failed: %v", err)
+	}
+	expected := "| [ci.yml](.github/workflows/ci.yml) | Runs CI. | [push](.github/workflows/ci.yml#L4) |\n| [empty.yml](.github/workflows/empty.yml) |  |  |\n"
+	if !strings.HasSuffix(sb.String(), expected) {
+		t.Errorf("Expected linked triggers, got:\n%s", sb.String())
+	}
+}
+
 // TestGenerateErrors tests error handling in Generate function
 func TestGenerateErrors(t *testing.T) {
 	// Test with non-existent directory
-	err := Generate("/non/existent/dir", "output.md")
+	_, err := Generate("/non/existent/dir", "output.md")
 	if err == nil {
 		t.Error("Expected error for non-existent directory, got nil")
 	}
@@ -384,7 +551,7 @@ on:
 `)
 
 	// Test with invalid output path (directory that doesn't exist)
-	err = Generate(workflowsDir, "/non/existent/dir/output.md")
+	_, err = Generate(workflowsDir, "/non/existent/dir/output.md")
 	if err == nil {
 		t.Error("Expected error for invalid output path, got nil")
 	}
@@ -398,7 +565,7 @@ on:
 		t.Fatalf("Failed to create unwritable dir: %v", err)
 	}
 
-	err = Generate(workflowsDir, unwritablePath)
+	_, err = Generate(workflowsDir, unwritablePath)
 	if err == nil {
 		t.Error("Expected error for unwritable output path, got nil")
 	}
@@ -411,7 +578,7 @@ func TestEmptyWorkflowsDirectory(t *testing.T) {
 	outputFile := filepath.Join(tempDir, "output.md")
 
 	// Call Generate with empty directory
-	err := Generate(tempDir, outputFile)
+	_, err := Generate(tempDir, outputFile)
 	if err != nil {
 		t.Fatalf("Generate failed with empty directory: %v", err)
 	}
@@ -467,7 +634,7 @@ on:
 	outputFile := filepath.Join(tempDir, "output.md")
 
 	// Call Generate
-	err := Generate(tempDir, outputFile)
+	_, err := Generate(tempDir, outputFile)
 	if err != nil {
 		t.Fatalf("Generate failed: %v", err)
 	}
@@ -514,7 +681,7 @@ on:
 
 	// Parse the workflow file
 	filePath := filepath.Join(tempDir, "special.yml")
-	workflow, err := parseWorkflowFile(filePath)
+	workflow, err := parseWorkflowFile(filePath, ScanOptions{})
 	if err != nil {
 		t.Fatalf("parseWorkflowFile failed: %v", err)
 	}
@@ -543,7 +710,7 @@ on:
 
 	// Parse the workflow file
 	filePath := filepath.Join(tempDir, "multiline.yml")
-	workflow, err := parseWorkflowFile(filePath)
+	workflow, err := parseWorkflowFile(filePath, ScanOptions{})
 	if err != nil {
 		t.Fatalf("parseWorkflowFile failed: %v", err)
 	}
@@ -586,7 +753,7 @@ on:
 
 	// Parse the workflow file
 	filePath := filepath.Join(tempDir, "complex.yml")
-	workflow, err := parseWorkflowFile(filePath)
+	workflow, err := parseWorkflowFile(filePath, ScanOptions{})
 	if err != nil {
 		t.Fatalf("parseWorkflowFile failed: %v", err)
 	}
@@ -621,6 +788,44 @@ on:
 	}
 }
 
+// TestOnParsedAsTrue tests detecting the triggers of workflows whose "on"
+// key was written as the boolean true by a YAML 1.1 tool
+func TestOnParsedAsTrue(t *testing.T) {
+	for _, test := range []struct {
+		content  string
+		expected []string
+		line     int
+	}{
+		{"name: CI\ntrue:\n  push:\n  pull_request:\n", []string{"pull_request", "push"}, 2},
+		{"name: CI\n'on': [push]\n", []string{"push"}, 2},
+		// A quoted "true" key is a string and not the "on" key
+		{"name: CI\n'true': push\njobs: {}\n", nil, 0},
+	} {
+		workflow, err := ParseWorkflow([]byte(test.content))
+		if err != nil {
+			t.Fatalf("ParseWorkflow failed: %v", err)
+		}
+		if !reflect.DeepEqual(workflow.Triggers, test.expected) {
+			t.Errorf("Expected triggers %v for %q, got %v", test.expected, test.content, workflow.Triggers)
+		}
+		if workflow.OnLine != test.line {
+			t.Errorf("Expected the on key on line %d for %q, got %d", test.line, test.content, workflow.OnLine)
+		}
+	}
+
+	// Custom columns read the triggers under "on" too
+	workflow, err := ParseWorkflow([]byte("true:\n  schedule:\n    - cron: '0 0 * * *'\n"))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if len(workflow.Schedules) != 1 {
+		t.Errorf("Expected 1 schedule, got %v", workflow.Schedules)
+	}
+	if values := Extract(workflow.document, "on"); !reflect.DeepEqual(values, []string{"schedule"}) {
+		t.Errorf("Expected the on column to hold [schedule], got %v", values)
+	}
+}
+
 // TestYamlExtensionVariants tests handling of different YAML file extensions
 func TestYamlExtensionVariants(t *testing.T) {
 	// Create temp directory
@@ -652,7 +857,7 @@ on:
 	outputFile := filepath.Join(tempDir, "output.md")
 
 	// Call Generate
-	err = Generate(workflowsDir, outputFile)
+	_, err = Generate(workflowsDir, outputFile)
 	if err != nil {
 		t.Fatalf("Generate failed: %v", err)
 	}
@@ -673,3 +878,520 @@ on:
 		t.Error("Output should contain workflow2.yaml")
 	}
 }
+
+// TestMetadataBlock tests extraction of the metadata block from leading comments
+func TestMetadataBlock(t *testing.T) {
+	tempDir := createTempDir(t, "metadata-block")
+
+	content := `## Deploys the application.
+## --- metadata
+## owner: platform-team
+## tags: [deploy, production]
+## links:
+##   runbook: https://example.com/runbook
+## ---
+## Requires approval.
+name: Deploy
+on: workflow_dispatch`
+
+	filePath := createTempWorkflowFile(t, tempDir, "deploy.yml", content)
+
+	workflow, err := parseWorkflowFile(filePath, ScanOptions{})
+	if err != nil {
+		t.Fatalf("parseWorkflowFile failed: %v", err)
+	}
+
+	expectedDesc := "Deploys the application.<br>Requires approval."
+	if workflow.Description != expectedDesc {
+		t.Errorf("Expected description %q, got %q", expectedDesc, workflow.Description)
+	}
+
+	if workflow.Metadata["owner"] != "platform-team" {
+		t.Errorf("Expected owner %q, got %v", "platform-team", workflow.Metadata["owner"])
+	}
+
+	tags, ok := workflow.Metadata["tags"].([]interface{})
+	if !ok || len(tags) != 2 || tags[0] != "deploy" || tags[1] != "production" {
+		t.Errorf("Expected tags [deploy production], got %v", workflow.Metadata["tags"])
+	}
+
+	links, ok := workflow.Metadata["links"].(map[string]interface{})
+	if !ok || links["runbook"] != "https://example.com/runbook" {
+		t.Errorf("Expected nested runbook link, got %v", workflow.Metadata["links"])
+	}
+}
+
+// TestTagsAnnotation tests tags given by "## @tags" comments
+func TestTagsAnnotation(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`## Scans the dependencies.
+## --- metadata
+## tags: deploy
+## ---
+## @tags security, nightly
+on: schedule
+jobs: {}
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	if workflow.Description != "Scans the dependencies." {
+		t.Errorf("Expected the annotation to be left out of the description, got %q", workflow.Description)
+	}
+	expected := []interface{}{"deploy", "security", "nightly"}
+	if !reflect.DeepEqual(workflow.Metadata["tags"], expected) {
+		t.Errorf("Expected tags %v, got %v", expected, workflow.Metadata["tags"])
+	}
+}
+
+// TestMetadataBlockErrors tests that malformed metadata blocks are ignored
+// with a warning rather than failing the workflow
+func TestMetadataBlockErrors(t *testing.T) {
+	testCases := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "Unterminated block",
+			content: `## --- metadata
+## owner: platform-team
+on: push`,
+		},
+		{
+			name: "Invalid YAML",
+			content: `## --- metadata
+## owner: [platform-team
+## ---
+on: push`,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			workflow, err := ParseWorkflow([]byte(tc.content))
+			if err != nil {
+				t.Fatalf("Expected malformed metadata block to be ignored, got %v", err)
+			}
+			if workflow.Metadata != nil {
+				t.Errorf("Expected no metadata, got %v", workflow.Metadata)
+			}
+			if len(workflow.warnings) != 1 {
+				t.Errorf("Expected 1 warning, got %v", workflow.warnings)
+			}
+		})
+	}
+}
+
+// TestDecoratedCommentHeader tests that "## ---" rules decorating the leading
+// comments are part of the description rather than a metadata block
+func TestDecoratedCommentHeader(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`## ---
+## Builds the application.
+## ---
+## Runs on every push.
+name: Build
+on: push
+jobs: {}
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	expected := "---<br>Builds the application.<br>---<br>Runs on every push."
+	if workflow.Description != expected {
+		t.Errorf("Expected description %q, got %q", expected, workflow.Description)
+	}
+	if workflow.Metadata != nil || len(workflow.warnings) != 0 {
+		t.Errorf("Expected no metadata or warnings, got %v and %v", workflow.Metadata, workflow.warnings)
+	}
+}
+
+// TestParseWorkflowDetails tests extraction of name, schedules, secrets, and environments
+func TestParseWorkflowDetails(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`## Deploys nightly.
+name: Nightly Deploy
+on:
+  schedule:
+    - cron: '0 2 * * *'
+    - cron: '0 14 * * 1'
+  workflow_dispatch:
+jobs:
+  staging:
+    environment: staging
+    runs-on: ubuntu-latest
+    steps:
+      - run: deploy --token ${{ secrets.DEPLOY_TOKEN }}
+  production:
+    environment:
+      name: production
+      url: https://example.com
+    runs-on: ubuntu-latest
+    steps:
+      - run: deploy --token ${{ secrets['DEPLOY_TOKEN'] }} --key ${{ secrets.SIGNING_KEY }}
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	if workflow.Name != "Nightly Deploy" {
+		t.Errorf("Expected name %q, got %q", "Nightly Deploy", workflow.Name)
+	}
+
+	expectedSchedules := []string{"0 2 * * *", "0 14 * * 1"}
+	if strings.Join(workflow.Schedules, "|") != strings.Join(expectedSchedules, "|") {
+		t.Errorf("Expected schedules %v, got %v", expectedSchedules, workflow.Schedules)
+	}
+
+	expectedSecrets := []string{"DEPLOY_TOKEN", "SIGNING_KEY"}
+	if strings.Join(workflow.Secrets, "|") != strings.Join(expectedSecrets, "|") {
+		t.Errorf("Expected secrets %v, got %v", expectedSecrets, workflow.Secrets)
+	}
+
+	expectedEnvironments := []string{"production", "staging"}
+	if strings.Join(workflow.Environments, "|") != strings.Join(expectedEnvironments, "|") {
+		t.Errorf("Expected environments %v, got %v", expectedEnvironments, workflow.Environments)
+	}
+}
+
+// TestIsWorkflowFile tests that config overrides are not treated as workflows
+func TestIsWorkflowFile(t *testing.T) {
+	for name, expected := range map[string]bool{
+		"ci.yml":       true,
+		"release.yaml": true,
+		"readme.md":    false,
+		".ghadoc.yaml": false,
+		".ghadoc.yml":  false,
+		"LEGACY.YML":   true,
+		"Deploy.Yaml":  true,
+	} {
+		if got := IsWorkflowFile(name); got != expected {
+			t.Errorf("IsWorkflowFile(%q) = %v, expected %v", name, got, expected)
+		}
+	}
+
+	// Extensions are configurable, with or without a leading dot
+	scanOpts := ScanOptions{Extensions: []string{"yml", ".yml.tmpl"}}
+	for name, expected := range map[string]bool{
+		"ci.yml":         true,
+		"ci.YML.TMPL":    true,
+		"release.yaml":   false,
+		"release.tmpl":   false,
+		"ci.yml.tmpl.md": false,
+	} {
+		if got := scanOpts.IsWorkflowFile(name); got != expected {
+			t.Errorf("IsWorkflowFile(%q) with extensions %v = %v, expected %v", name, scanOpts.Extensions, got, expected)
+		}
+	}
+}
+
+// TestRecursive tests scanning the subdirectories of the workflows directory
+func TestRecursive(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"ci.yml":                 "on: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n",
+		"reusable/build.yml":     "on: workflow_call\njobs:\n  build:\n    runs-on: ubuntu-latest\n",
+		"reusable/settings.yml":  "retries: 3\n",
+		"team/deploy/deploy.yml": "on: workflow_dispatch\njobs:\n  deploy:\n    runs-on: ubuntu-latest\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	workflows, err := ScanDirWithOptions(root, ScanOptions{Recursive: true})
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions failed: %v", err)
+	}
+	var names []string
+	for _, workflow := range workflows {
+		names = append(names, workflow.Filename)
+	}
+	expected := []string{"ci.yml", "reusable/build.yml", "team/deploy/deploy.yml"}
+	if !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{WorkflowsDir: root, Output: filepath.Join(root, "workflows.md")})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	if !strings.Contains(content, "| [reusable/build.yml](reusable/build.yml) |  | workflow_call |\n") {
+		t.Errorf("Expected the subpath in the table, got:\n%s", content)
+	}
+
+	workflows, err = ScanDir(root)
+	if err != nil {
+		t.Fatalf("ScanDir failed: %v", err)
+	}
+	if len(workflows) != 1 {
+		t.Errorf("Expected only ci.yml without Recursive, got %d workflows", len(workflows))
+	}
+}
+
+// TestMultipleDirectories tests documenting several workflows directories
+// together
+func TestMultipleDirectories(t *testing.T) {
+	root := t.TempDir()
+	ciDir := createTempDir(t, "ci")
+	cdDir := createTempDir(t, "cd")
+	createTempWorkflowFile(t, ciDir, "build.yml", "## Builds.\non: push\n")
+	createTempWorkflowFile(t, cdDir, "build.yml", "## Deploys.\non: release\n")
+	output := filepath.Join(root, "workflows.md")
+
+	opts := Options{WorkflowsDirs: []string{ciDir, cdDir}, Output: output}
+	workflows, _, err := opts.ScanWorkflows(context.Background())
+	if err != nil {
+		t.Fatalf("ScanWorkflows failed: %v", err)
+	}
+	if len(workflows) != 2 || workflows[0].Dir != ciDir || workflows[1].Dir != cdDir {
+		t.Fatalf("Expected a workflow of each directory, got %+v", workflows)
+	}
+
+	content, err := generateMarkdownTable(workflows, opts)
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	ciLink := workflowLink(workflows[0], ciDir, output)
+	for _, expected := range []string{
+		fmt.Sprintf("| [%s](%s) | Builds. | push |\n", filepath.ToSlash(filepath.Join(ciDir, "build.yml")), ciLink),
+		"| Deploys. | release |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	opts.GroupBy = GroupByDirectory
+	content, err = generateMarkdownTable(workflows, opts)
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	ciSection := strings.Index(content, "## "+filepath.ToSlash(ciDir)+"\n")
+	cdSection := strings.Index(content, "## "+filepath.ToSlash(cdDir)+"\n")
+	if ciSection == -1 || cdSection < ciSection {
+		t.Errorf("Expected a section per directory in the given order, got:\n%s", content)
+	}
+	if !strings.Contains(content, fmt.Sprintf("| [build.yml](%s) | Builds. | push |\n", ciLink)) {
+		t.Errorf("Expected filenames without directories within sections, got:\n%s", content)
+	}
+}
+
+// TestReuseScans tests that with ReuseScans, every directory is scanned
+// once, and each scan returns only the workflows of its directory
+func TestReuseScans(t *testing.T) {
+	ciDir := createTempDir(t, "ci")
+	cdDir := createTempDir(t, "cd")
+	createTempWorkflowFile(t, ciDir, "build.yml", "## Builds.\non: push\n")
+	createTempWorkflowFile(t, cdDir, "deploy.yml", "## Deploys.\non: release\n")
+
+	opts := Options{WorkflowsDirs: []string{ciDir, cdDir}, Sort: SortName}
+	opts.ReuseScans()
+	first, _, err := opts.ScanWorkflows(context.Background())
+	if err != nil {
+		t.Fatalf("ScanWorkflows failed: %v", err)
+	}
+
+	// Later scans must not read the directories again
+	if err := os.Remove(filepath.Join(cdDir, "deploy.yml")); err != nil {
+		t.Fatalf("Failed to remove deploy.yml: %v", err)
+	}
+	second, _, err := opts.ScanWorkflows(context.Background())
+	if err != nil {
+		t.Fatalf("ScanWorkflows failed: %v", err)
+	}
+	if len(first) != 2 || !reflect.DeepEqual(first, second) {
+		t.Errorf("Expected the same workflows of each directory once, got %+v and %+v", first, second)
+	}
+	if second[0].Dir != ciDir || second[1].Dir != cdDir {
+		t.Errorf("Expected the workflows to keep their directories, got %+v", second)
+	}
+}
+
+// TestRenderTo tests rendering to a writer, and that failing to render leaves
+// no output file behind
+func TestRenderTo(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}}}
+
+	var sb strings.Builder
+	if err := RenderTo(&sb, workflows, Options{Format: FormatCSV}); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+	if expected := "Filename,Description,Triggers\nci.yml,Runs CI.,push\n"; sb.String() != expected {
+		t.Errorf("Expected %q, got %q", expected, sb.String())
+	}
+
+	sb.Reset()
+	if err := RenderTo(&sb, workflows, Options{Header: "Intro"}); err != nil {
+		t.Fatalf("RenderTo failed: %v", err)
+	}
+	if !strings.HasPrefix(sb.String(), "Intro\n\n# ") {
+		t.Errorf("Expected the header before the table, got:\n%s", sb.String())
+	}
+
+	tempDir := createTempDir(t, "render-to")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+	output := filepath.Join(tempDir, "workflows.pdf")
+	if _, err := GenerateWithOptions(Options{WorkflowsDir: tempDir, Output: output, Format: "pdf"}); err == nil {
+		t.Fatal("Expected error for unsupported format, got nil")
+	}
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Errorf("Expected no output file after failing to render, got %v", err)
+	}
+}
+
+// TestScanDirContext tests that scanning stops once the context is canceled
+func TestScanDirContext(t *testing.T) {
+	tempDir := createTempDir(t, "scan-context")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := ScanDirContext(ctx, tempDir, ScanOptions{}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+	if _, err := GenerateContext(ctx, Options{WorkflowsDir: tempDir, Output: filepath.Join(tempDir, "workflows.md")}); !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context canceled, got %v", err)
+	}
+
+	workflows, _, err := ScanDirContext(context.Background(), tempDir, ScanOptions{})
+	if err != nil || len(workflows) != 1 {
+		t.Errorf("Expected 1 workflow, got %d (%v)", len(workflows), err)
+	}
+}
+
+// TestParallelScan tests that parsing files concurrently keeps the order of
+// the scan
+func TestParallelScan(t *testing.T) {
+	tempDir := createTempDir(t, "parallel")
+	if err := os.Mkdir(filepath.Join(tempDir, "nested"), 0755); err != nil {
+		t.Fatalf("Failed to create nested directory: %v", err)
+	}
+	for i := 0; i < 40; i++ {
+		createTempWorkflowFile(t, tempDir, fmt.Sprintf("w%02d.yml", i), fmt.Sprintf("## Workflow %d\non: push\n", i))
+		createTempWorkflowFile(t, tempDir, fmt.Sprintf("nested/n%02d.yml", i), "on: push\njobs: {}\n")
+	}
+	createTempWorkflowFile(t, tempDir, "w05.yml", "on: [push\n")
+
+	serial, serialErrors, err := ScanDirWithErrors(tempDir, ScanOptions{Recursive: true, Workers: 1})
+	if err != nil {
+		t.Fatalf("ScanDirWithErrors failed: %v", err)
+	}
+	parallel, parallelErrors, err := ScanDirWithErrors(tempDir, ScanOptions{Recursive: true, Workers: 16})
+	if err != nil {
+		t.Fatalf("ScanDirWithErrors failed: %v", err)
+	}
+
+	if len(parallel) != 79 || len(parallelErrors) != 1 || parallelErrors[0].Filename != "w05.yml" {
+		t.Fatalf("Expected 79 workflows and a parse error for w05.yml, got %d and %v", len(parallel), parallelErrors)
+	}
+	if len(serialErrors) != 1 {
+		t.Errorf("Expected 1 parse error scanning serially, got %v", serialErrors)
+	}
+	for i := range parallel {
+		if parallel[i].Filename != serial[i].Filename || parallel[i].Description != serial[i].Description {
+			t.Fatalf("Expected %s at %d as when scanning serially, got %s", serial[i].Filename, i, parallel[i].Filename)
+		}
+	}
+	if parallel[0].Filename != "nested/n00.yml" || parallel[40].Filename != "w00.yml" {
+		t.Errorf("Expected the workflows in the order of the directory, got %s and %s first", parallel[0].Filename, parallel[40].Filename)
+	}
+}
+
+// TestScanLimits tests skipping oversized files and deep directories
+func TestScanLimits(t *testing.T) {
+	tempDir := createTempDir(t, "limits")
+	if err := os.MkdirAll(filepath.Join(tempDir, "a", "b", "c"), 0755); err != nil {
+		t.Fatalf("Failed to create nested directories: %v", err)
+	}
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+	createTempWorkflowFile(t, tempDir, "large.yml", "on: push\n"+strings.Repeat("# padding\n", 200))
+	createTempWorkflowFile(t, tempDir, "a/one.yml", "on: push\njobs: {}\n")
+	createTempWorkflowFile(t, tempDir, "a/b/two.yml", "on: push\njobs: {}\n")
+	createTempWorkflowFile(t, tempDir, "a/b/c/three.yml", "on: push\njobs: {}\n")
+
+	workflows, parseErrors, err := ScanDirWithErrors(tempDir, ScanOptions{Recursive: true, MaxFileSize: 1024, MaxDepth: 2})
+	if err != nil {
+		t.Fatalf("ScanDirWithErrors failed: %v", err)
+	}
+	var filenames []string
+	for _, workflow := range workflows {
+		filenames = append(filenames, workflow.Filename)
+	}
+	if expected := []string{"a/b/two.yml", "a/one.yml", "ci.yml"}; !reflect.DeepEqual(filenames, expected) {
+		t.Errorf("Expected workflows %v, got %v", expected, filenames)
+	}
+	if len(parseErrors) != 2 || parseErrors[0].Filename != "a/b/c/" || parseErrors[1].Filename != "large.yml" {
+		t.Fatalf("Expected a/b/c/ and large.yml to be skipped, got %v", parseErrors)
+	}
+	for _, parseError := range parseErrors {
+		if !errors.Is(parseError, ErrLimit) {
+			t.Errorf("Expected %v to match ErrLimit", parseError)
+		}
+	}
+
+	// Without limits everything is scanned
+	workflows, parseErrors, err = ScanDirWithErrors(tempDir, ScanOptions{Recursive: true, MaxFileSize: -1})
+	if err != nil || len(workflows) != 5 || len(parseErrors) != 0 {
+		t.Errorf("Expected 5 workflows without limits, got %d and %v (%v)", len(workflows), parseErrors, err)
+	}
+}
+
+// largeWorkflow returns a workflow with many jobs and top-level keys that
+// are not documented by default.
+func largeWorkflow() []byte {
+	var sb strings.Builder
+	sb.WriteString("## Builds everything\nname: Large\non:\n  push:\n    branches: [main]\n  schedule:\n    - cron: '0 0 * * *'\npermissions: read-all\nenv:\n")
+	for i := 0; i < 200; i++ {
+		fmt.Fprintf(&sb, "  VAR_%d: value-%d\n", i, i)
+	}
+	sb.WriteString("jobs:\n")
+	for i := 0; i < 50; i++ {
+		fmt.Fprintf(&sb, "  job%d:\n    runs-on: ubuntu-latest\n    environment: env%d\n    steps:\n      - uses: actions/checkout@v4\n      - run: echo ${{ secrets.TOKEN_%d }}\n", i, i%3, i)
+	}
+	return []byte(sb.String())
+}
+
+// TestPartialParse tests that partial parses extract the same information
+func TestPartialParse(t *testing.T) {
+	content := largeWorkflow()
+	full, err := ParseWorkflowWithOptions(content, ParseOptions{})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+	partial, err := ParseWorkflowWithOptions(content, ParseOptions{Partial: true})
+	if err != nil {
+		t.Fatalf("ParseWorkflowWithOptions failed: %v", err)
+	}
+
+	if _, ok := partial.document["env"]; ok {
+		t.Error("Expected env not to be decoded by a partial parse")
+	}
+	full.document, partial.document = nil, nil
+	if !reflect.DeepEqual(full, partial) {
+		t.Errorf("Expected partial parse %+v to match full parse %+v", partial, full)
+	}
+
+	if !ReadsDocument([]Column{{Name: "Owner", Exec: "owners"}, {Name: "Runners", Path: "jobs.*.runs-on"}}) || ReadsDocument([]Column{{Name: "Owner", Exec: "owners"}}) {
+		t.Error("Expected only columns with a path to read the document")
+	}
+}
+
+// BenchmarkParseWorkflow compares full and partial parses of a large
+// workflow
+func BenchmarkParseWorkflow(b *testing.B) {
+	content := largeWorkflow()
+	for _, partial := range []bool{false, true} {
+		b.Run(fmt.Sprintf("partial=%v", partial), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				if _, err := ParseWorkflowWithOptions(content, ParseOptions{Partial: partial}); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"sort"
+)
+
+// Supported ways of grouping the summary table.
+const (
+	GroupByTrigger   = "trigger"   // Events triggering the workflow
+	GroupByDirectory = "directory" // Directory of the workflow file
+	GroupByOwner     = "owner"     // `owner`/`owners` of the metadata block
+	GroupByTag       = "tag"       // `tags` of the metadata block
+)
+
+// GroupBys are the supported values of Options.GroupBy.
+var GroupBys = []string{GroupByTrigger, GroupByDirectory, GroupByOwner, GroupByTag}
+
+// Group is a section of the summary table.
+type Group struct {
+	Name      string
+	Workflows []WorkflowInfo
+}
+
+// groupWorkflows splits workflows into the groups of opts.GroupBy. The
+// workflows keep their order within each group, and a workflow with several
+// triggers, owners, or tags is in several groups. Workflows without any are
+// collected in a last group.
+func groupWorkflows(workflows []WorkflowInfo, opts Options) ([]Group, error) {
+	var key func(WorkflowInfo) []string
+	var fallback string
+	switch opts.GroupBy {
+	case GroupByTrigger:
+		key = func(workflow WorkflowInfo) []string { return workflow.Triggers }
+		fallback = opts.t("No triggers")
+	case GroupByOwner:
+		key = func(workflow WorkflowInfo) []string { return metadataValues(workflow.Metadata, "owner", "owners") }
+		fallback = opts.t("No owner")
+	case GroupByTag:
+		key = func(workflow WorkflowInfo) []string { return metadataValues(workflow.Metadata, "tags", "tag") }
+		fallback = opts.t("No tags")
+	case GroupByDirectory:
+		key = func(workflow WorkflowInfo) []string {
+			return []string{path.Join(filepath.ToSlash(opts.workflowsDir(workflow)), path.Dir(workflow.Filename))}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported grouping %q", opts.GroupBy)
+	}
+
+	var groups []Group
+	index := make(map[string]int)
+	var rest []WorkflowInfo
+	for _, workflow := range workflows {
+		names := key(workflow)
+		if len(names) == 0 {
+			rest = append(rest, workflow)
+		}
+		for _, name := range names {
+			i, ok := index[name]
+			if !ok {
+				i = len(groups)
+				index[name] = i
+				groups = append(groups, Group{Name: name})
+			}
+			groups[i].Workflows = append(groups[i].Workflows, workflow)
+		}
+	}
+
+	// Directories keep the order they were scanned in: as given, but after
+	// the directories of the reusable workflows they call
+	if opts.GroupBy != GroupByDirectory {
+		sort.SliceStable(groups, func(i, j int) bool { return groups[i].Name < groups[j].Name })
+	}
+	if len(rest) > 0 {
+		groups = append(groups, Group{Name: fallback, Workflows: rest})
+	}
+	return groups, nil
+}
+
+// metadataValues returns the values of the first of keys present in
+// metadata, which may be a single value or a list.
+func metadataValues(metadata map[string]interface{}, keys ...string) []string {
+	for _, key := range keys {
+		switch value := metadata[key].(type) {
+		case nil:
+			continue
+		case []interface{}:
+			var items []string
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			return items
+		default:
+			return []string{fmt.Sprint(value)}
+		}
+	}
+	return nil
+}
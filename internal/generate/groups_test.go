@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestGroupWorkflows tests grouping by trigger, owner, and tag
+func TestGroupWorkflows(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"push", "pull_request"}, Metadata: map[string]interface{}{"owner": "platform", "tags": []interface{}{"ci"}}},
+		{Filename: "deploy.yml", Triggers: []string{"push"}, Metadata: map[string]interface{}{"owners": []interface{}{"ops", "platform"}}},
+		{Filename: "manual.yml"},
+	}
+
+	for _, test := range []struct {
+		groupBy  string
+		expected map[string][]string
+		order    []string
+	}{
+		{GroupByTrigger, map[string][]string{"pull_request": {"ci.yml"}, "push": {"ci.yml", "deploy.yml"}, "No triggers": {"manual.yml"}}, []string{"pull_request", "push", "No triggers"}},
+		{GroupByOwner, map[string][]string{"ops": {"deploy.yml"}, "platform": {"ci.yml", "deploy.yml"}, "No owner": {"manual.yml"}}, []string{"ops", "platform", "No owner"}},
+		{GroupByTag, map[string][]string{"ci": {"ci.yml"}, "No tags": {"deploy.yml", "manual.yml"}}, []string{"ci", "No tags"}},
+	} {
+		groups, err := groupWorkflows(workflows, Options{GroupBy: test.groupBy})
+		if err != nil {
+			t.Fatalf("groupWorkflows(%s) failed: %v", test.groupBy, err)
+		}
+
+		var order []string
+		for _, group := range groups {
+			order = append(order, group.Name)
+			var filenames []string
+			for _, workflow := range group.Workflows {
+				filenames = append(filenames, workflow.Filename)
+			}
+			if !reflect.DeepEqual(filenames, test.expected[group.Name]) {
+				t.Errorf("Expected group %q of %s to hold %v, got %v", group.Name, test.groupBy, test.expected[group.Name], filenames)
+			}
+		}
+		if !reflect.DeepEqual(order, test.order) {
+			t.Errorf("Expected %s groups %v, got %v", test.groupBy, test.order, order)
+		}
+	}
+
+	if _, err := groupWorkflows(workflows, Options{GroupBy: "color"}); err == nil {
+		t.Error("Expected error for unsupported grouping, got nil")
+	}
+}
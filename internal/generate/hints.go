@@ -0,0 +1,64 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// triggerHints describes the context and payload a workflow receives for
+// each trigger, and the security restrictions that apply.
+var triggerHints = map[string]string{
+	"check_run":                   "`github.event.check_run` available; runs on the default branch.",
+	"check_suite":                 "`github.event.check_suite` available; runs on the default branch.",
+	"create":                      "`github.event.ref` and `github.event.ref_type` name the created branch or tag.",
+	"delete":                      "`github.event.ref` and `github.event.ref_type` name the deleted branch or tag; runs on the default branch.",
+	"deployment":                  "`github.event.deployment` available; `github.sha` is the commit to deploy.",
+	"deployment_status":           "`github.event.deployment` and `github.event.deployment_status` available.",
+	"issue_comment":               "`github.event.issue` and `github.event.comment` available; also fires for pull request comments (`github.event.issue.pull_request` is set). Runs on the default branch with secrets.",
+	"issues":                      "`github.event.issue` available; runs on the default branch.",
+	"merge_group":                 "`github.event.merge_group` available; `github.ref` is the temporary merge queue branch.",
+	"pull_request":                "`github.event.pull_request` available; runs on the merge commit. Secrets unavailable and `GITHUB_TOKEN` read-only for forks.",
+	"pull_request_review":         "`github.event.review` and `github.event.pull_request` available. Secrets unavailable and `GITHUB_TOKEN` read-only for forks.",
+	"pull_request_review_comment": "`github.event.comment` and `github.event.pull_request` available. Secrets unavailable and `GITHUB_TOKEN` read-only for forks.",
+	"pull_request_target":         "`github.event.pull_request` available; runs on the base branch with secrets and a write `GITHUB_TOKEN`, even for forks. Never check out and run untrusted pull request code.",
+	"push":                        "`github.event` is the push payload (`before`, `after`, `commits`); `github.ref` is the pushed branch or tag.",
+	"registry_package":            "`github.event.registry_package` available.",
+	"release":                     "`github.event.release` available; `github.ref` is the release tag.",
+	"repository_dispatch":         "`github.event.client_payload` holds the payload sent to the API; runs on the default branch.",
+	"schedule":                    "Runs on the latest commit of the default branch; `github.event.schedule` is the cron expression that fired. Disabled after 60 days without activity in public repositories.",
+	"status":                      "`github.event` describes the commit status (`state`, `context`, `sha`).",
+	"workflow_call":               "`inputs` and `secrets` are passed by the caller; the `github` context is the caller's.",
+	"workflow_dispatch":           "`inputs` holds the inputs given when triggered manually; runs on the selected branch.",
+	"workflow_run":                "`github.event.workflow_run` describes the triggering run; runs on the default branch with secrets and a write `GITHUB_TOKEN`, even when the triggering run came from a fork.",
+}
+
+// TriggerHint returns a short note on the context and payload a workflow
+// receives for trigger, or "" if there is none.
+func TriggerHint(trigger string) string {
+	return triggerHints[trigger]
+}
+
+// writeTriggerHints writes a section with the hint of every trigger used by
+// the workflows, in the language of opts.
+func writeTriggerHints(sb *strings.Builder, workflows []WorkflowInfo, opts Options) {
+	seen := make(map[string]bool)
+	var triggers []string
+	for _, workflow := range workflows {
+		for _, trigger := range workflow.Triggers {
+			if !seen[trigger] && TriggerHint(trigger) != "" {
+				seen[trigger] = true
+				triggers = append(triggers, trigger)
+			}
+		}
+	}
+	if len(triggers) == 0 {
+		return
+	}
+	sort.Strings(triggers)
+
+	sb.WriteString("\n## " + opts.t("Trigger notes") + "\n\n")
+	for _, trigger := range triggers {
+		sb.WriteString(fmt.Sprintf("- `%s`: %s\n", trigger, opts.t(TriggerHint(trigger))))
+	}
+}
@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTriggerHintsInTable tests the trigger notes below the summary table
+func TestTriggerHintsInTable(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"pull_request", "push"}},
+		{Filename: "label.yml", Triggers: []string{"pull_request_target", "custom_event"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{Output: "workflows.md", TriggerHints: true})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	expected := "\n## Trigger notes\n\n" +
+		"- `pull_request`: " + TriggerHint("pull_request") + "\n" +
+		"- `pull_request_target`: " + TriggerHint("pull_request_target") + "\n" +
+		"- `push`: " + TriggerHint("push") + "\n"
+	if !strings.HasSuffix(content, expected) {
+		t.Errorf("Expected output to end with:\n%s\nGot:\n%s", expected, content)
+	}
+
+	content, err = generateMarkdownTable(workflows, Options{Output: "workflows.md"})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	if strings.Contains(content, "Trigger notes") {
+		t.Errorf("Expected no trigger notes without TriggerHints, got:\n%s", content)
+	}
+}
+
+// TestTriggerHintsInPages tests the trigger notes on workflow pages
+func TestTriggerHintsInPages(t *testing.T) {
+	pagesDir := createTempDir(t, "pages")
+
+	err := generatePages([]WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"custom_event", "pull_request"}},
+	}, Options{PagesDir: pagesDir, TriggerHints: true})
+	if err != nil {
+		t.Fatalf("generatePages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(pagesDir, "ci.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+
+	expected := "- `custom_event`\n- `pull_request`: " + TriggerHint("pull_request") + "\n"
+	if !strings.Contains(string(content), expected) {
+		t.Errorf("Expected page to contain %q, got:\n%s", expected, content)
+	}
+	if !strings.Contains(TriggerHint("pull_request"), "forks") {
+		t.Errorf("Expected pull_request hint to mention forks, got %q", TriggerHint("pull_request"))
+	}
+}
@@ -0,0 +1,29 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+)
+
+// Hook runs on every workflow of a scan once it is parsed, before it is
+// filtered, sorted, and rendered. It may change the workflow, for example to
+// add Metadata looked up in a service catalog, and returns false to leave
+// the workflow out of the documentation. An error fails the scan. Hooks run
+// on one workflow at a time, in the order of the scan.
+type Hook func(ctx context.Context, workflow *WorkflowInfo) (keep bool, err error)
+
+// ApplyHooks runs the hooks of scanOpts on workflow in order, stopping at
+// the first that leaves it out or fails. It reports whether to keep the
+// workflow.
+func (scanOpts ScanOptions) ApplyHooks(ctx context.Context, workflow *WorkflowInfo) (bool, error) {
+	for _, hook := range scanOpts.Hooks {
+		keep, err := hook(ctx, workflow)
+		if err != nil {
+			return false, fmt.Errorf("error processing workflow %s: %v", workflow.Filename, err)
+		}
+		if !keep {
+			return false, nil
+		}
+	}
+	return true, nil
+}
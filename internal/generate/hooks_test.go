@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// TestHooks tests enriching and leaving out workflows with scan hooks
+func TestHooks(t *testing.T) {
+	tempDir := createTempDir(t, "hooks")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "on: push\n")
+	createTempWorkflowFile(t, tempDir, "deploy.yml", "on: workflow_dispatch\n")
+	createTempWorkflowFile(t, tempDir, "scratch.yml", "on: push\n")
+
+	owners := map[string]string{"ci.yml": "platform", "deploy.yml": "release"}
+	var calls []string
+	scanOpts := ScanOptions{Hooks: []Hook{
+		func(ctx context.Context, workflow *WorkflowInfo) (bool, error) {
+			calls = append(calls, workflow.Filename)
+			return workflow.Filename != "scratch.yml", nil
+		},
+		func(ctx context.Context, workflow *WorkflowInfo) (bool, error) {
+			if workflow.Metadata == nil {
+				workflow.Metadata = make(map[string]interface{})
+			}
+			workflow.Metadata["owner"] = owners[workflow.Filename]
+			return true, nil
+		},
+	}}
+
+	workflows, _, err := ScanDirWithErrors(tempDir, scanOpts)
+	if err != nil {
+		t.Fatalf("ScanDirWithErrors failed: %v", err)
+	}
+	if len(workflows) != 2 || len(calls) != 3 {
+		t.Fatalf("Expected 2 of 3 workflows kept, got %d of %d", len(workflows), len(calls))
+	}
+	for _, workflow := range workflows {
+		if workflow.Metadata["owner"] != owners[workflow.Filename] {
+			t.Errorf("Expected owner %q for %s, got %v", owners[workflow.Filename], workflow.Filename, workflow.Metadata["owner"])
+		}
+	}
+
+	// The metadata added by the hooks is rendered
+	table, err := Render(Options{WorkflowsDir: tempDir, ScanOptions: scanOpts, GroupBy: GroupByOwner})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(table, "## platform") || !strings.Contains(table, "## release") {
+		t.Errorf("Expected sections of the owners added by the hooks, got:\n%s", table)
+	}
+
+	// A failing hook fails the scan
+	scanOpts.Hooks = append(scanOpts.Hooks, func(ctx context.Context, workflow *WorkflowInfo) (bool, error) {
+		return false, fmt.Errorf("catalog unavailable")
+	})
+	_, _, err = ScanDirWithErrors(tempDir, scanOpts)
+	if err == nil || !strings.Contains(err.Error(), "catalog unavailable") {
+		t.Errorf("Expected the error of the hook, got %v", err)
+	}
+}
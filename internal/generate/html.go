@@ -0,0 +1,120 @@
+package generate
+
+import (
+	"embed"
+	"html/template"
+	"sort"
+	"strings"
+)
+
+//go:embed templates
+var templatesFS embed.FS
+
+// widgetData is the data rendered by the HTML widget template.
+type widgetData struct {
+	Total      int
+	Documented int
+	Coverage   int // Percentage of workflows with a description
+	Triggers   []triggerCount
+	Workflows  []widgetRow
+
+	HasStatus bool // Whether the latest runs of the workflows are shown
+	Passing   int  // Workflows whose latest run succeeded
+	Failing   int  // Workflows whose latest run failed
+}
+
+// triggerCount is the number of workflows using a trigger.
+type triggerCount struct {
+	Name    string
+	Count   int
+	Percent int
+}
+
+// widgetRow is a single workflow in the HTML widget.
+type widgetRow struct {
+	Filename         string
+	Link             string
+	DescriptionLines []string
+	Triggers         []string
+	Status           string // Conclusion of the latest run, with HasStatus
+	StatusClass      string // Class coloring Status: success, failure, or neutral
+	LastRun          string // Time of the latest run
+}
+
+// generateHTMLWidget creates a self-contained HTML page summarizing workflow
+// counts, trigger usage, and description coverage. The page has no external
+// dependencies so that it can be embedded in dashboards via an iframe.
+func generateHTMLWidget(workflows []WorkflowInfo, opts Options) (string, error) {
+	tmpl, err := template.ParseFS(templatesFS, "templates/widget.html.tmpl")
+	if err != nil {
+		return "", err
+	}
+
+	data := widgetData{Total: len(workflows), HasStatus: opts.Status != nil}
+	counts := make(map[string]int)
+
+	for _, workflow := range workflows {
+		row := widgetRow{
+			Filename: workflow.Filename,
+			Link:     opts.link(workflow, opts.Output),
+			Triggers: workflow.Triggers,
+		}
+		if workflow.Description != "" {
+			data.Documented++
+			row.DescriptionLines = strings.Split(workflow.Description, "<br>")
+		}
+		if data.HasStatus {
+			status := opts.Status[workflow.Filename]
+			row.Status, row.LastRun = opts.t(status.statusCell()), status.lastRunCell()
+			row.StatusClass = statusClass(status.Conclusion)
+			switch row.StatusClass {
+			case "success":
+				data.Passing++
+			case "failure":
+				data.Failing++
+			}
+		}
+		for _, trigger := range workflow.Triggers {
+			counts[trigger]++
+		}
+		data.Workflows = append(data.Workflows, row)
+	}
+
+	if data.Total > 0 {
+		data.Coverage = data.Documented * 100 / data.Total
+	}
+
+	for name, count := range counts {
+		data.Triggers = append(data.Triggers, triggerCount{
+			Name:    name,
+			Count:   count,
+			Percent: count * 100 / data.Total,
+		})
+	}
+	// Most used triggers first, then alphabetically for a stable order
+	sort.Slice(data.Triggers, func(i, j int) bool {
+		if data.Triggers[i].Count != data.Triggers[j].Count {
+			return data.Triggers[i].Count > data.Triggers[j].Count
+		}
+		return data.Triggers[i].Name < data.Triggers[j].Name
+	})
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, data); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// statusClass returns the class coloring the conclusion of a run in the
+// widget: success, failure for runs that need attention, or neutral.
+func statusClass(conclusion string) string {
+	switch conclusion {
+	case "success":
+		return "success"
+	case "failure", "timed_out", "startup_failure":
+		return "failure"
+	default:
+		return "neutral"
+	}
+}
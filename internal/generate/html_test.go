@@ -0,0 +1,116 @@
+package generate
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestGenerateHTMLWidget tests the HTML widget output
+func TestGenerateHTMLWidget(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{
+			Filename:    "ci.yml",
+			Description: "Runs CI.<br>On every push.",
+			Triggers:    []string{"pull_request", "push"},
+		},
+		{
+			Filename:    "release.yml",
+			Description: "",
+			Triggers:    []string{"push"},
+		},
+		{
+			Filename:    "nightly.yml",
+			Description: "Runs <nightly> checks.",
+			Triggers:    []string{"schedule"},
+		},
+	}
+
+	html, err := generateHTMLWidget(workflows, Options{WorkflowsDir: "test/workflows", Output: "test/output.html"})
+	if err != nil {
+		t.Fatalf("generateHTMLWidget failed: %v", err)
+	}
+
+	expectedStrings := []string{
+		"<!DOCTYPE html>",
+		`<div class="value">3</div><div class="label">Workflows</div>`,
+		`<div class="value">2</div><div class="label">Documented</div>`,
+		`<div class="value">66%</div>`,
+		"<tr><td>push</td><td>2</td>",
+		`<a href="workflows/ci.yml" target="_top">ci.yml</a>`,
+		"<td>Runs CI.<br>On every push.</td>",
+		"Runs &lt;nightly&gt; checks.",
+		`<button type="button" data-trigger="schedule">schedule</button>`,
+	}
+
+	for _, str := range expectedStrings {
+		if !strings.Contains(html, str) {
+			t.Errorf("Expected HTML widget to contain %q, but it doesn't", str)
+		}
+	}
+
+	// The most used trigger should be listed first
+	if strings.Index(html, "<tr><td>push</td>") > strings.Index(html, "<tr><td>pull_request</td>") {
+		t.Error("Expected triggers to be ordered by usage")
+	}
+}
+
+// TestGenerateHTMLWidgetStatus tests showing the latest runs of the
+// workflows in the HTML widget
+func TestGenerateHTMLWidgetStatus(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"push"}},
+		{Filename: "deploy.yml", Triggers: []string{"push"}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}},
+	}
+	opts := Options{WorkflowsDir: "test/workflows", Output: "test/output.html"}
+
+	html, err := generateHTMLWidget(workflows, opts)
+	if err != nil {
+		t.Fatalf("generateHTMLWidget failed: %v", err)
+	}
+	if strings.Contains(html, "<th>Status</th>") || strings.Contains(html, `<div class="label">Passing</div>`) {
+		t.Error("Expected no status without requested statuses")
+	}
+
+	opts.Status = map[string]RunStatus{
+		"ci.yml":     {Conclusion: "success", RunAt: time.Date(2024, 5, 1, 12, 30, 0, 0, time.UTC)},
+		"deploy.yml": {Conclusion: "failure", RunAt: time.Date(2024, 5, 2, 8, 0, 0, 0, time.UTC)},
+	}
+	html, err = generateHTMLWidget(workflows, opts)
+	if err != nil {
+		t.Fatalf("generateHTMLWidget failed: %v", err)
+	}
+	for _, expected := range []string{
+		"<th>Status</th>",
+		`<div class="value">1</div><div class="label">Passing</div>`,
+		`<div class="value">1</div><div class="label">Failing</div>`,
+		`<span class="status success" title="2024-05-01 12:30 UTC">success</span>`,
+		`<span class="status failure" title="2024-05-02 08:00 UTC">failure</span>`,
+		`<span class="status neutral">no runs</span>`,
+	} {
+		if !strings.Contains(html, expected) {
+			t.Errorf("Expected HTML widget to contain %q, got:\n%s", expected, html)
+		}
+	}
+}
+
+// TestGenerateWithOptionsFormats tests format selection in GenerateWithOptions
+func TestGenerateWithOptionsFormats(t *testing.T) {
+	workflowsDir := createWorkflowsDir(t, map[string]string{
+		"ci.yml": "## Runs CI.\non: push\n",
+	})
+	outputDir := filepath.Dir(workflowsDir)
+
+	htmlOutput := filepath.Join(outputDir, "workflows.html")
+	_, err := GenerateWithOptions(Options{WorkflowsDir: workflowsDir, Output: htmlOutput, Format: FormatHTML})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	_, err = GenerateWithOptions(Options{WorkflowsDir: workflowsDir, Output: htmlOutput, Format: "pdf"})
+	if err == nil {
+		t.Error("Expected error for unsupported format, got nil")
+	}
+}
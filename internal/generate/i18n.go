@@ -0,0 +1,77 @@
+package generate
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLanguage is the language the documentation is written in unless
+// another one is requested. It needs no bundle.
+const DefaultLanguage = "en"
+
+// localeFS holds the translation bundles, one YAML file per language mapping
+// English text to its translation.
+//
+//go:embed locales/*.yaml
+var localeFS embed.FS
+
+// Languages returns the languages documentation can be generated in.
+func Languages() []string {
+	languages := []string{DefaultLanguage}
+	files, _ := fs.Glob(localeFS, "locales/*.yaml")
+	for _, file := range files {
+		languages = append(languages, strings.TrimSuffix(path.Base(file), ".yaml"))
+	}
+	sort.Strings(languages)
+	return languages
+}
+
+// loadMessages returns the translation bundle of lang, e.g. "de" or "de-AT",
+// which falls back to "de". The default language has no bundle.
+func loadMessages(lang string) (map[string]string, error) {
+	lang = strings.ToLower(strings.ReplaceAll(lang, "_", "-"))
+	base, _, _ := strings.Cut(lang, "-")
+	if lang == "" || base == DefaultLanguage {
+		return nil, nil
+	}
+
+	content, err := localeFS.ReadFile("locales/" + lang + ".yaml")
+	if err != nil {
+		content, err = localeFS.ReadFile("locales/" + base + ".yaml")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unsupported language %q; available languages are %s", lang, strings.Join(Languages(), ", "))
+	}
+
+	var messages map[string]string
+	if err := yaml.Unmarshal(content, &messages); err != nil {
+		return nil, fmt.Errorf("error parsing translations of %s: %v", lang, err)
+	}
+	return messages, nil
+}
+
+// loadLanguage loads the translation bundle of opts.Lang, unless it is loaded
+// already.
+func (opts *Options) loadLanguage() error {
+	if opts.messages != nil {
+		return nil
+	}
+	messages, err := loadMessages(opts.Lang)
+	opts.messages = messages
+	return err
+}
+
+// t translates English text into the language of opts, or returns it as is
+// if there is no translation.
+func (opts Options) t(text string) string {
+	if translated, ok := opts.messages[text]; ok {
+		return translated
+	}
+	return text
+}
@@ -0,0 +1,52 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestLanguages tests that every translation bundle loads and formats
+func TestLanguages(t *testing.T) {
+	for _, lang := range Languages() {
+		messages, err := loadMessages(lang)
+		if err != nil {
+			t.Fatalf("loadMessages(%q) failed: %v", lang, err)
+		}
+		if lang != DefaultLanguage && len(messages) == 0 {
+			t.Errorf("Expected translations for %s", lang)
+		}
+
+		metrics := RunMetrics{Runs: 20, Decided: 18, SuccessRate: 0.8333}
+		if cell := metrics.successRateCell(Options{messages: messages}.t); strings.Contains(cell, "%!") || !strings.Contains(cell, "83") || !strings.Contains(cell, "18") {
+			t.Errorf("Unexpected success rate in %s: %q", lang, cell)
+		}
+	}
+}
+
+// TestTranslatedTable tests generating the table in another language
+func TestTranslatedTable(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Triggers: []string{"push"}}}
+
+	content, err := render(context.Background(), workflows, Options{
+		Output: "workflows.md",
+		Lang:   "de_AT",
+		Status: map[string]RunStatus{},
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	for _, expected := range []string{
+		"# Übersicht der GitHub-Workflows\n",
+		"| Dateiname | Beschreibung | Auslöser | Status | Letzter Lauf |\n",
+		"| [ci.yml](ci.yml) |  | push | keine Läufe |  |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	if _, err := render(context.Background(), workflows, Options{Lang: "tlh"}); err == nil {
+		t.Error("Expected an error for an unsupported language")
+	}
+}
@@ -0,0 +1,138 @@
+package generate
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/pathmatch"
+)
+
+// IgnoreFiles are the files whose gitignore-style patterns are honored when
+// scanning with ScanOptions.RespectIgnore.
+var IgnoreFiles = []string{".gitignore", ".ghadocignore"}
+
+// ignoreRule is a pattern of an ignore file.
+type ignoreRule struct {
+	base    string // Directory of the ignore file, relative to the root
+	pattern string
+	negate  bool
+}
+
+// ignorer decides which files are ignored by the ignore files between the
+// root of a git repository and the scanned directory.
+type ignorer struct {
+	root  string
+	rules []ignoreRule
+}
+
+// newIgnorer loads the ignore files of dir and of its parents up to the root
+// of the git repository containing it, or only those of dir outside of one.
+func newIgnorer(dir string) (*ignorer, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving workflows directory: %v", err)
+	}
+
+	root := dir
+	for parent := dir; ; parent = filepath.Dir(parent) {
+		if _, err := os.Stat(filepath.Join(parent, ".git")); err == nil {
+			root = parent
+			break
+		}
+		if filepath.Dir(parent) == parent {
+			break
+		}
+	}
+
+	ig := &ignorer{root: root}
+	rel, _ := filepath.Rel(root, dir)
+	current := root
+	if err := ig.load(current); err != nil {
+		return nil, err
+	}
+	if rel != "." {
+		for _, name := range strings.Split(rel, string(filepath.Separator)) {
+			current = filepath.Join(current, name)
+			if err := ig.load(current); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ig, nil
+}
+
+// load adds the rules of the ignore files of dir, which must be within the
+// root.
+func (ig *ignorer) load(dir string) error {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	base, err := filepath.Rel(ig.root, dir)
+	if err != nil {
+		return err
+	}
+	base = filepath.ToSlash(base)
+
+	for _, name := range IgnoreFiles {
+		file, err := os.Open(filepath.Join(dir, name))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", name, err)
+		}
+
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			rule := ignoreRule{base: base, pattern: line}
+			if strings.HasPrefix(line, "!") {
+				rule.negate = true
+				rule.pattern = line[1:]
+			}
+			ig.rules = append(ig.rules, rule)
+		}
+		err = scanner.Err()
+		file.Close()
+		if err != nil {
+			return fmt.Errorf("error reading %s: %v", name, err)
+		}
+	}
+	return nil
+}
+
+// ignored reports whether the file at filePath is ignored. The last matching
+// rule decides, so later and deeper rules override earlier ones.
+func (ig *ignorer) ignored(filePath string) bool {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return false
+	}
+	rel, err := filepath.Rel(ig.root, abs)
+	if err != nil {
+		return false
+	}
+	rel = filepath.ToSlash(rel)
+
+	ignored := false
+	for _, rule := range ig.rules {
+		target := rel
+		if rule.base != "." {
+			if !strings.HasPrefix(rel, rule.base+"/") {
+				continue
+			}
+			target = strings.TrimPrefix(rel, rule.base+"/")
+		}
+		if pathmatch.MatchGitignore(rule.pattern, target) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
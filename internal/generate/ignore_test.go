@@ -0,0 +1,56 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestRespectIgnore tests skipping hidden and ignored workflow files
+func TestRespectIgnore(t *testing.T) {
+	root := t.TempDir()
+	workflowsDir := filepath.Join(root, ".github", "workflows")
+	for _, dir := range []string{filepath.Join(root, ".git"), workflowsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+
+	files := map[string]string{
+		filepath.Join(root, ".gitignore"):            "# Generated workflows\n*.gen.yml\n",
+		filepath.Join(workflowsDir, ".ghadocignore"): "draft.yml\n!keep.gen.yml\n",
+	}
+	for _, name := range []string{"ci.yml", "draft.yml", "matrix.gen.yml", "keep.gen.yml", ".hidden.yml"} {
+		files[filepath.Join(workflowsDir, name)] = "on: push\n"
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", path, err)
+		}
+	}
+
+	filenames := func(workflows []WorkflowInfo) []string {
+		var names []string
+		for _, workflow := range workflows {
+			names = append(names, workflow.Filename)
+		}
+		return names
+	}
+
+	workflows, err := ScanDirWithOptions(workflowsDir, ScanOptions{RespectIgnore: true})
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions failed: %v", err)
+	}
+	if names, expected := filenames(workflows), []string{"ci.yml", "keep.gen.yml"}; !reflect.DeepEqual(names, expected) {
+		t.Errorf("Expected %v, got %v", expected, names)
+	}
+
+	workflows, err = ScanDir(workflowsDir)
+	if err != nil {
+		t.Fatalf("ScanDir failed: %v", err)
+	}
+	if len(workflows) != 5 {
+		t.Errorf("Expected all 5 workflows without RespectIgnore, got %v", filenames(workflows))
+	}
+}
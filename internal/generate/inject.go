@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Markers enclosing the generated documentation in a file it is injected
+// into with Options.Inject.
+const (
+	InjectStart = "<!-- ghadoc:start -->"
+	InjectEnd   = "<!-- ghadoc:end -->"
+)
+
+// injectFile replaces the content between the markers of the file at path
+// with content, leaving the rest of the file untouched. The file is only
+// written if its content changes.
+func (opts Options) injectFile(path, content string) error {
+	existing, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("error reading file to inject into: %v", err)
+	}
+
+	injected, err := inject(string(existing), content)
+	if err != nil {
+		return fmt.Errorf("error injecting into %s: %v", path, err)
+	}
+	if injected == string(existing) {
+		return nil
+	}
+	if opts.DryRun != nil {
+		opts.DryRun(path, []byte(injected))
+		return nil
+	}
+
+	err = os.WriteFile(path, []byte(injected), 0644)
+	if err != nil {
+		return outputError(fmt.Errorf("error writing to output file: %v", err))
+	}
+	return nil
+}
+
+// inject replaces the text between InjectStart and InjectEnd in document
+// with content, on lines of its own. Injecting the same content again leaves
+// document unchanged.
+func inject(document, content string) (string, error) {
+	start := strings.Index(document, InjectStart)
+	if start == -1 {
+		return "", fmt.Errorf("missing %s marker", InjectStart)
+	}
+	afterStart := start + len(InjectStart)
+	end := strings.Index(document[afterStart:], InjectEnd)
+	if end == -1 {
+		return "", fmt.Errorf("missing %s marker after %s", InjectEnd, InjectStart)
+	}
+	end += afterStart
+
+	return document[:afterStart] + "\n" + strings.Trim(content, "\n") + "\n" + document[end:], nil
+}
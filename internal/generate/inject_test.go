@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestInject tests injecting the table into a file between markers
+func TestInject(t *testing.T) {
+	tempDir := createTempDir(t, "inject-test")
+	defer os.RemoveAll(tempDir)
+
+	workflowsDir := filepath.Join(tempDir, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows directory: %v", err)
+	}
+	createTempWorkflowFile(t, workflowsDir, "ci.yml", "## Runs CI.\non: push\n")
+
+	readme := filepath.Join(tempDir, "README.md")
+	original := "# Project\n\n<!-- ghadoc:start -->\nstale\n<!-- ghadoc:end -->\n\n## License\n"
+	if err := os.WriteFile(readme, []byte(original), 0644); err != nil {
+		t.Fatalf("Failed to write README: %v", err)
+	}
+
+	opts := Options{WorkflowsDir: workflowsDir, Inject: readme}
+	if _, err := GenerateWithOptions(opts); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	content, err := os.ReadFile(readme)
+	if err != nil {
+		t.Fatalf("Failed to read README: %v", err)
+	}
+
+	expected := "# Project\n\n<!-- ghadoc:start -->\n# GitHub Workflows Summary\n\n| Filename | Description | Triggers |\n| --- | --- | --- |\n" +
+		"| [ci.yml](.github/workflows/ci.yml) | Runs CI. | push |\n<!-- ghadoc:end -->\n\n## License\n"
+	if string(content) != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, content)
+	}
+
+	// Injecting again leaves the file unchanged
+	if _, err := GenerateWithOptions(opts); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	again, _ := os.ReadFile(readme)
+	if string(again) != expected {
+		t.Errorf("Expected injecting to be idempotent, got:\n%s", again)
+	}
+}
+
+// TestInjectErrors tests files without markers
+func TestInjectErrors(t *testing.T) {
+	for _, document := range []string{
+		"# Project\n",
+		"<!-- ghadoc:end -->\n<!-- ghadoc:start -->\n",
+	} {
+		if _, err := inject(document, "table"); err == nil || !strings.Contains(err.Error(), "marker") {
+			t.Errorf("Expected missing marker error for %q, got %v", document, err)
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+)
+
+// JobInfo stores information about a job of a workflow.
+type JobInfo struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	RunsOn      []string   `json:"runs_on,omitempty"`     // Runner labels, or the runner group
+	Uses        string     `json:"uses,omitempty"`        // Reusable workflow called by the job
+	Permissions []string   `json:"permissions,omitempty"` // GITHUB_TOKEN permissions of the job
+	Steps       []StepInfo `json:"steps,omitempty"`
+}
+
+// StepInfo stores information about a step of a job.
+type StepInfo struct {
+	ID   string            `json:"id,omitempty"`
+	Name string            `json:"name,omitempty"`
+	Uses string            `json:"uses,omitempty"`
+	Run  string            `json:"run,omitempty"`
+	With map[string]string `json:"with,omitempty"` // Inputs of the action
+}
+
+// parseJobs extracts the jobs of a workflow, sorted by ID.
+func parseJobs(jobs interface{}) []JobInfo {
+	jobsMap, ok := jobs.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	var jobInfos []JobInfo
+	for id, job := range jobsMap {
+		jobMap, ok := job.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		jobInfo := JobInfo{
+			ID:          id,
+			Name:        stringValue(jobMap["name"]),
+			RunsOn:      parseRunsOn(jobMap["runs-on"]),
+			Uses:        stringValue(jobMap["uses"]),
+			Permissions: parsePermissions(jobMap["permissions"]),
+		}
+
+		if steps, ok := jobMap["steps"].([]interface{}); ok {
+			for _, step := range steps {
+				stepMap, ok := step.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				jobInfo.Steps = append(jobInfo.Steps, StepInfo{
+					ID:   stringValue(stepMap["id"]),
+					Name: stringValue(stepMap["name"]),
+					Uses: stringValue(stepMap["uses"]),
+					Run:  stringValue(stepMap["run"]),
+					With: parseWith(stepMap["with"]),
+				})
+			}
+		}
+
+		jobInfos = append(jobInfos, jobInfo)
+	}
+
+	sort.Slice(jobInfos, func(i, j int) bool {
+		return jobInfos[i].ID < jobInfos[j].ID
+	})
+	return jobInfos
+}
+
+// parseRunsOn extracts the runner labels from a runs-on value, which is a
+// label, a list of labels, or a map with a group and labels.
+func parseRunsOn(runsOn interface{}) []string {
+	if runsOnMap, ok := runsOn.(map[string]interface{}); ok {
+		var labels []string
+		if group := stringValue(runsOnMap["group"]); group != "" {
+			labels = append(labels, "group:"+group)
+		}
+		return append(labels, stringList(runsOnMap["labels"])...)
+	}
+	return stringList(runsOn)
+}
+
+// parsePermissions extracts GITHUB_TOKEN permissions, which are either a
+// single value such as "read-all" or a map of scopes to access levels. Scopes
+// are returned as sorted "scope: level" entries.
+func parsePermissions(permissions interface{}) []string {
+	switch v := permissions.(type) {
+	case string:
+		return []string{v}
+	case map[string]interface{}:
+		entries := []string{}
+		for scope, level := range v {
+			entries = append(entries, scope+": "+stringValue(level))
+		}
+		sort.Strings(entries)
+		return entries
+	}
+	return nil
+}
+
+// parseWith extracts the inputs of a step.
+func parseWith(with interface{}) map[string]string {
+	withMap, ok := with.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	inputs := make(map[string]string)
+	for name, value := range withMap {
+		inputs[name] = stringValue(value)
+	}
+	return inputs
+}
+
+// stringValue converts a YAML scalar into a string, or "" if it is absent.
+func stringValue(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	if str, ok := value.(string); ok {
+		return str
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,136 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// PageName returns the filename of the documentation page generated for the
+// workflow file filename, e.g. "ci.md" for "ci.yml" or "ci.yml.disabled".
+func PageName(filename string) string {
+	filename = strings.TrimSuffix(filename, DisabledSuffix)
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ".md"
+}
+
+// PagePath returns the path of the documentation page generated for
+// workflow, relative to the pages directory and with forward slashes.
+// Workflows of several directories get their pages in a subdirectory per
+// workflows directory.
+func PagePath(workflow WorkflowInfo) string {
+	if workflow.Dir == "" {
+		return PageName(workflow.Filename)
+	}
+
+	// Keep apart the pages of workflows of several directories
+	dir := filepath.Clean(workflow.Dir)
+	if !filepath.IsLocal(dir) {
+		dir = filepath.Base(dir)
+	}
+	return path.Join(filepath.ToSlash(dir), PageName(workflow.Filename))
+}
+
+// GeneratePages writes one markdown page per workflow into pagesDir, creating
+// the directory if necessary.
+func GeneratePages(workflows []WorkflowInfo, workflowsDir string, pagesDir string) error {
+	return generatePages(workflows, Options{WorkflowsDir: workflowsDir, PagesDir: pagesDir})
+}
+
+// generatePages writes one markdown page per workflow into opts.PagesDir,
+// linking to the workflow files as configured by opts.
+func generatePages(workflows []WorkflowInfo, opts Options) error {
+	if opts.DryRun == nil {
+		err := os.MkdirAll(opts.PagesDir, 0755)
+		if err != nil {
+			return outputError(fmt.Errorf("error creating pages directory: %v", err))
+		}
+	}
+
+	// Calls between all workflows are resolved first, so that every page
+	// links to the pages of its callers and callees
+	resolved := resolveCalls(workflows, opts)
+	for _, workflow := range workflows {
+		pagePath := filepath.Join(opts.PagesDir, filepath.FromSlash(PagePath(workflow)))
+		page := generatePage(workflow, opts, pagePath, resolved)
+		if opts.DryRun != nil {
+			opts.DryRun(pagePath, []byte(page))
+			continue
+		}
+
+		// Workflows of subdirectories get their pages in subdirectories
+		err := os.MkdirAll(filepath.Dir(pagePath), 0755)
+		if err != nil {
+			return outputError(fmt.Errorf("error creating pages directory: %v", err))
+		}
+		err = os.WriteFile(pagePath, []byte(page), 0644)
+		if err != nil {
+			return outputError(fmt.Errorf("error writing page for %s: %v", workflow.Filename, err))
+		}
+	}
+
+	return nil
+}
+
+// generatePage creates the markdown page for a single workflow, linking to
+// the pages of the workflows it calls and is called by in resolved.
+func generatePage(workflow WorkflowInfo, opts Options, pagePath string, resolved calls) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", workflow.Filename))
+
+	if workflow.Description != "" {
+		// Each description line becomes its own line on the page
+		sb.WriteString(strings.ReplaceAll(workflow.Description, "<br>", "\n") + "\n\n")
+	}
+
+	sb.WriteString("## " + opts.t("Triggers") + "\n\n")
+	if len(workflow.Triggers) == 0 {
+		sb.WriteString(opts.t("None") + "\n\n")
+	}
+	for _, trigger := range workflow.Triggers {
+		if hint := TriggerHint(trigger); opts.TriggerHints && hint != "" {
+			sb.WriteString(fmt.Sprintf("- `%s`: %s\n", trigger, opts.t(hint)))
+		} else {
+			sb.WriteString(fmt.Sprintf("- `%s`\n", trigger))
+		}
+	}
+	if len(workflow.Triggers) > 0 {
+		sb.WriteString("\n")
+	}
+
+	if notes := SecurityNotes(workflow); opts.Security && len(notes) > 0 {
+		sb.WriteString("## " + opts.t("Security notes") + "\n\n")
+		for _, note := range notes {
+			sb.WriteString("- " + note + "\n")
+		}
+		sb.WriteString("\n")
+	}
+
+	writeCalls(&sb, opts.t("Calls"), workflow, resolved.callees[workflowKey(workflow)], opts)
+	writeCalls(&sb, opts.t("Called by"), workflow, resolved.callers[workflowKey(workflow)], opts)
+
+	sb.WriteString(fmt.Sprintf("%s: [%s](%s)\n", opts.t("Source"), workflow.Filename, opts.link(workflow, pagePath)))
+
+	return sb.String()
+}
+
+// writeCalls writes a section titled heading listing workflows, linked to
+// their pages relative to the page of workflow, if there are any.
+func writeCalls(sb *strings.Builder, heading string, workflow WorkflowInfo, workflows []WorkflowInfo, opts Options) {
+	if len(workflows) == 0 {
+		return
+	}
+
+	sb.WriteString("## " + heading + "\n\n")
+	for _, other := range workflows {
+		link, err := filepath.Rel(filepath.Dir(filepath.FromSlash(PagePath(workflow))), filepath.FromSlash(PagePath(other)))
+		if err != nil {
+			sb.WriteString(fmt.Sprintf("- %s\n", opts.displayName(other)))
+			continue
+		}
+		sb.WriteString(fmt.Sprintf("- [%s](%s)\n", opts.displayName(other), filepath.ToSlash(link)))
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,84 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestPageName tests deriving page filenames from workflow filenames
+func TestPageName(t *testing.T) {
+	testCases := map[string]string{
+		"ci.yml":             "ci.md",
+		"release.yaml":       "release.md",
+		"build.and.push.yml": "build.and.push.md",
+		"ci.yml.disabled":    "ci.md",
+	}
+
+	for filename, expected := range testCases {
+		if actual := PageName(filename); actual != expected {
+			t.Errorf("PageName(%q) = %q, expected %q", filename, actual, expected)
+		}
+	}
+}
+
+// TestPagePath tests the paths of the pages of workflows of one or several
+// directories
+func TestPagePath(t *testing.T) {
+	testCases := []struct {
+		workflow WorkflowInfo
+		expected string
+	}{
+		{WorkflowInfo{Filename: "ci.yml"}, "ci.md"},
+		{WorkflowInfo{Filename: "ci.yml", Dir: ".github/workflows"}, ".github/workflows/ci.md"},
+		{WorkflowInfo{Filename: "release.yaml.disabled", Dir: "services/api/workflows"}, "services/api/workflows/release.md"},
+		{WorkflowInfo{Filename: "ci.yml", Dir: "../shared"}, "shared/ci.md"},
+	}
+
+	for _, tc := range testCases {
+		if actual := PagePath(tc.workflow); actual != tc.expected {
+			t.Errorf("PagePath(%+v) = %q, expected %q", tc.workflow, actual, tc.expected)
+		}
+	}
+}
+
+// TestGeneratePages tests writing one page per workflow
+func TestGeneratePages(t *testing.T) {
+	tempDir := createTempDir(t, "pages")
+	workflowsDir := filepath.Join(tempDir, ".github", "workflows")
+	pagesDir := filepath.Join(tempDir, "docs", "workflows")
+
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs CI.<br>On every push.", Triggers: []string{"pull_request", "push"}},
+		{Filename: "manual.yaml", Description: "", Triggers: []string{}},
+	}
+
+	err := GeneratePages(workflows, workflowsDir, pagesDir)
+	if err != nil {
+		t.Fatalf("GeneratePages failed: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(pagesDir, "ci.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+
+	expected := "# ci.yml\n\n" +
+		"Runs CI.\nOn every push.\n\n" +
+		"## Triggers\n\n" +
+		"- `pull_request`\n" +
+		"- `push`\n\n" +
+		"Source: [ci.yml](../../.github/workflows/ci.yml)\n"
+	if string(content) != expected {
+		t.Errorf("Expected page:\n%s\ngot:\n%s", expected, content)
+	}
+
+	content, err = os.ReadFile(filepath.Join(pagesDir, "manual.md"))
+	if err != nil {
+		t.Fatalf("Failed to read page: %v", err)
+	}
+	if !strings.Contains(string(content), "## Triggers\n\nNone\n") {
+		t.Errorf("Expected page without triggers to say so, got:\n%s", content)
+	}
+}
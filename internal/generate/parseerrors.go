@@ -0,0 +1,77 @@
+package generate
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ParseError records a workflow file that failed to parse.
+type ParseError struct {
+	Filename string // Path of the file relative to its workflows directory
+	Dir      string // Workflows directory of the file when several are documented together
+	Err      error
+}
+
+// Error implements the error interface.
+func (e ParseError) Error() string {
+	return fmt.Sprintf("error parsing workflow file %s: %v", e.path(), e.Err)
+}
+
+// Unwrap returns the error the file failed to parse with.
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// Is reports whether target is ErrParse.
+func (e ParseError) Is(target error) bool {
+	return target == ErrParse
+}
+
+// path returns the filename of the file, prefixed by its directory when
+// several are documented together.
+func (e ParseError) path() string {
+	if e.Dir == "" {
+		return e.Filename
+	}
+	return path.Join(filepath.ToSlash(e.Dir), e.Filename)
+}
+
+// Warning is a problem that did not stop the documentation from being
+// generated, such as a workflow file skipped because it failed to parse.
+type Warning struct {
+	File    string `json:"file,omitempty"` // Workflow file the warning is about
+	Message string `json:"message"`
+}
+
+// String returns the warning prefixed by its file.
+func (w Warning) String() string {
+	if w.File == "" {
+		return w.Message
+	}
+	return w.File + ": " + w.Message
+}
+
+// warnings returns the warnings about the workflow files skipped because
+// they failed to parse.
+func warnings(parseErrors []ParseError) []Warning {
+	var warnings []Warning
+	for _, parseError := range parseErrors {
+		warnings = append(warnings, Warning{File: parseError.path(), Message: "skipped: " + parseError.Err.Error()})
+	}
+	return warnings
+}
+
+// writeParseErrors writes a section listing the workflow files skipped
+// because they failed to parse, headed in the language of opts.
+func writeParseErrors(sb *strings.Builder, parseErrors []ParseError, opts Options) {
+	if len(parseErrors) == 0 {
+		return
+	}
+
+	sb.WriteString("\n## " + opts.t("Parse errors") + "\n\n")
+	for _, parseError := range parseErrors {
+		sb.WriteString(fmt.Sprintf("- `%s`: %s\n", parseError.path(), escapeCell(parseError.Err.Error())))
+	}
+}
@@ -0,0 +1,74 @@
+package generate
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseErrors tests returning warnings about the skipped workflow files
+// and listing them below the table, and failing the scan in strict mode
+func TestParseErrors(t *testing.T) {
+	tempDir := createTempDir(t, "parse-errors")
+	createTempWorkflowFile(t, tempDir, "ci.yml", "## Runs CI.\non: push\n")
+	createTempWorkflowFile(t, tempDir, "broken.yml", "## Broken.\non: [push\n")
+
+	output := filepath.Join(tempDir, "workflows.md")
+	warnings, err := GenerateWithOptions(Options{WorkflowsDir: tempDir, Output: output})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	if len(warnings) != 1 || warnings[0].File != "broken.yml" || !strings.HasPrefix(warnings[0].Message, "skipped: ") {
+		t.Errorf("Expected a warning about broken.yml, got %v", warnings)
+	}
+	content, err := os.ReadFile(output)
+	if err != nil {
+		t.Fatalf("Failed to read output: %v", err)
+	}
+	if !strings.Contains(string(content), "[ci.yml](ci.yml)") {
+		t.Errorf("Expected ci.yml in the table, got:\n%s", content)
+	}
+	if !strings.Contains(string(content), "\n## Parse errors\n\n- `broken.yml`: ") {
+		t.Errorf("Expected broken.yml in a Parse errors section, got:\n%s", content)
+	}
+
+	// The section can be left out, and JSON output lists the warnings
+	hidden, err := Render(Options{WorkflowsDir: tempDir, HideParseErrors: true})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if strings.Contains(hidden, "Parse errors") {
+		t.Errorf("Expected no Parse errors section, got:\n%s", hidden)
+	}
+	document, err := Render(Options{WorkflowsDir: tempDir, Format: FormatJSON})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(document, `"file": "broken.yml"`) {
+		t.Errorf("Expected a warning about broken.yml in the JSON output, got:\n%s", document)
+	}
+
+	_, err = ScanDirWithOptions(tempDir, ScanOptions{Strict: true})
+	var parseError ParseError
+	if !errors.As(err, &parseError) || parseError.Filename != "broken.yml" {
+		t.Errorf("Expected a parse error for broken.yml in strict mode, got %v", err)
+	}
+
+	// Without parse errors there is no section
+	workflows, parseErrors, err := ScanDirWithErrors(tempDir, ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanDirWithErrors failed: %v", err)
+	}
+	if len(parseErrors) != 1 || parseErrors[0].Filename != "broken.yml" {
+		t.Errorf("Expected a parse error for broken.yml, got %v", parseErrors)
+	}
+	table, err := generateMarkdownTable(workflows, Options{})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+	if strings.Contains(table, "Parse errors") {
+		t.Errorf("Expected no Parse errors section, got:\n%s", table)
+	}
+}
@@ -0,0 +1,45 @@
+package generate
+
+import (
+	"github.com/droctothorpe/gha-docs/internal/pathmatch"
+)
+
+// pathTriggers are the triggers that fire based on the files a change touches.
+var pathTriggers = []string{"pull_request", "pull_request_target", "push"}
+
+// TriggersForPath returns the triggers of the workflow that would fire for a
+// change to the file at path (relative to the repository root), taking
+// `paths` and `paths-ignore` filters into account.
+func (w WorkflowInfo) TriggersForPath(path string) []string {
+	var triggers []string
+	for _, trigger := range pathTriggers {
+		if !w.HasTrigger(trigger) {
+			continue
+		}
+
+		filter := w.Filters[trigger]
+		switch {
+		case len(filter.Paths) > 0:
+			if pathmatch.MatchFilters(filter.Paths, path) {
+				triggers = append(triggers, trigger)
+			}
+		case len(filter.PathsIgnore) > 0:
+			if !pathmatch.MatchFilters(filter.PathsIgnore, path) {
+				triggers = append(triggers, trigger)
+			}
+		default:
+			triggers = append(triggers, trigger)
+		}
+	}
+	return triggers
+}
+
+// HasTrigger reports whether the workflow is triggered by the event.
+func (w WorkflowInfo) HasTrigger(event string) bool {
+	for _, trigger := range w.Triggers {
+		if trigger == event {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseTriggerFilters tests extraction of trigger filters
+func TestParseTriggerFilters(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`on:
+  push:
+    branches: [main]
+    paths-ignore: ['**/*.md']
+  pull_request:
+    paths:
+      - 'backend/**'
+      - '!**/OWNERS'
+  workflow_dispatch:
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	expected := map[string]TriggerFilter{
+		"push":         {Branches: []string{"main"}, PathsIgnore: []string{"**/*.md"}},
+		"pull_request": {Paths: []string{"backend/**", "!**/OWNERS"}},
+	}
+	if !reflect.DeepEqual(workflow.Filters, expected) {
+		t.Errorf("Expected filters %+v, got %+v", expected, workflow.Filters)
+	}
+}
+
+// TestTriggersForPath tests which triggers fire for a changed file
+func TestTriggersForPath(t *testing.T) {
+	workflow := WorkflowInfo{
+		Triggers: []string{"pull_request", "push", "schedule"},
+		Filters: map[string]TriggerFilter{
+			"push":         {PathsIgnore: []string{"**/*.md"}},
+			"pull_request": {Paths: []string{"backend/**", "!**/OWNERS"}},
+		},
+	}
+
+	testCases := map[string][]string{
+		"backend/main.go":   {"pull_request", "push"},
+		"backend/README.md": {"pull_request"},
+		"backend/OWNERS":    {"push"},
+		"docs/index.md":     nil,
+	}
+
+	for path, expected := range testCases {
+		if actual := workflow.TriggersForPath(path); !reflect.DeepEqual(actual, expected) {
+			t.Errorf("TriggersForPath(%q) = %v, expected %v", path, actual, expected)
+		}
+	}
+
+	unfiltered := WorkflowInfo{Triggers: []string{"pull_request_target"}}
+	if actual := unfiltered.TriggersForPath("anything.go"); !reflect.DeepEqual(actual, []string{"pull_request_target"}) {
+		t.Errorf("Expected unfiltered trigger to fire, got %v", actual)
+	}
+}
@@ -0,0 +1,34 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// provenance returns the banner added to the top of generated documentation
+// with Options.Provenance: an HTML comment naming the tool and its version,
+// the time of generation unless opts.Timestamp is zero or the output is
+// reproducible, and the source of the
+// workflows, with a warning not to edit the file by hand.
+func provenance(opts Options) string {
+	var sb strings.Builder
+
+	sb.WriteString("<!--\n")
+	generated := "Generated by gha-docs"
+	if opts.Version != "" {
+		generated += " " + opts.Version
+	}
+	if !opts.Timestamp.IsZero() && !opts.Reproducible {
+		generated += " on " + opts.Timestamp.UTC().Format(time.RFC3339)
+	}
+	source := opts.source()
+	if opts.Scan != nil && opts.RepoURL != "" {
+		source = fmt.Sprintf("%s of %s", source, opts.RepoURL)
+	}
+	sb.WriteString(fmt.Sprintf("  %s from %s.\n", generated, source))
+	sb.WriteString("  DO NOT EDIT BY HAND: changes are overwritten when the documentation is regenerated.\n")
+	sb.WriteString("-->\n")
+
+	return sb.String()
+}
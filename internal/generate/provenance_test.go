@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestProvenance tests the provenance banner with and without a timestamp
+func TestProvenance(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Triggers: []string{"push"}}}
+	opts := Options{
+		WorkflowsDir: ".github/workflows",
+		Header:       "Intro.",
+		Provenance:   true,
+		Version:      "v1.2.3",
+		Timestamp:    time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+	}
+
+	content, err := render(context.Background(), workflows, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	expected := "<!--\n  Generated by gha-docs v1.2.3 on 2026-01-02T03:04:05Z from .github/workflows.\n" +
+		"  DO NOT EDIT BY HAND: changes are overwritten when the documentation is regenerated.\n-->\n\nIntro.\n\n# GitHub Workflows Summary\n"
+	if !strings.HasPrefix(content, expected) {
+		t.Errorf("Expected the banner above the header, got:\n%s", content)
+	}
+
+	opts.Timestamp = time.Time{}
+	first, _ := render(context.Background(), workflows, opts)
+	second, _ := render(context.Background(), workflows, opts)
+	if strings.Contains(first, " on ") || first != second {
+		t.Errorf("Expected identical output without a timestamp, got:\n%s", first)
+	}
+
+	opts.Format = FormatCSV
+	if _, err := render(context.Background(), workflows, opts); err == nil {
+		t.Error("Expected an error adding provenance to CSV output")
+	}
+}
+
+// TestReproducible tests output that is identical on any system
+func TestReproducible(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Triggers: []string{"push"}}}
+	opts := Options{
+		WorkflowsDir: ".github/workflows",
+		Header:       "Intro\r\nsecond line\r\n",
+		Provenance:   true,
+		Timestamp:    time.Now(),
+		Reproducible: true,
+	}
+
+	content, err := render(context.Background(), workflows, opts)
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if strings.Contains(content, " on ") || strings.Contains(content, "\r") {
+		t.Errorf("Expected no timestamp and no CRLF line endings, got:\n%q", content)
+	}
+}
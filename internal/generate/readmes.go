@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ReadmeName is the name of the per-directory READMEs written with
+// Options.DirReadmes.
+const ReadmeName = "README.md"
+
+// writeDirReadmes writes a README documenting the workflows of each directory
+// into that directory. Existing READMEs keep their content: the documentation
+// replaces the content between InjectStart and InjectEnd, or is appended
+// between them if the README has no markers yet.
+func writeDirReadmes(ctx context.Context, workflows []WorkflowInfo, opts Options) error {
+	var dirs []string
+	byDir := make(map[string][]WorkflowInfo)
+	for _, workflow := range workflows {
+		dir := path.Join(filepath.ToSlash(opts.workflowsDir(workflow)), path.Dir(workflow.Filename))
+		if _, ok := byDir[dir]; !ok {
+			dirs = append(dirs, dir)
+		}
+		// Workflows are documented relative to their own directory
+		workflow.Filename = path.Base(workflow.Filename)
+		workflow.Dir = ""
+		byDir[dir] = append(byDir[dir], workflow)
+	}
+
+	for _, dir := range dirs {
+		dirOpts := opts
+		dirOpts.WorkflowsDir = filepath.FromSlash(dir)
+		dirOpts.WorkflowsDirs = nil
+		dirOpts.Output = filepath.Join(dirOpts.WorkflowsDir, ReadmeName)
+		if dirOpts.GroupBy == GroupByDirectory {
+			dirOpts.GroupBy = ""
+		}
+
+		content, err := render(ctx, byDir[dir], dirOpts)
+		if err != nil {
+			return err
+		}
+		if err := dirOpts.writeReadme(dirOpts.Output, content); err != nil {
+			return err
+		}
+		if dirOpts.DryRun == nil {
+			slog.Info("Successfully generated", "path", dirOpts.Output)
+		}
+	}
+	return nil
+}
+
+// writeReadme writes content to the README at readmePath, injecting it
+// between markers if the README exists.
+func (opts Options) writeReadme(readmePath, content string) error {
+	existing, err := os.ReadFile(readmePath)
+	if os.IsNotExist(err) {
+		return opts.writeFile(readmePath, content)
+	}
+	if err != nil {
+		return fmt.Errorf("error reading %s: %v", readmePath, err)
+	}
+
+	if strings.Contains(string(existing), InjectStart) {
+		return opts.injectFile(readmePath, content)
+	}
+
+	document := strings.TrimRight(string(existing), "\n") + "\n\n" + InjectStart + "\n" + InjectEnd + "\n"
+	injected, err := inject(document, content)
+	if err != nil {
+		return err
+	}
+	return opts.writeFile(readmePath, injected)
+}
+
+// writeFile writes content to the file at filePath.
+func (opts Options) writeFile(filePath, content string) error {
+	if opts.DryRun != nil {
+		opts.DryRun(filePath, []byte(content))
+		return nil
+	}
+	err := os.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		return outputError(fmt.Errorf("error writing %s: %v", filePath, err))
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestDirReadmes tests writing a README into each scanned directory
+func TestDirReadmes(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"ci.yml":             "## Runs CI.\non: push\njobs: {}\n",
+		"reusable/build.yml": "on: workflow_call\njobs: {}\n",
+		"reusable/README.md": "# Reusable workflows\n\nCall these from other workflows.\n",
+		"team/deploy.yml":    "on: workflow_dispatch\njobs: {}\n",
+		"team/README.md":     "# Team\n\n<!-- ghadoc:start -->\nstale\n<!-- ghadoc:end -->\n\nContact us.\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	opts := Options{
+		WorkflowsDir: root,
+		Output:       filepath.Join(t.TempDir(), "workflows.md"),
+		DirReadmes:   true,
+		ScanOptions:  ScanOptions{Recursive: true},
+	}
+	if _, err := GenerateWithOptions(opts); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	for name, expected := range map[string][]string{
+		"README.md":          {"# GitHub Workflows Summary\n", "| [ci.yml](ci.yml) | Runs CI. | push |\n"},
+		"reusable/README.md": {"Call these from other workflows.\n\n<!-- ghadoc:start -->\n# GitHub Workflows Summary", "| [build.yml](build.yml) |"},
+		"team/README.md":     {"<!-- ghadoc:start -->\n# GitHub Workflows Summary", "| [deploy.yml](deploy.yml) |", "<!-- ghadoc:end -->\n\nContact us.\n"},
+	} {
+		content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(name)))
+		if err != nil {
+			t.Fatalf("Failed to read %s: %v", name, err)
+		}
+		for _, text := range expected {
+			if !strings.Contains(string(content), text) {
+				t.Errorf("Expected %s to contain %q, got:\n%s", name, text, content)
+			}
+		}
+		if name != "README.md" && strings.Contains(string(content), "ci.yml") {
+			t.Errorf("Expected %s to document only its own workflows, got:\n%s", name, content)
+		}
+	}
+
+	// Generating again leaves the READMEs unchanged
+	before, _ := os.ReadFile(filepath.Join(root, "reusable", "README.md"))
+	if _, err := GenerateWithOptions(opts); err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+	after, _ := os.ReadFile(filepath.Join(root, "reusable", "README.md"))
+	if string(before) != string(after) {
+		t.Errorf("Expected regenerating to be idempotent, got:\n%s", after)
+	}
+}
@@ -0,0 +1,128 @@
+package generate
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Renderer renders workflows in an output format. Register renderers with
+// RegisterRenderer to add output formats selected by Options.Format.
+type Renderer interface {
+	// Name returns the output format, e.g. "markdown".
+	Name() string
+
+	// Render renders workflows as configured by opts.
+	Render(workflows []WorkflowInfo, opts Options) ([]byte, error)
+}
+
+var (
+	renderersMu sync.RWMutex
+	renderers   = make(map[string]Renderer)
+)
+
+func init() {
+	RegisterRenderer(builtinRenderer{FormatMarkdown, func(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+		content, err := generateMarkdownTable(workflows, opts)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, content)
+		return err
+	}})
+	RegisterRenderer(builtinRenderer{FormatHTML, func(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+		content, err := generateHTMLWidget(workflows, opts)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, content)
+		return err
+	}})
+	RegisterRenderer(builtinRenderer{FormatCSV, func(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+		return writeCSV(w, workflows)
+	}})
+	RegisterRenderer(builtinRenderer{FormatJSON, func(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+		return writeJSON(w, workflows, opts.source(), warnings(opts.ParseErrors))
+	}})
+}
+
+// RegisterRenderer makes renderer available as the output format of its
+// name, replacing any renderer registered under the same name. It panics if
+// renderer is nil or has no name.
+func RegisterRenderer(renderer Renderer) {
+	if renderer == nil || renderer.Name() == "" {
+		panic("generate: RegisterRenderer called with a nil or unnamed renderer")
+	}
+
+	renderersMu.Lock()
+	defer renderersMu.Unlock()
+	renderers[renderer.Name()] = renderer
+}
+
+// LookupRenderer returns the renderer registered for the output format
+// name, if any.
+func LookupRenderer(name string) (Renderer, bool) {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+	renderer, ok := renderers[name]
+	return renderer, ok
+}
+
+// Formats returns the registered output formats, sorted.
+func Formats() []string {
+	renderersMu.RLock()
+	defer renderersMu.RUnlock()
+
+	formats := make([]string, 0, len(renderers))
+	for name := range renderers {
+		formats = append(formats, name)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// builtinRenderer is a Renderer of the built-in formats, which also stream
+// their output.
+type builtinRenderer struct {
+	name  string
+	write func(w io.Writer, workflows []WorkflowInfo, opts Options) error
+}
+
+// Name implements Renderer.
+func (r builtinRenderer) Name() string {
+	return r.name
+}
+
+// Render implements Renderer.
+func (r builtinRenderer) Render(workflows []WorkflowInfo, opts Options) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := r.write(&buf, workflows, opts); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// renderFormat renders workflows to w with the renderer of opts.Format.
+func renderFormat(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	format := opts.Format
+	if format == "" {
+		format = FormatMarkdown
+	}
+	renderer, ok := LookupRenderer(format)
+	if !ok {
+		return fmt.Errorf("unsupported output format %q, expected one of %s", opts.Format, strings.Join(Formats(), ", "))
+	}
+
+	if builtin, ok := renderer.(builtinRenderer); ok {
+		return builtin.write(w, workflows, opts)
+	}
+	content, err := renderer.Render(workflows, opts)
+	if err != nil {
+		return fmt.Errorf("error rendering %s: %v", format, err)
+	}
+	_, err = w.Write(content)
+	return err
+}
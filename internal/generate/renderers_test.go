@@ -0,0 +1,62 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// lineRenderer renders the filename of every workflow on a line
+type lineRenderer struct{}
+
+func (lineRenderer) Name() string { return "lines" }
+
+func (lineRenderer) Render(workflows []WorkflowInfo, opts Options) ([]byte, error) {
+	var sb strings.Builder
+	for _, workflow := range workflows {
+		if workflow.Filename == "" {
+			return nil, fmt.Errorf("workflow without filename")
+		}
+		sb.WriteString(workflow.Filename + "\n")
+	}
+	return []byte(sb.String()), nil
+}
+
+// TestRegisterRenderer tests adding an output format with a renderer
+func TestRegisterRenderer(t *testing.T) {
+	for _, format := range []string{FormatCSV, FormatHTML, FormatJSON, FormatMarkdown} {
+		if _, ok := LookupRenderer(format); !ok {
+			t.Errorf("Expected a built-in renderer for %s", format)
+		}
+	}
+
+	workflows := []WorkflowInfo{{Filename: "ci.yml"}, {Filename: "deploy.yml"}}
+	_, err := render(context.Background(), workflows, Options{Format: "lines"})
+	if err == nil || !strings.Contains(err.Error(), "expected one of csv, html, json, markdown") {
+		t.Errorf("Expected an unsupported format error listing the formats, got %v", err)
+	}
+
+	RegisterRenderer(lineRenderer{})
+	defer func() {
+		renderersMu.Lock()
+		delete(renderers, "lines")
+		renderersMu.Unlock()
+	}()
+
+	content, err := render(context.Background(), workflows, Options{Format: "lines"})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if content != "ci.yml\ndeploy.yml\n" {
+		t.Errorf("Unexpected output:\n%s", content)
+	}
+	if !strings.Contains(strings.Join(Formats(), ","), "lines") {
+		t.Errorf("Expected lines among the formats, got %v", Formats())
+	}
+
+	_, err = render(context.Background(), []WorkflowInfo{{}}, Options{Format: "lines"})
+	if err == nil || !strings.Contains(err.Error(), "error rendering lines: workflow without filename") {
+		t.Errorf("Expected the error of the renderer, got %v", err)
+	}
+}
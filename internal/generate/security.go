@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// untrustedRefs are expressions that resolve to the head of a pull request.
+var untrustedRefs = []string{"github.event.pull_request.head", "github.head_ref"}
+
+// SecurityNotes returns notes on secret availability and GITHUB_TOKEN write
+// access for workflows that run on pull requests from forks or on events
+// they can cause.
+func SecurityNotes(workflow WorkflowInfo) []string {
+	var notes []string
+
+	if workflow.HasTrigger("pull_request_target") {
+		notes = append(notes, "`pull_request_target` runs in the context of the base repository for pull requests from forks, with access to "+secretsList(workflow)+".")
+		notes = append(notes, tokenNote(workflow))
+		if step := checkoutOfHead(workflow); step != "" {
+			notes = append(notes, fmt.Sprintf("**Warning:** %s checks out the pull request head, so untrusted code from forks runs with these privileges.", step))
+		}
+	}
+
+	if workflow.HasTrigger("pull_request") {
+		note := "`pull_request` runs for pull requests from forks without access to secrets other than `GITHUB_TOKEN`, and the `GITHUB_TOKEN` is read-only."
+		if secrets := userSecrets(workflow); len(secrets) > 0 {
+			note += fmt.Sprintf(" %s will be empty in those runs.", strings.Join(codeList(secrets), ", "))
+		}
+		notes = append(notes, note)
+	}
+
+	if workflow.HasTrigger("workflow_run") {
+		notes = append(notes, "`workflow_run` runs with access to "+secretsList(workflow)+" even when the triggering run came from a fork; treat its artifacts and outputs as untrusted.")
+		if !workflow.HasTrigger("pull_request_target") {
+			notes = append(notes, tokenNote(workflow))
+		}
+	}
+
+	return notes
+}
+
+// secretsList describes the secrets a privileged run has access to.
+func secretsList(workflow WorkflowInfo) string {
+	secrets := userSecrets(workflow)
+	if len(secrets) == 0 {
+		return "the repository's secrets"
+	}
+	return "the repository's secrets, including " + strings.Join(codeList(secrets), ", ")
+}
+
+// userSecrets returns the secrets referenced by the workflow other than
+// GITHUB_TOKEN.
+func userSecrets(workflow WorkflowInfo) []string {
+	var secrets []string
+	for _, secret := range workflow.Secrets {
+		if secret != "GITHUB_TOKEN" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
+
+// tokenNote describes the GITHUB_TOKEN permissions of a privileged run.
+func tokenNote(workflow WorkflowInfo) string {
+	var writes []string
+	for _, permission := range workflow.Permissions {
+		if isWrite(permission) {
+			writes = append(writes, permission)
+		}
+	}
+	for _, job := range workflow.Jobs {
+		for _, permission := range job.Permissions {
+			if isWrite(permission) {
+				writes = append(writes, fmt.Sprintf("%s (job `%s`)", permission, job.ID))
+			}
+		}
+	}
+	sort.Strings(writes)
+
+	switch {
+	case len(writes) > 0:
+		return "The `GITHUB_TOKEN` has write access: " + strings.Join(writes, ", ") + "."
+	case workflow.Permissions == nil:
+		return "The `GITHUB_TOKEN` has the repository's default permissions, which may include write access; declare `permissions` to restrict it."
+	default:
+		return "The `GITHUB_TOKEN` is restricted to read access."
+	}
+}
+
+// isWrite reports whether a permission entry grants write access.
+func isWrite(permission string) bool {
+	return permission == "write-all" || strings.HasSuffix(permission, ": write")
+}
+
+// checkoutOfHead returns a description of the first step that checks out the
+// head of the pull request, or "" if there is none.
+func checkoutOfHead(workflow WorkflowInfo) string {
+	for _, job := range workflow.Jobs {
+		for i, step := range job.Steps {
+			if !strings.HasPrefix(step.Uses, "actions/checkout@") {
+				continue
+			}
+			for _, ref := range untrustedRefs {
+				if strings.Contains(step.With["ref"], ref) || strings.Contains(step.With["repository"], ref) {
+					name := step.Name
+					if name == "" {
+						name = fmt.Sprintf("step %d", i+1)
+					}
+					return fmt.Sprintf("Job `%s` (%s)", job.ID, name)
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// codeList formats each item as inline code.
+func codeList(items []string) []string {
+	var formatted []string
+	for _, item := range items {
+		formatted = append(formatted, "`"+item+"`")
+	}
+	return formatted
+}
+
+// writeSecurityNotes writes a section with the security notes of every
+// workflow that has any, headed in the language of opts.
+func writeSecurityNotes(sb *strings.Builder, workflows []WorkflowInfo, opts Options) {
+	wroteHeading := false
+	for _, workflow := range workflows {
+		notes := SecurityNotes(workflow)
+		if len(notes) == 0 {
+			continue
+		}
+
+		if !wroteHeading {
+			sb.WriteString("\n## " + opts.t("Security notes") + "\n")
+			wroteHeading = true
+		}
+		sb.WriteString(fmt.Sprintf("\n### %s\n\n", workflow.Filename))
+		for _, note := range notes {
+			sb.WriteString("- " + note + "\n")
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package generate
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestSecurityNotes tests the notes for fork-triggered workflows
+func TestSecurityNotes(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte(`on:
+  pull_request_target:
+  pull_request:
+permissions:
+  contents: read
+  pull-requests: write
+jobs:
+  label:
+    runs-on: ubuntu-latest
+    steps:
+      - name: Check out PR
+        uses: actions/checkout@v4
+        with:
+          ref: ${{ github.event.pull_request.head.sha }}
+      - run: ./label.sh --token ${{ secrets.LABEL_TOKEN }} ${{ secrets.GITHUB_TOKEN }}
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+
+	expected := []string{
+		"`pull_request_target` runs in the context of the base repository for pull requests from forks, with access to the repository's secrets, including `LABEL_TOKEN`.",
+		"The `GITHUB_TOKEN` has write access: pull-requests: write.",
+		"**Warning:** Job `label` (Check out PR) checks out the pull request head, so untrusted code from forks runs with these privileges.",
+		"`pull_request` runs for pull requests from forks without access to secrets other than `GITHUB_TOKEN`, and the `GITHUB_TOKEN` is read-only. `LABEL_TOKEN` will be empty in those runs.",
+	}
+	if notes := SecurityNotes(workflow); !reflect.DeepEqual(notes, expected) {
+		t.Errorf("Expected notes:\n%s\nGot:\n%s", strings.Join(expected, "\n"), strings.Join(notes, "\n"))
+	}
+}
+
+// TestSecurityNotesTokenPermissions tests the GITHUB_TOKEN note
+func TestSecurityNotesTokenPermissions(t *testing.T) {
+	testCases := []struct {
+		workflow WorkflowInfo
+		expected string
+	}{
+		{
+			WorkflowInfo{Triggers: []string{"workflow_run"}},
+			"The `GITHUB_TOKEN` has the repository's default permissions, which may include write access; declare `permissions` to restrict it.",
+		},
+		{
+			WorkflowInfo{Triggers: []string{"workflow_run"}, Permissions: []string{}},
+			"The `GITHUB_TOKEN` is restricted to read access.",
+		},
+		{
+			WorkflowInfo{
+				Triggers:    []string{"workflow_run"},
+				Permissions: []string{"read-all"},
+				Jobs:        []JobInfo{{ID: "deploy", Permissions: []string{"deployments: write"}}},
+			},
+			"The `GITHUB_TOKEN` has write access: deployments: write (job `deploy`).",
+		},
+	}
+
+	for _, tc := range testCases {
+		notes := SecurityNotes(tc.workflow)
+		if len(notes) != 2 || notes[1] != tc.expected {
+			t.Errorf("Expected token note %q, got %v", tc.expected, notes)
+		}
+	}
+}
+
+// TestSecurityNotesInTable tests the security notes section below the table
+func TestSecurityNotesInTable(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "build.yml", Triggers: []string{"push"}},
+		{Filename: "ci.yml", Triggers: []string{"pull_request"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{Output: "workflows.md", Security: true})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	expected := "\n## Security notes\n\n### ci.yml\n\n- `pull_request` runs for pull requests from forks"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+	}
+	if strings.Contains(content, "### build.yml") {
+		t.Errorf("Expected no notes for build.yml, got:\n%s", content)
+	}
+}
@@ -0,0 +1,88 @@
+package generate
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported orders of the workflows.
+const (
+	SortName     = "name"     // Value of the top-level "name" field, or the filename
+	SortFilename = "filename" // Path of the workflow file
+	SortTrigger  = "trigger"  // First trigger, alphabetically
+	SortModified = "modified" // Modification time of the workflow file, oldest first
+)
+
+// Sorts are the supported values of Options.Sort.
+var Sorts = []string{SortName, SortFilename, SortTrigger, SortModified}
+
+// sortWorkflows sorts workflows in place by opts.Sort, in descending order
+// if opts.Desc is set. Workflows of several directories keep the order of
+// their directories in workflows, as given but after the directories of the
+// reusable workflows they call, and ties are broken by filename.
+func sortWorkflows(workflows []WorkflowInfo, opts Options) error {
+	if opts.Reproducible {
+		switch opts.Sort {
+		case "":
+			// Scanning order may differ between systems
+			opts.Sort = SortFilename
+		case SortModified:
+			return fmt.Errorf("sorting by modification time is not reproducible")
+		}
+	}
+
+	var compare func(a, b WorkflowInfo) int
+	switch opts.Sort {
+	case "":
+		return nil
+	case SortName:
+		compare = func(a, b WorkflowInfo) int { return strings.Compare(sortName(a), sortName(b)) }
+	case SortFilename:
+		compare = func(a, b WorkflowInfo) int { return 0 }
+	case SortTrigger:
+		compare = func(a, b WorkflowInfo) int {
+			// Workflows without triggers sort after the others
+			if len(a.Triggers) == 0 || len(b.Triggers) == 0 {
+				return len(b.Triggers) - len(a.Triggers)
+			}
+			return strings.Compare(a.Triggers[0], b.Triggers[0])
+		}
+	case SortModified:
+		compare = func(a, b WorkflowInfo) int { return a.modified.Compare(b.modified) }
+	default:
+		return fmt.Errorf("unsupported sort order %q", opts.Sort)
+	}
+
+	// Directories are ordered by ScanWorkflows, by their calls
+	dirIndex := make(map[string]int)
+	for _, workflow := range workflows {
+		if _, ok := dirIndex[workflow.Dir]; !ok {
+			dirIndex[workflow.Dir] = len(dirIndex)
+		}
+	}
+
+	sort.SliceStable(workflows, func(i, j int) bool {
+		a, b := workflows[i], workflows[j]
+		if a.Dir != b.Dir {
+			return dirIndex[a.Dir] < dirIndex[b.Dir]
+		}
+		result := compare(a, b)
+		if result == 0 {
+			result = strings.Compare(a.Filename, b.Filename)
+		}
+		if opts.Desc {
+			return result > 0
+		}
+		return result < 0
+	})
+	return nil
+}
+
+// sortName returns the name workflow is sorted by with SortName.
+func sortName(workflow WorkflowInfo) string {
+	if workflow.Name != "" {
+		return workflow.Name
+	}
+	return workflow.Filename
+}
@@ -0,0 +1,60 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// TestSortWorkflows tests the supported sort orders
+func TestSortWorkflows(t *testing.T) {
+	tempDir := createTempDir(t, "sort-test")
+	defer os.RemoveAll(tempDir)
+
+	createTempWorkflowFile(t, tempDir, "a.yml", "name: Zeta\non: schedule\njobs: {}\n")
+	createTempWorkflowFile(t, tempDir, "b.yml", "name: Alpha\non: [push, workflow_dispatch]\njobs: {}\n")
+	createTempWorkflowFile(t, tempDir, "c.yml", "jobs: {}\n")
+
+	now := time.Now()
+	for i, name := range []string{"b.yml", "c.yml", "a.yml"} {
+		modified := now.Add(time.Duration(i) * time.Hour)
+		if err := os.Chtimes(filepath.Join(tempDir, name), modified, modified); err != nil {
+			t.Fatalf("Failed to set modification time: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		sort     string
+		desc     bool
+		expected []string
+	}{
+		{"", false, []string{"a.yml", "b.yml", "c.yml"}},
+		{SortFilename, true, []string{"c.yml", "b.yml", "a.yml"}},
+		{SortName, false, []string{"b.yml", "a.yml", "c.yml"}},
+		{SortTrigger, false, []string{"b.yml", "a.yml", "c.yml"}},
+		{SortModified, false, []string{"b.yml", "c.yml", "a.yml"}},
+		{SortModified, true, []string{"a.yml", "c.yml", "b.yml"}},
+	} {
+		workflows, _, err := Options{WorkflowsDir: tempDir, Sort: test.sort, Desc: test.desc}.ScanWorkflows(context.Background())
+		if err != nil {
+			t.Fatalf("ScanWorkflows failed: %v", err)
+		}
+		var filenames []string
+		for _, workflow := range workflows {
+			filenames = append(filenames, workflow.Filename)
+		}
+		if !reflect.DeepEqual(filenames, test.expected) {
+			t.Errorf("Expected sort %q (desc %v) to give %v, got %v", test.sort, test.desc, test.expected, filenames)
+		}
+	}
+
+	if _, _, err := (Options{WorkflowsDir: tempDir, Sort: SortModified, Reproducible: true}).ScanWorkflows(context.Background()); err == nil {
+		t.Error("Expected error sorting reproducible output by modification time, got nil")
+	}
+	if _, _, err := (Options{WorkflowsDir: tempDir, Sort: "size"}).ScanWorkflows(context.Background()); err == nil {
+		t.Error("Expected error for unsupported sort order, got nil")
+	}
+}
@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"fmt"
+	"time"
+)
+
+// RunStatus is the outcome of the latest completed run of a workflow.
+type RunStatus struct {
+	Conclusion string    // e.g. success or failure; empty if the workflow has not run
+	RunAt      time.Time // When the latest run started
+}
+
+// statusCell formats the conclusion for the summary table.
+func (s RunStatus) statusCell() string {
+	if s.Conclusion == "" {
+		return "no runs"
+	}
+	return s.Conclusion
+}
+
+// lastRunCell formats the time of the latest run for the summary table.
+func (s RunStatus) lastRunCell() string {
+	if s.RunAt.IsZero() {
+		return ""
+	}
+	return s.RunAt.UTC().Format("2006-01-02 15:04 UTC")
+}
+
+// RunMetrics summarizes the recent completed runs of a workflow.
+type RunMetrics struct {
+	Runs           int           // Completed runs considered
+	Decided        int           // Runs that succeeded or failed; others do not count towards SuccessRate
+	SuccessRate    float64       // Fraction of the decided runs that succeeded
+	MedianDuration time.Duration // Median duration of the completed runs
+}
+
+// successRateCell formats the success rate for the summary table, with text
+// translated by t.
+func (m RunMetrics) successRateCell(t func(string) string) string {
+	if m.Runs == 0 {
+		return t("no runs")
+	}
+	if m.Decided == 0 {
+		return t("n/a")
+	}
+	return fmt.Sprintf(t("%.0f%% of %d"), m.SuccessRate*100, m.Decided)
+}
+
+// medianDurationCell formats the median duration for the summary table.
+func (m RunMetrics) medianDurationCell() string {
+	if m.Runs == 0 {
+		return ""
+	}
+	return m.MedianDuration.Round(time.Second).String()
+}
+
+// stateCell formats the state of a workflow in GitHub for the summary table.
+// Workflows GitHub does not know, for example because they have not been
+// pushed yet, have no state.
+func stateCell(state string) string {
+	switch state {
+	case "":
+		return "not on GitHub"
+	case "active":
+		return "active"
+	case "disabled_manually":
+		return "**disabled** (manually)"
+	case "disabled_inactivity":
+		return "**disabled** (inactivity)"
+	case "disabled_fork":
+		return "**disabled** (fork)"
+	default:
+		return state
+	}
+}
@@ -0,0 +1,97 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestStatusColumns tests the Status and Last Run columns
+func TestStatusColumns(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}},
+		{Filename: "new.yml", Triggers: []string{"workflow_dispatch"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{
+		Output: "workflows.md",
+		Status: map[string]RunStatus{
+			"ci.yml": {Conclusion: "failure", RunAt: time.Date(2025, 3, 1, 14, 30, 0, 0, time.UTC)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"| Filename | Description | Triggers | Status | Last Run |\n| --- | --- | --- | --- | --- |\n",
+		"| [ci.yml](ci.yml) | Runs CI. | push | failure | 2025-03-01 14:30 UTC |\n",
+		"| [new.yml](new.yml) |  | workflow_dispatch | no runs |  |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// TestMetricsColumns tests the Success Rate and Median Duration columns
+func TestMetricsColumns(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"push"}},
+		{Filename: "cancelled.yml", Triggers: []string{"push"}},
+		{Filename: "new.yml", Triggers: []string{"workflow_dispatch"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{
+		Output: "workflows.md",
+		Metrics: map[string]RunMetrics{
+			"ci.yml":        {Runs: 20, Decided: 18, SuccessRate: 0.8333, MedianDuration: 192500 * time.Millisecond},
+			"cancelled.yml": {Runs: 2, MedianDuration: time.Minute},
+		},
+	})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"| Filename | Description | Triggers | Success Rate | Median Duration |\n",
+		"| [ci.yml](ci.yml) |  | push | 83% of 18 | 3m13s |\n",
+		"| [cancelled.yml](cancelled.yml) |  | push | n/a | 1m0s |\n",
+		"| [new.yml](new.yml) |  | workflow_dispatch | no runs |  |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// TestStateColumn tests the State column
+func TestStateColumn(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"push"}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}},
+		{Filename: "new.yml", Triggers: []string{"workflow_dispatch"}},
+	}
+
+	content, err := generateMarkdownTable(workflows, Options{
+		Output: "workflows.md",
+		State: map[string]string{
+			"ci.yml":      "active",
+			"nightly.yml": "disabled_inactivity",
+		},
+	})
+	if err != nil {
+		t.Fatalf("generateMarkdownTable failed: %v", err)
+	}
+
+	for _, expected := range []string{
+		"| Filename | Description | Triggers | State |\n",
+		"| [ci.yml](ci.yml) |  | push | active |\n",
+		"| [nightly.yml](nightly.yml) |  | schedule | **disabled** (inactivity) |\n",
+		"| [new.yml](new.yml) |  | workflow_dispatch | not on GitHub |\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
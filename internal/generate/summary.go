@@ -0,0 +1,47 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+)
+
+// StepSummaryEnv is the environment variable GitHub Actions sets to the path
+// of the job summary file.
+const StepSummaryEnv = "GITHUB_STEP_SUMMARY"
+
+// writeStepSummary appends the markdown table of workflows to the job summary
+// file opts.StepSummary, whatever the format of the output. Relative links
+// do not work in job summaries, so unless opts links to a ref, links point to
+// the workflow files at the commit of the GitHub Actions run.
+func writeStepSummary(workflows []WorkflowInfo, opts Options) error {
+	if opts.Ref == "" {
+		server, repo, sha := os.Getenv("GITHUB_SERVER_URL"), os.Getenv("GITHUB_REPOSITORY"), os.Getenv("GITHUB_SHA")
+		if server != "" && repo != "" && sha != "" {
+			opts.RepoURL = server + "/" + repo
+			opts.Ref = sha
+		}
+	}
+
+	content, err := generateMarkdownTable(workflows, opts)
+	if err != nil {
+		return err
+	}
+
+	if opts.DryRun != nil {
+		opts.DryRun(opts.StepSummary, []byte(content+"\n"))
+		return nil
+	}
+
+	file, err := os.OpenFile(opts.StepSummary, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return outputError(fmt.Errorf("error opening job summary: %v", err))
+	}
+	_, err = file.WriteString(content + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return outputError(fmt.Errorf("error writing job summary: %v", err))
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestStepSummary tests appending the table to the job summary
+func TestStepSummary(t *testing.T) {
+	dir := t.TempDir()
+	workflowsDir := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflowsDir, 0755); err != nil {
+		t.Fatalf("Failed to create workflows dir: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(workflowsDir, "ci.yml"), []byte("## Runs CI.\non: push\n"), 0644); err != nil {
+		t.Fatalf("Failed to write workflow: %v", err)
+	}
+
+	summary := filepath.Join(dir, "summary.md")
+	if err := os.WriteFile(summary, []byte("Earlier step output\n"), 0644); err != nil {
+		t.Fatalf("Failed to write summary: %v", err)
+	}
+
+	t.Setenv("GITHUB_SERVER_URL", "https://github.com")
+	t.Setenv("GITHUB_REPOSITORY", "owner/repo")
+	t.Setenv("GITHUB_SHA", "0123abc")
+
+	_, err := GenerateWithOptions(Options{
+		WorkflowsDir: ".github/workflows",
+		Output:       filepath.Join(dir, "workflows.json"),
+		Format:       FormatJSON,
+		StepSummary:  summary,
+		Scan: func(string) ([]WorkflowInfo, []ParseError, error) {
+			return ScanDirWithErrors(workflowsDir, ScanOptions{})
+		},
+	})
+	if err != nil {
+		t.Fatalf("GenerateWithOptions failed: %v", err)
+	}
+
+	content, err := os.ReadFile(summary)
+	if err != nil {
+		t.Fatalf("Failed to read summary: %v", err)
+	}
+	for _, expected := range []string{
+		"Earlier step output\n# GitHub Workflows Summary\n",
+		"| [ci.yml](https://github.com/owner/repo/blob/0123abc/.github/workflows/ci.yml) | Runs CI. | push |",
+	} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("Expected summary to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
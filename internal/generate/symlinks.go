@@ -0,0 +1,73 @@
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Supported values of ScanOptions.Symlinks.
+const (
+	SymlinksFollow  = "follow"  // Document symlinked files and scan symlinked directories at their link
+	SymlinksResolve = "resolve" // Follow symlinks, linking to the files they point to
+	SymlinksSkip    = "skip"    // Ignore symlinked files and directories
+)
+
+// SymlinkPolicies are the supported values of ScanOptions.Symlinks.
+var SymlinkPolicies = []string{SymlinksFollow, SymlinksResolve, SymlinksSkip}
+
+// validateSymlinks reports an unsupported symlink policy.
+func validateSymlinks(policy string) error {
+	if policy != "" && !hasAny(SymlinkPolicies, []string{policy}) {
+		return fmt.Errorf("unsupported symlink policy %q, expected one of %s", policy, strings.Join(SymlinkPolicies, ", "))
+	}
+	return nil
+}
+
+// symlinkedDir reports whether the symlink at linkPath points to a
+// directory. Symlinks to dir or one of its parents are not, so that scanning
+// them recursively cannot loop.
+func symlinkedDir(dir, linkPath string) bool {
+	info, err := os.Stat(linkPath)
+	if err != nil || !info.IsDir() {
+		return false
+	}
+
+	target, err := filepath.EvalSymlinks(linkPath)
+	if err != nil {
+		return false
+	}
+	realDir, err := filepath.EvalSymlinks(dir)
+	if err != nil {
+		return false
+	}
+	target, _ = filepath.Abs(target)
+	realDir, _ = filepath.Abs(realDir)
+	return realDir != target && !strings.HasPrefix(realDir, target+string(filepath.Separator))
+}
+
+// resolvedPath returns the path of the file filePath in workflowsDir points
+// to through symlinks, relative to workflowsDir as given so that symlinks in
+// its own path don't change links, or an empty string if it is not a
+// symlink.
+func resolvedPath(workflowsDir, filePath string) string {
+	target, err := filepath.EvalSymlinks(filePath)
+	if err != nil {
+		return ""
+	}
+	realDir, err := filepath.EvalSymlinks(workflowsDir)
+	if err != nil {
+		return ""
+	}
+	target, _ = filepath.Abs(target)
+	realDir, _ = filepath.Abs(realDir)
+	relativePath, err := filepath.Rel(realDir, target)
+	if err != nil {
+		return ""
+	}
+	if resolved := filepath.Join(workflowsDir, relativePath); resolved != filePath {
+		return resolved
+	}
+	return ""
+}
@@ -0,0 +1,71 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// TestSymlinks tests following, resolving, and skipping symlinked workflow
+// files and directories
+func TestSymlinks(t *testing.T) {
+	root := t.TempDir()
+	shared := filepath.Join(root, "shared")
+	workflowsDir := filepath.Join(root, "workflows")
+	for _, dir := range []string{filepath.Join(shared, "nested"), workflowsDir} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			t.Fatalf("Failed to create %s: %v", dir, err)
+		}
+	}
+	createTempWorkflowFile(t, shared, "lint.yml", "on: push\njobs: {}\n")
+	createTempWorkflowFile(t, filepath.Join(shared, "nested"), "release.yml", "on: push\njobs: {}\n")
+	createTempWorkflowFile(t, workflowsDir, "ci.yml", "on: push\njobs: {}\n")
+	for link, target := range map[string]string{
+		"lint.yml": filepath.Join("..", "shared", "lint.yml"),
+		"shared":   filepath.Join("..", "shared", "nested"),
+		"loop":     ".",
+	} {
+		if err := os.Symlink(target, filepath.Join(workflowsDir, link)); err != nil {
+			t.Skipf("Symlinks are not supported: %v", err)
+		}
+	}
+
+	for _, test := range []struct {
+		symlinks string
+		expected []string
+	}{
+		{"", []string{"ci.yml", "lint.yml", "shared/release.yml"}},
+		{SymlinksResolve, []string{"ci.yml", "lint.yml", "shared/release.yml"}},
+		{SymlinksSkip, []string{"ci.yml"}},
+	} {
+		workflows, err := ScanDirWithOptions(workflowsDir, ScanOptions{Recursive: true, Symlinks: test.symlinks})
+		if err != nil {
+			t.Fatalf("ScanDirWithOptions failed: %v", err)
+		}
+		var filenames []string
+		for _, workflow := range workflows {
+			filenames = append(filenames, workflow.Filename)
+		}
+		if !reflect.DeepEqual(filenames, test.expected) {
+			t.Errorf("Expected %v with symlinks %q, got %v", test.expected, test.symlinks, filenames)
+		}
+	}
+
+	// Resolved symlinks are linked at the files they point to
+	workflows, err := ScanDirWithOptions(workflowsDir, ScanOptions{Recursive: true, Symlinks: SymlinksResolve})
+	if err != nil {
+		t.Fatalf("ScanDirWithOptions failed: %v", err)
+	}
+	output := filepath.Join(root, "workflows.md")
+	expected := []string{"workflows/ci.yml", "shared/lint.yml", "shared/nested/release.yml"}
+	for i, workflow := range workflows {
+		if link := workflowLink(workflow, workflowsDir, output); link != expected[i] {
+			t.Errorf("Expected link %q for %s, got %q", expected[i], workflow.Filename, link)
+		}
+	}
+
+	if _, err := ScanDirWithOptions(workflowsDir, ScanOptions{Symlinks: "copy"}); err == nil {
+		t.Error("Expected error for unsupported symlink policy, got nil")
+	}
+}
@@ -0,0 +1,120 @@
+package generate
+
+import (
+	"context"
+	"fmt"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Target is one of several documents generated in a single run, each with its
+// own destination and selection of workflows, e.g. the deploy workflows
+// injected into docs/deploys.md and all workflows into README.md. Unset
+// fields keep the value of the base options.
+type Target struct {
+	Output         string   `yaml:"output"` // File to write
+	Inject         string   `yaml:"inject"` // File to inject into between markers
+	Format         string   `yaml:"format"`
+	Template       string   `yaml:"template"`
+	Theme          string   `yaml:"theme"`
+	Columns        []Column `yaml:"columns"`
+	FilterTriggers []string `yaml:"filter-trigger"`
+	FilterTags     []string `yaml:"filter-tag"`
+	GroupBy        string   `yaml:"group-by"`
+	Sort           string   `yaml:"sort"`
+}
+
+// UnmarshalYAML decodes a column given as NAME=PATH, like the --columns flag.
+func (c *Column) UnmarshalYAML(value *yaml.Node) error {
+	var spec string
+	if err := value.Decode(&spec); err != nil {
+		return err
+	}
+	column, err := ParseColumn(spec)
+	if err != nil {
+		return err
+	}
+	*c = column
+	return nil
+}
+
+// MarshalYAML encodes a column as NAME=PATH.
+func (c Column) MarshalYAML() (interface{}, error) {
+	return c.Name + "=" + c.Path, nil
+}
+
+// options returns base with the settings of the target.
+func (target Target) options(base Options) Options {
+	opts := base
+	opts.Output, opts.Inject = target.Output, target.Inject
+	if target.Format != "" {
+		opts.Format = target.Format
+	}
+	if target.Template != "" {
+		opts.Template = target.Template
+	}
+	if target.Theme != "" {
+		opts.Theme = target.Theme
+	}
+	if target.Columns != nil {
+		opts.Columns = target.Columns
+	}
+	if target.FilterTriggers != nil {
+		opts.FilterTriggers = target.FilterTriggers
+	}
+	if target.FilterTags != nil {
+		opts.FilterTags = target.FilterTags
+	}
+	if target.GroupBy != "" {
+		opts.GroupBy = target.GroupBy
+	}
+	if target.Sort != "" {
+		opts.Sort = target.Sort
+	}
+	return opts
+}
+
+// GenerateTargets generates the document of every target, in order, with
+// the options of opts overridden by those of the target. The pages,
+// per-directory READMEs, and job summary of opts are written once, for all
+// workflows selected by opts. The warnings of every target are returned once.
+func GenerateTargets(ctx context.Context, opts Options, targets []Target) ([]Warning, error) {
+	if opts.DirReadmes && opts.Scan != nil {
+		return nil, fmt.Errorf("per-directory READMEs require local workflows directories")
+	}
+	for i, target := range targets {
+		if (target.Output == "") == (target.Inject == "") {
+			return nil, fmt.Errorf("target %d: set either output or inject", i+1)
+		}
+	}
+
+	var all []Warning
+	seen := make(map[Warning]bool)
+	for _, target := range targets {
+		targetOpts := target.options(opts)
+		targetOpts.PagesDir, targetOpts.StepSummary, targetOpts.DirReadmes = "", "", false
+		warnings, err := GenerateContext(ctx, targetOpts)
+		if err != nil {
+			return nil, err
+		}
+		for _, warning := range warnings {
+			if !seen[warning] {
+				seen[warning] = true
+				all = append(all, warning)
+			}
+		}
+	}
+
+	if opts.PagesDir == "" && opts.StepSummary == "" && !opts.DirReadmes {
+		return all, nil
+	}
+	if err := opts.loadLanguage(); err != nil {
+		return nil, err
+	}
+	workflows, parseErrors, err := opts.ScanWorkflows(ctx)
+	if err != nil {
+		return nil, err
+	}
+	opts.ParseErrors = append(opts.ParseErrors, parseErrors...)
+	return all, writeExtras(ctx, workflows, opts)
+}
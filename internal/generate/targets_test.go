@@ -0,0 +1,61 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+// TestGenerateTargets tests generating several documents in one run
+func TestGenerateTargets(t *testing.T) {
+	tempDir := createTempDir(t, "targets-test")
+	defer os.RemoveAll(tempDir)
+
+	createTempWorkflowFile(t, tempDir, "ci.yml", "## Runs CI.\non: push\njobs:\n  test:\n    runs-on: ubuntu-latest\n")
+	createTempWorkflowFile(t, tempDir, "deploy.yml", "## @tags deploy\non: workflow_dispatch\njobs:\n  deploy:\n    runs-on: self-hosted\n")
+
+	readme := filepath.Join(tempDir, "README.md")
+	if err := os.WriteFile(readme, []byte("<!-- ghadoc:start -->\n<!-- ghadoc:end -->\n"), 0644); err != nil {
+		t.Fatalf("Failed to write README: %v", err)
+	}
+	deploys := filepath.Join(tempDir, "deploys.md")
+
+	var targets []Target
+	err := yaml.Unmarshal([]byte(`
+- output: `+deploys+`
+  filter-tag: [deploy]
+  columns: [Runners=jobs.*.runs-on]
+- inject: `+readme+`
+`), &targets)
+	if err != nil {
+		t.Fatalf("Failed to decode targets: %v", err)
+	}
+
+	if _, err := GenerateTargets(context.Background(), Options{WorkflowsDir: tempDir, Output: filepath.Join(tempDir, "unused.md")}, targets); err != nil {
+		t.Fatalf("GenerateTargets failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(deploys)
+	if !strings.Contains(string(content), "| Runners |") || !strings.Contains(string(content), "deploy.yml") || strings.Contains(string(content), "ci.yml") {
+		t.Errorf("Expected the deploy workflows with a Runners column, got:\n%s", content)
+	}
+	content, _ = os.ReadFile(readme)
+	if !strings.Contains(string(content), "ci.yml") || !strings.Contains(string(content), "deploy.yml") || strings.Contains(string(content), "Runners") {
+		t.Errorf("Expected all workflows in the README, got:\n%s", content)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "unused.md")); !os.IsNotExist(err) {
+		t.Error("Expected the output of the base options not to be written")
+	}
+
+	_, err = GenerateTargets(context.Background(), Options{WorkflowsDir: tempDir}, []Target{{Output: deploys, Inject: readme}})
+	if err == nil {
+		t.Error("Expected error for a target with both output and inject, got nil")
+	}
+	if err := yaml.Unmarshal([]byte("- columns: [Runners]\n"), &targets); err == nil {
+		t.Error("Expected error for an invalid column, got nil")
+	}
+}
@@ -0,0 +1,80 @@
+package generate
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"text/template"
+
+	"github.com/Masterminds/sprig/v3"
+)
+
+// TemplateData is the data a custom template is executed with.
+type TemplateData struct {
+	Workflows []WorkflowInfo
+	RepoURL   string
+	Ref       string
+	Status    map[string]RunStatus  // Latest run of each workflow, keyed by filename; nil unless requested
+	Metrics   map[string]RunMetrics // Recent run metrics, keyed by filename; nil unless requested
+	State     map[string]string     // State in GitHub, keyed by filename; nil unless requested
+}
+
+// renderTemplate renders workflows through the text/template at
+// opts.Template to w.
+func renderTemplate(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	text, err := os.ReadFile(opts.Template)
+	if err != nil {
+		return fmt.Errorf("error reading template: %v", err)
+	}
+	return executeTemplate(w, opts.Template, string(text), workflows, opts)
+}
+
+// executeTemplate parses text as a template named name and executes it with
+// the data of workflows to w.
+func executeTemplate(w io.Writer, name, text string, workflows []WorkflowInfo, opts Options) error {
+	tmpl, err := template.New(name).Funcs(templateFuncs(opts)).Parse(text)
+	if err != nil {
+		return fmt.Errorf("error parsing template: %v", err)
+	}
+
+	data := TemplateData{
+		Workflows: workflows,
+		RepoURL:   opts.RepoURL,
+		Ref:       opts.Ref,
+		Status:    opts.Status,
+		Metrics:   opts.Metrics,
+		State:     opts.State,
+	}
+
+	err = tmpl.Execute(w, data)
+	if err != nil {
+		return fmt.Errorf("error executing template: %v", err)
+	}
+	return nil
+}
+
+// templateFuncs returns the functions available to templates: the functions
+// of the sprig library, and helpers that format workflows the way the
+// built-in table does.
+func templateFuncs(opts Options) template.FuncMap {
+	funcs := sprig.TxtFuncMap()
+	for name, fn := range map[string]interface{}{
+		"link": func(workflow WorkflowInfo) string {
+			return opts.link(workflow, opts.Output)
+		},
+		"badge": func(workflow WorkflowInfo) (string, error) {
+			return BadgeMarkdown(opts.RepoURL, workflow.Filename, opts.BadgeStyle, opts.Branch)
+		},
+		"page":          PageName,
+		"t":             opts.t,
+		"anchor":        anchor,
+		"escape":        escapeCell,
+		"triggerGroups": triggerGroups,
+		"extract": func(path string, workflow WorkflowInfo) []string {
+			return Extract(workflow.document, path)
+		},
+	} {
+		funcs[name] = fn
+	}
+	return funcs
+}
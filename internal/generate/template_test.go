@@ -0,0 +1,104 @@
+package generate
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestTemplate tests rendering workflows through a custom template
+func TestTemplate(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "workflows.md.tmpl")
+	text := `{{ range .Workflows }}- [{{ .Filename }}]({{ link . }}): {{ default "none" .Description }} ({{ join ", " .Triggers | upper }})
+{{ end }}`
+	if err := os.WriteFile(templatePath, []byte(text), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"pull_request", "push"}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}},
+	}
+
+	content, err := render(context.Background(), workflows, Options{
+		WorkflowsDir: ".github/workflows",
+		Output:       "docs/workflows.md",
+		Template:     templatePath,
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+
+	expected := "- [ci.yml](../.github/workflows/ci.yml): Runs CI. (PULL_REQUEST, PUSH)\n" +
+		"- [nightly.yml](../.github/workflows/nightly.yml): none (SCHEDULE)\n"
+	if content != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+// TestTemplateSprig tests that the functions of the sprig library are
+// available to templates
+func TestTemplateSprig(t *testing.T) {
+	dir := t.TempDir()
+	templatePath := filepath.Join(dir, "workflows.md.tmpl")
+	text := `{{ len .Workflows | add 1 }}{{ range .Workflows }} {{ .Filename | trimSuffix ".yml" | title }}:{{ .Triggers | sortAlpha | first }}{{ end }}`
+	if err := os.WriteFile(templatePath, []byte(text), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+
+	workflows := []WorkflowInfo{{Filename: "release.yml", Triggers: []string{"workflow_dispatch", "push"}}}
+	content, err := render(context.Background(), workflows, Options{Output: "workflows.md", Template: templatePath})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if content != "2 Release:push" {
+		t.Errorf("Expected %q, got %q", "2 Release:push", content)
+	}
+}
+
+// TestTemplateErrors tests that invalid templates are reported
+func TestTemplateErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := render(context.Background(), nil, Options{Template: filepath.Join(dir, "missing.tmpl")})
+	if err == nil || !strings.Contains(err.Error(), "error reading template") {
+		t.Errorf("Expected a read error, got %v", err)
+	}
+
+	invalid := filepath.Join(dir, "invalid.tmpl")
+	if err := os.WriteFile(invalid, []byte("{{ range .Workflows }}"), 0644); err != nil {
+		t.Fatalf("Failed to write template: %v", err)
+	}
+	_, err = render(context.Background(), nil, Options{Template: invalid})
+	if err == nil || !strings.Contains(err.Error(), "error parsing template") {
+		t.Errorf("Expected a parse error, got %v", err)
+	}
+}
+
+// TestHeaderFooter tests surrounding the output with a header and footer
+func TestHeaderFooter(t *testing.T) {
+	workflows := []WorkflowInfo{{Filename: "ci.yml", Triggers: []string{"push"}}}
+
+	content, err := render(context.Background(), workflows, Options{
+		Output: "workflows.md",
+		Header: "Workflows of this repository.\n",
+		Footer: "<!-- Generated by gha-docs. Do not edit. -->",
+	})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.HasPrefix(content, "Workflows of this repository.\n\n# GitHub Workflows Summary\n") {
+		t.Errorf("Expected the header first, got:\n%s", content)
+	}
+	if !strings.HasSuffix(content, "| push |\n\n<!-- Generated by gha-docs. Do not edit. -->\n") {
+		t.Errorf("Expected the footer last, got:\n%s", content)
+	}
+
+	_, err = render(context.Background(), workflows, Options{Format: FormatJSON, Header: "Workflows"})
+	if err == nil {
+		t.Error("Expected an error adding a header to JSON output")
+	}
+}
@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// Built-in themes.
+const (
+	ThemeCompact  = "compact"  // Minimal table of names and descriptions
+	ThemeDetailed = "detailed" // Table plus a section per workflow
+	ThemeBadge    = "badge"    // Status badges first; requires a repository URL
+	ThemeGrouped  = "grouped"  // One table per trigger
+)
+
+// Themes lists the names of the built-in themes.
+var Themes = []string{ThemeCompact, ThemeDetailed, ThemeBadge, ThemeGrouped}
+
+//go:embed themes/*.md.tmpl
+var themeFS embed.FS
+
+// renderTheme renders workflows through the built-in theme opts.Theme to w.
+func renderTheme(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	text, err := themeFS.ReadFile("themes/" + opts.Theme + ".md.tmpl")
+	if err != nil {
+		return fmt.Errorf("unknown theme %q; available themes are %s", opts.Theme, strings.Join(Themes, ", "))
+	}
+	if opts.Theme == ThemeBadge && opts.RepoURL == "" {
+		return fmt.Errorf("a repository URL is required for the %s theme", ThemeBadge)
+	}
+	return executeTemplate(w, opts.Theme, string(text), workflows, opts)
+}
+
+// TriggerGroup holds the workflows run by a trigger.
+type TriggerGroup struct {
+	Trigger   string
+	Workflows []WorkflowInfo
+}
+
+// triggerGroups groups workflows by trigger, sorted by trigger. A workflow
+// with several triggers is in several groups.
+func triggerGroups(workflows []WorkflowInfo) []TriggerGroup {
+	byTrigger := make(map[string][]WorkflowInfo)
+	for _, workflow := range workflows {
+		for _, trigger := range workflow.Triggers {
+			byTrigger[trigger] = append(byTrigger[trigger], workflow)
+		}
+	}
+
+	var groups []TriggerGroup
+	for trigger, members := range byTrigger {
+		groups = append(groups, TriggerGroup{Trigger: trigger, Workflows: members})
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].Trigger < groups[j].Trigger })
+	return groups
+}
+
+// anchor returns the anchor GitHub generates for a markdown heading: lower
+// case, with spaces turned into hyphens and other punctuation dropped.
+func anchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(heading) {
+		switch {
+		case r == ' ':
+			sb.WriteRune('-')
+		case r == '-' || r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r):
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,66 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestThemes tests rendering workflows through the built-in themes
+func TestThemes(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Name: "CI", Description: "Runs CI.", Triggers: []string{"pull_request", "push"},
+			Jobs: []JobInfo{{ID: "test", RunsOn: []string{"ubuntu-latest"}}}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}, Schedules: []string{"0 0 * * *"}},
+	}
+
+	tests := map[string][]string{
+		ThemeCompact: {
+			"| Workflow | Description |\n",
+			"| [CI](ci.yml) | Runs CI. |\n",
+			"| [nightly.yml](nightly.yml) |  |\n",
+		},
+		ThemeDetailed: {
+			"| [ci.yml](#ciyml) | Runs CI. | pull_request, push |\n",
+			"## ci.yml\n\n**CI** · [source](ci.yml)\n\nRuns CI.\n",
+			"| test | ubuntu-latest |\n",
+			"- **Schedules:** `0 0 * * *`\n",
+		},
+		ThemeBadge: {
+			"| [![ci.yml](https://github.com/owner/repo/actions/workflows/ci.yml/badge.svg)](https://github.com/owner/repo/actions/workflows/ci.yml) | [ci.yml](ci.yml) | Runs CI. |\n",
+		},
+		ThemeGrouped: {
+			"## pull_request\n\n| Filename | Description |\n| --- | --- |\n| [ci.yml](ci.yml) | Runs CI. |\n",
+			"## schedule\n\n| Filename | Description |\n| --- | --- |\n| [nightly.yml](nightly.yml) |  |\n",
+		},
+	}
+
+	for _, theme := range Themes {
+		content, err := render(context.Background(), workflows, Options{
+			Output:  "workflows.md",
+			RepoURL: "https://github.com/owner/repo",
+			Theme:   theme,
+		})
+		if err != nil {
+			t.Fatalf("render with theme %s failed: %v", theme, err)
+		}
+		for _, expected := range tests[theme] {
+			if !strings.Contains(content, expected) {
+				t.Errorf("Expected theme %s to contain %q, got:\n%s", theme, expected, content)
+			}
+		}
+	}
+}
+
+// TestThemeErrors tests that unknown themes and missing settings are reported
+func TestThemeErrors(t *testing.T) {
+	for _, opts := range []Options{
+		{Theme: "fancy"},
+		{Theme: ThemeBadge},
+		{Theme: ThemeCompact, Template: "workflows.md.tmpl"},
+	} {
+		if _, err := render(context.Background(), nil, opts); err == nil {
+			t.Errorf("Expected an error for %+v", opts)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+)
+
+// addTOC inserts a table of contents of the second and third level headings
+// of the markdown content below its title, or at the top if it has none.
+// Content without such headings is returned unchanged.
+func addTOC(content string) string {
+	lines := strings.Split(content, "\n")
+
+	var toc strings.Builder
+	seen := make(map[string]int)
+	title := -1
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(line, "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if title == -1 && toc.Len() == 0 && strings.HasPrefix(line, "# ") {
+			title = i
+			continue
+		}
+
+		level := 0
+		switch {
+		case strings.HasPrefix(line, "## "):
+			level = 2
+		case strings.HasPrefix(line, "### "):
+			level = 3
+		default:
+			continue
+		}
+		heading := strings.TrimSpace(line[level+1:])
+
+		// GitHub suffixes repeated anchors with -1, -2, and so on
+		slug := anchor(heading)
+		if n := seen[slug]; n > 0 {
+			seen[slug] = n + 1
+			slug = fmt.Sprintf("%s-%d", slug, n)
+		} else {
+			seen[slug] = 1
+		}
+
+		toc.WriteString(fmt.Sprintf("%s- [%s](#%s)\n", strings.Repeat("  ", level-2), heading, slug))
+	}
+	if toc.Len() == 0 {
+		return content
+	}
+
+	insert := []string{toc.String()}
+	if title == -1 {
+		return strings.Join(append(insert, lines...), "\n")
+	}
+	// Keep the blank line following the title
+	at := title + 1
+	if at < len(lines) && lines[at] == "" {
+		at++
+	}
+	result := append(append(append([]string{}, lines[:at]...), insert...), lines[at:]...)
+	return strings.Join(result, "\n")
+}
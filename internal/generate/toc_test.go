@@ -0,0 +1,49 @@
+package generate
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+// TestTOC tests the table of contents of grouped and themed output
+func TestTOC(t *testing.T) {
+	workflows := []WorkflowInfo{
+		{Filename: "ci.yml", Triggers: []string{"pull_request", "push"}},
+		{Filename: "nightly.yml", Triggers: []string{"schedule"}},
+	}
+
+	content, err := render(context.Background(), workflows, Options{WorkflowsDir: ".", GroupBy: GroupByTrigger, TOC: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	expected := "# GitHub Workflows Summary\n\n- [pull_request](#pull_request)\n- [push](#push)\n- [schedule](#schedule)\n\n## pull_request"
+	if !strings.HasPrefix(content, expected) {
+		t.Errorf("Expected the table of contents below the title, got:\n%s", content)
+	}
+
+	content, err = render(context.Background(), workflows, Options{WorkflowsDir: ".", Theme: ThemeDetailed, TOC: true})
+	if err != nil {
+		t.Fatalf("render failed: %v", err)
+	}
+	if !strings.Contains(content, "- [ci.yml](#ciyml)\n- [nightly.yml](#nightlyyml)\n") {
+		t.Errorf("Expected links to the workflow sections, got:\n%s", content)
+	}
+
+	if _, err := render(context.Background(), workflows, Options{WorkflowsDir: ".", Format: FormatJSON, TOC: true}); err == nil {
+		t.Error("Expected error for a table of contents of JSON output, got nil")
+	}
+}
+
+// TestAddTOC tests headings in code blocks and repeated headings
+func TestAddTOC(t *testing.T) {
+	content := "## Setup\n\n```\n## not a heading\n```\n\n### Setup\n"
+	expected := "- [Setup](#setup)\n  - [Setup](#setup-1)\n\n" + content
+	if toc := addTOC(content); toc != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, toc)
+	}
+
+	if toc := addTOC("# Title\n\nNo sections.\n"); toc != "# Title\n\nNo sections.\n" {
+		t.Errorf("Expected content without sections unchanged, got:\n%s", toc)
+	}
+}
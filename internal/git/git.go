@@ -0,0 +1,226 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// Commit is a single entry from git log.
+type Commit struct {
+	Hash    string
+	Subject string
+}
+
+// run executes git with the given arguments in dir and returns its stdout.
+// git is killed once ctx is done.
+func run(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return nil, fmt.Errorf("git %s: %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// TopLevel returns the root directory of the repository containing dir.
+func TopLevel(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// FindRoot returns the root directory of the repository containing dir,
+// found by walking up from dir to a directory with a .git entry, without
+// running git. The .git entry of a worktree or submodule is a file.
+func FindRoot(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	for {
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", fmt.Errorf("no git repository found")
+		}
+		dir = parent
+	}
+}
+
+// HooksDir returns the directory git runs the hooks of the repository
+// containing dir from, which core.hooksPath may move.
+func HooksDir(ctx context.Context, dir string) (string, error) {
+	out, err := run(ctx, dir, "rev-parse", "--git-path", "hooks")
+	if err != nil {
+		return "", err
+	}
+	hooksDir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(hooksDir) {
+		hooksDir = filepath.Join(dir, hooksDir)
+	}
+	return hooksDir, nil
+}
+
+// RevParse returns the SHA of the commit ref points to in the repository
+// containing dir.
+func RevParse(ctx context.Context, dir, ref string) (string, error) {
+	out, err := run(ctx, dir, "rev-parse", "--verify", "--quiet", ref+"^{commit}")
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s: %v", ref, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// RelativePath returns file relative to the repository root repoDir using
+// forward slashes. file may be relative to the current directory or absolute.
+func RelativePath(repoDir, file string) (string, error) {
+	absPath, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+
+	// Resolve symlinks on both sides so that paths such as /tmp on macOS
+	// compare equal to what git reports.
+	if resolved, err := filepath.EvalSymlinks(absPath); err == nil {
+		absPath = resolved
+	}
+	if resolved, err := filepath.EvalSymlinks(repoDir); err == nil {
+		repoDir = resolved
+	}
+
+	relativePath, err := filepath.Rel(repoDir, absPath)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("%s is outside of repository %s", file, repoDir)
+	}
+	return filepath.ToSlash(relativePath), nil
+}
+
+// ListFiles returns the names of the files directly inside dir at ref. dir is
+// relative to the repository root. A directory that does not exist at ref
+// yields an empty list.
+func ListFiles(ctx context.Context, repoDir, ref, dir string) ([]string, error) {
+	out, err := run(ctx, repoDir, "ls-tree", "--name-only", "--full-tree", ref, path.Clean(dir)+"/")
+	if err != nil {
+		return nil, err
+	}
+
+	var names []string
+	for _, line := range strings.Split(string(out), "\n") {
+		if line == "" {
+			continue
+		}
+		names = append(names, path.Base(line))
+	}
+	return names, nil
+}
+
+// Show returns the content of file at ref. file is relative to the
+// repository root.
+func Show(ctx context.Context, repoDir, ref, file string) ([]byte, error) {
+	return run(ctx, repoDir, "show", ref+":"+path.Clean(file))
+}
+
+// Log returns the commits reachable from to but not from from that touch
+// file, oldest first.
+func Log(ctx context.Context, repoDir, from, to, file string) ([]Commit, error) {
+	out, err := run(ctx, repoDir, "log", "--reverse", "--format=%h %s", from+".."+to, "--", path.Clean(file))
+	if err != nil {
+		return nil, err
+	}
+
+	var commits []Commit
+	for _, line := range strings.Split(string(out), "\n") {
+		if line == "" {
+			continue
+		}
+		hash, subject, _ := strings.Cut(line, " ")
+		commits = append(commits, Commit{Hash: hash, Subject: subject})
+	}
+	return commits, nil
+}
+
+// Clone clones the default branch of remoteURL into dir, without history.
+// config holds key=value configuration, such as credentials, applied to the
+// clone only.
+func Clone(ctx context.Context, remoteURL, dir string, config ...string) error {
+	_, err := run(ctx, "", append(configArgs(config), "clone", "--quiet", "--depth", "1", remoteURL, dir)...)
+	return err
+}
+
+// CommitAll commits every change in repoDir with message as the given
+// author, and reports whether there was anything to commit.
+func CommitAll(ctx context.Context, repoDir, message, authorName, authorEmail string) (bool, error) {
+	if _, err := run(ctx, repoDir, "add", "--all"); err != nil {
+		return false, err
+	}
+
+	out, err := run(ctx, repoDir, "status", "--porcelain")
+	if err != nil {
+		return false, err
+	}
+	if len(bytes.TrimSpace(out)) == 0 {
+		return false, nil
+	}
+
+	identity := configArgs([]string{"user.name=" + authorName, "user.email=" + authorEmail})
+	_, err = run(ctx, repoDir, append(identity, "commit", "--quiet", "--message", message)...)
+	return err == nil, err
+}
+
+// Changed reports whether any of paths, relative to repoDir, differs from
+// the last commit or is untracked.
+func Changed(ctx context.Context, repoDir string, paths []string) (bool, error) {
+	out, err := run(ctx, repoDir, append([]string{"status", "--porcelain", "--untracked-files=all", "--"}, paths...)...)
+	if err != nil {
+		return false, err
+	}
+	return len(bytes.TrimSpace(out)) > 0, nil
+}
+
+// CommitPaths commits the changes to paths, relative to repoDir, with message
+// as the given author, leaving other changes uncommitted. It reports whether
+// there was anything to commit.
+func CommitPaths(ctx context.Context, repoDir string, paths []string, message, authorName, authorEmail string) (bool, error) {
+	changed, err := Changed(ctx, repoDir, paths)
+	if err != nil || !changed {
+		return false, err
+	}
+	if _, err := run(ctx, repoDir, append([]string{"add", "--all", "--"}, paths...)...); err != nil {
+		return false, err
+	}
+
+	identity := configArgs([]string{"user.name=" + authorName, "user.email=" + authorEmail})
+	_, err = run(ctx, repoDir, append(identity, append([]string{"commit", "--quiet", "--message", message, "--"}, paths...)...)...)
+	return err == nil, err
+}
+
+// Push pushes the current branch of repoDir to its origin. config holds
+// key=value configuration applied to the push only.
+func Push(ctx context.Context, repoDir string, config ...string) error {
+	_, err := run(ctx, repoDir, append(configArgs(config), "push", "--quiet", "origin", "HEAD")...)
+	return err
+}
+
+// configArgs converts key=value configuration into git -c arguments.
+func configArgs(config []string) []string {
+	var args []string
+	for _, entry := range config {
+		args = append(args, "-c", entry)
+	}
+	return args
+}
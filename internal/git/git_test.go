@@ -0,0 +1,70 @@
+package git
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// TestFindRoot tests finding the repository root by its .git entry from a
+// nested directory, including the .git file of a worktree.
+func TestFindRoot(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0755); err != nil {
+		t.Fatalf("Failed to create directory: %v", err)
+	}
+
+	if found, err := FindRoot(nested); err == nil {
+		t.Errorf("Expected an error outside a repository, got %s", found)
+	}
+
+	if err := os.WriteFile(filepath.Join(root, ".git"), []byte("gitdir: elsewhere\n"), 0644); err != nil {
+		t.Fatalf("Failed to write .git file: %v", err)
+	}
+	found, err := FindRoot(nested)
+	if err != nil {
+		t.Fatalf("FindRoot failed: %v", err)
+	}
+	if found != root {
+		t.Errorf("Expected %s, got %s", root, found)
+	}
+}
+
+// TestCommitPaths tests committing the changes to some paths only.
+func TestCommitPaths(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	if _, err := run(context.Background(), dir, "init", "--quiet"); err != nil {
+		t.Fatalf("git init failed: %v", err)
+	}
+	for _, name := range []string{"docs.md", "other.txt"} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(name), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	changed, err := Changed(context.Background(), dir, []string{"docs.md"})
+	if err != nil || !changed {
+		t.Fatalf("Expected docs.md to be changed, got %v, %v", changed, err)
+	}
+	committed, err := CommitPaths(context.Background(), dir, []string{"docs.md"}, "Update docs", "Bot", "bot@example.com")
+	if err != nil || !committed {
+		t.Fatalf("Expected a commit, got %v, %v", committed, err)
+	}
+	if changed, _ := Changed(context.Background(), dir, []string{"docs.md"}); changed {
+		t.Errorf("Expected docs.md to be committed")
+	}
+	if changed, _ := Changed(context.Background(), dir, []string{"other.txt"}); !changed {
+		t.Errorf("Expected other.txt to be left uncommitted")
+	}
+
+	committed, err = CommitPaths(context.Background(), dir, []string{"docs.md"}, "Update docs", "Bot", "bot@example.com")
+	if err != nil || committed {
+		t.Errorf("Expected nothing to commit, got %v, %v", committed, err)
+	}
+}
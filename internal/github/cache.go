@@ -0,0 +1,105 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultCacheTTL is how long the gha-docs command uses cached responses
+// without asking the API whether they changed.
+const DefaultCacheTTL = time.Minute
+
+// Cache stores API responses on disk together with their ETags, so that
+// repeated requests are sent as conditional requests. Responses that have not
+// changed are then served from the cache, and do not count against the
+// rate limit.
+type Cache struct {
+	Dir string
+
+	// TTL is how long responses are served from the cache without sending
+	// a request at all. Older responses are only served once a conditional
+	// request confirms they have not changed. Zero always sends a request.
+	TTL time.Duration
+}
+
+// DefaultCacheDir returns the directory API responses are cached in by
+// default, within the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "ghadoc", "api"), nil
+}
+
+// cachedResponse is a response stored in the cache.
+type cachedResponse struct {
+	ETag   string    `json:"etag"`
+	Body   []byte    `json:"body"`
+	Stored time.Time `json:"stored"` // When the response was last confirmed current
+}
+
+// fresh reports whether response may be served without a request.
+func (c *Cache) fresh(response cachedResponse) bool {
+	return c.TTL > 0 && time.Since(response.Stored) < c.TTL
+}
+
+// key returns the cache key of a request to endpoint with token. The token is
+// part of the key because responses depend on who is asking.
+func (c *Cache) key(endpoint, token string) string {
+	sum := sha256.Sum256([]byte(token + "\n" + endpoint))
+	return hex.EncodeToString(sum[:])
+}
+
+// get returns the cached response for key, if any.
+func (c *Cache) get(key string) (cachedResponse, bool) {
+	var cached cachedResponse
+	content, err := os.ReadFile(filepath.Join(c.Dir, key+".json"))
+	if err != nil {
+		return cached, false
+	}
+	if err := json.Unmarshal(content, &cached); err != nil || cached.ETag == "" {
+		return cached, false
+	}
+	return cached, true
+}
+
+// Clear removes every cached response.
+func (c *Cache) Clear() error {
+	if err := os.RemoveAll(c.Dir); err != nil {
+		return fmt.Errorf("error clearing cache: %v", err)
+	}
+	return nil
+}
+
+// put stores response under key. The file is written atomically, so that
+// concurrent requests never read a partially written response.
+func (c *Cache) put(key string, response cachedResponse) error {
+	if err := os.MkdirAll(c.Dir, 0700); err != nil {
+		return fmt.Errorf("error creating cache directory: %v", err)
+	}
+
+	content, err := json.Marshal(response)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.CreateTemp(c.Dir, key+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("error writing cache: %v", err)
+	}
+	_, err = file.Write(content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(file.Name())
+		return fmt.Errorf("error writing cache: %v", err)
+	}
+	return os.Rename(file.Name(), filepath.Join(c.Dir, key+".json"))
+}
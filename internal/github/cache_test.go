@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestCache tests serving unchanged responses from the cache
+func TestCache(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "repo", "default_branch": "main"}`))
+	})
+	client.Cache = &Cache{Dir: t.TempDir()}
+
+	for i := 0; i < 2; i++ {
+		repo, err := client.GetRepo(context.Background(), "owner", "repo")
+		if err != nil {
+			t.Fatalf("GetRepo failed: %v", err)
+		}
+		if repo.DefaultBranch != "main" {
+			t.Errorf("Expected default branch main on request %d, got %q", i+1, repo.DefaultBranch)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests, got %d", requests)
+	}
+
+	// Responses are cached per token
+	client.Token = "other-token"
+	if _, err := client.GetRepo(context.Background(), "owner", "repo"); err != nil {
+		t.Fatalf("GetRepo failed: %v", err)
+	}
+	if cached, ok := client.Cache.get(client.Cache.key(client.BaseURL+"/repos/owner/repo", "test-token")); !ok || cached.ETag != `"v1"` {
+		t.Errorf("Expected cached response for the first token, got %+v", cached)
+	}
+}
+
+// TestCacheTTL tests serving fresh responses from the cache without a
+// request
+func TestCacheTTL(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`{"name": "repo", "default_branch": "main"}`))
+	})
+	client.Cache = &Cache{Dir: t.TempDir(), TTL: time.Hour}
+
+	for i := 0; i < 3; i++ {
+		repo, err := client.GetRepo(context.Background(), "owner", "repo")
+		if err != nil || repo.DefaultBranch != "main" {
+			t.Fatalf("Expected default branch main on request %d, got %+v: %v", i+1, repo, err)
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request within the TTL, got %d", requests)
+	}
+
+	// Expired responses are confirmed with a conditional request, which
+	// makes them fresh again
+	key := client.Cache.key(client.BaseURL+"/repos/owner/repo", client.Token)
+	cached, _ := client.Cache.get(key)
+	cached.Stored = time.Now().Add(-2 * time.Hour)
+	client.Cache.put(key, cached)
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetRepo(context.Background(), "owner", "repo"); err != nil {
+			t.Fatalf("GetRepo failed: %v", err)
+		}
+	}
+	if requests != 2 {
+		t.Errorf("Expected 2 requests after the TTL, got %d", requests)
+	}
+
+	if err := client.Cache.Clear(); err != nil {
+		t.Fatalf("Clear failed: %v", err)
+	}
+	if _, ok := client.Cache.get(key); ok {
+		t.Error("Expected no cached response after Clear")
+	}
+}
+
+// TestRateLimitBackoff tests retrying requests once the rate limit resets
+func TestRateLimitBackoff(t *testing.T) {
+	requests := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "You have exceeded a secondary rate limit"}`))
+		case 2:
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.Write([]byte(`{"name": "repo"}`))
+		}
+	})
+
+	var waits []time.Duration
+	client.Sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	repo, err := client.GetRepo(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("GetRepo failed: %v", err)
+	}
+	if repo.Name != "repo" {
+		t.Errorf("Unexpected repository: %+v", repo)
+	}
+	if len(waits) != 2 || waits[0] != 2*time.Second || waits[1] < 55*time.Second || waits[1] > 62*time.Second {
+		t.Errorf("Unexpected waits: %v", waits)
+	}
+}
+
+// TestRateLimitTooLong tests failing when the rate limit resets too late
+func TestRateLimitTooLong(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	})
+	client.Sleep = func(d time.Duration) { t.Errorf("Unexpected wait of %v", d) }
+
+	_, err := client.GetRepo(context.Background(), "owner", "repo")
+	if err == nil || !strings.Contains(err.Error(), "rate limit exceeded") {
+		t.Errorf("Expected rate limit error, got %v", err)
+	}
+}
+
+// TestForbidden tests that permission errors are not retried
+func TestForbidden(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+	})
+	client.Sleep = func(d time.Duration) { t.Errorf("Unexpected wait of %v", d) }
+
+	_, err := client.GetRepo(context.Background(), "owner", "repo")
+	if apiErr, ok := err.(*Error); !ok || apiErr.StatusCode != http.StatusForbidden {
+		t.Errorf("Expected 403 API error, got %v", err)
+	}
+}
+
+// TestRateLimitCanceled tests that waiting for the rate limit to reset stops
+// when the context is canceled
+func TestRateLimitCanceled(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	_, err := client.GetRepo(ctx, "owner", "repo")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > 10*time.Second {
+		t.Errorf("Expected the wait to stop at the deadline, took %v", elapsed)
+	}
+}
@@ -0,0 +1,259 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is the base URL of the public GitHub REST API.
+const DefaultBaseURL = "https://api.github.com"
+
+// DefaultTimeout is the time allowed for each request of a client returned
+// by NewClient.
+const DefaultTimeout = 30 * time.Second
+
+// Client is a minimal client for the GitHub REST API.
+type Client struct {
+	BaseURL    string
+	Token      string
+	HTTPClient *http.Client
+	Cache      *Cache              // Optional cache of GET responses
+	Sleep      func(time.Duration) // Waits for the rate limit to reset; defaults to time.Sleep
+}
+
+// NewClient returns a client for the API at baseURL authenticating with
+// token. An empty baseURL selects DefaultBaseURL and an empty token makes
+// unauthenticated requests.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+	}
+}
+
+// TokenFromEnv returns the API token from the GITHUB_TOKEN or GH_TOKEN
+// environment variables.
+func TokenFromEnv() string {
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		return token
+	}
+	return os.Getenv("GH_TOKEN")
+}
+
+// ParseRepo splits an "owner/name" repository reference.
+func ParseRepo(repo string) (owner string, name string, err error) {
+	owner, name, ok := strings.Cut(repo, "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("invalid repository %q: expected owner/name", repo)
+	}
+	return owner, name, nil
+}
+
+// RepoFromURL returns the "owner/name" reference of a repository URL such as
+// https://github.com/owner/name.
+func RepoFromURL(repoURL string) (string, error) {
+	parsed, err := url.Parse(repoURL)
+	if err != nil || parsed.Host == "" {
+		return "", fmt.Errorf("invalid repository URL %q", repoURL)
+	}
+
+	repo := strings.TrimSuffix(strings.Trim(parsed.Path, "/"), ".git")
+	if _, _, err := ParseRepo(repo); err != nil {
+		return "", fmt.Errorf("invalid repository URL %q: expected https://<host>/owner/name", repoURL)
+	}
+	return repo, nil
+}
+
+// Error is returned for API responses with a non-2xx status code.
+type Error struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("GitHub API returned %d: %s", e.StatusCode, e.Message)
+}
+
+// IsNotFound reports whether err is an API error with status 404.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*Error)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// get requests path with the given query and decodes the JSON response into v.
+func (c *Client) get(ctx context.Context, path string, query url.Values, v interface{}) error {
+	_, err := c.do(ctx, http.MethodGet, path, query, nil, v)
+	return err
+}
+
+// do sends a request with an optional JSON body and decodes the JSON
+// response into v if v is not nil. GET requests are made conditional on the
+// cached response if the client has a cache, or not sent at all while the
+// cached response is within the TTL of the cache, returning a nil response.
+// Requests that hit the rate limit are retried once the limit resets, unless
+// ctx is done first.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body interface{}, v interface{}) (*http.Response, error) {
+	endpoint := c.BaseURL + path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var content []byte
+	if body != nil {
+		var err error
+		content, err = json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var cacheKey string
+	var cached cachedResponse
+	var hasCached bool
+	if method == http.MethodGet && c.Cache != nil {
+		cacheKey = c.Cache.key(endpoint, c.Token)
+		cached, hasCached = c.Cache.get(cacheKey)
+	}
+	if hasCached && c.Cache.fresh(cached) {
+		if v != nil {
+			if err := json.Unmarshal(cached.Body, v); err != nil {
+				return nil, fmt.Errorf("error decoding GitHub API response: %v", err)
+			}
+		}
+		return nil, nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		var reader io.Reader
+		if body != nil {
+			reader = bytes.NewReader(content)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+		if err != nil {
+			return nil, err
+		}
+		req.Header.Set("Accept", "application/vnd.github+json")
+		req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+		if body != nil {
+			req.Header.Set("Content-Type", "application/json")
+		}
+		if c.Token != "" {
+			req.Header.Set("Authorization", "Bearer "+c.Token)
+		}
+		if hasCached {
+			req.Header.Set("If-None-Match", cached.ETag)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		data, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return resp, fmt.Errorf("error reading GitHub API response: %v", err)
+		}
+
+		if wait, limited := rateLimitWait(resp); limited && attempt < maxRateLimitRetries {
+			if wait > maxRateLimitWait {
+				return resp, fmt.Errorf("GitHub API rate limit exceeded; it resets in %v", wait.Round(time.Second))
+			}
+			if err := c.sleep(ctx, wait); err != nil {
+				return resp, err
+			}
+			continue
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusNotModified && hasCached:
+			data = cached.Body
+			if c.Cache.TTL > 0 {
+				cached.Stored = time.Now()
+				c.Cache.put(cacheKey, cached)
+			}
+		case resp.StatusCode < 200 || resp.StatusCode > 299:
+			var apiErr struct {
+				Message string `json:"message"`
+			}
+			json.Unmarshal(data, &apiErr)
+			return resp, &Error{StatusCode: resp.StatusCode, Message: apiErr.Message}
+		case cacheKey != "" && resp.Header.Get("ETag") != "":
+			// A failure to cache only costs a full request next time
+			c.Cache.put(cacheKey, cachedResponse{ETag: resp.Header.Get("ETag"), Body: data, Stored: time.Now()})
+		}
+
+		if v != nil {
+			if err := json.Unmarshal(data, v); err != nil {
+				return resp, fmt.Errorf("error decoding GitHub API response: %v", err)
+			}
+		}
+		return resp, nil
+	}
+}
+
+// Limits of waiting for the rate limit to reset.
+const (
+	maxRateLimitRetries = 3
+	maxRateLimitWait    = 15 * time.Minute
+)
+
+// rateLimitWait reports whether resp is a rate limit response, and how long
+// to wait before retrying: the Retry-After delay of secondary rate limits, or
+// the time until the primary rate limit resets.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return time.Minute, true
+		}
+		wait := time.Until(time.Unix(reset, 0)) + time.Second
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+// sleep waits for d, or calls the client's Sleep function if it is set. It
+// returns the error of ctx if ctx is done before d has passed.
+func (c *Client) sleep(ctx context.Context, d time.Duration) error {
+	if c.Sleep != nil {
+		c.Sleep(d)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,242 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a client for a test server serving handler
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(server.URL, "test-token")
+}
+
+// TestParseRepo tests parsing owner/name repository references
+func TestParseRepo(t *testing.T) {
+	owner, name, err := ParseRepo("droctothorpe/ghadoc")
+	if err != nil || owner != "droctothorpe" || name != "ghadoc" {
+		t.Errorf("ParseRepo returned %q, %q, %v", owner, name, err)
+	}
+
+	for _, repo := range []string{"", "ghadoc", "/ghadoc", "droctothorpe/", "a/b/c"} {
+		if _, _, err := ParseRepo(repo); err == nil {
+			t.Errorf("Expected error for repository %q, got nil", repo)
+		}
+	}
+}
+
+// TestRepoFromURL tests extracting owner/name from repository URLs
+func TestRepoFromURL(t *testing.T) {
+	for repoURL, expected := range map[string]string{
+		"https://github.com/droctothorpe/ghadoc":  "droctothorpe/ghadoc",
+		"https://github.com/droctothorpe/ghadoc/": "droctothorpe/ghadoc",
+		"https://ghe.example.com/org/repo.git":    "org/repo",
+	} {
+		repo, err := RepoFromURL(repoURL)
+		if err != nil || repo != expected {
+			t.Errorf("RepoFromURL(%q) = %q, %v, expected %q", repoURL, repo, err, expected)
+		}
+	}
+
+	for _, repoURL := range []string{"", "droctothorpe/ghadoc", "https://github.com/droctothorpe", "https://github.com/a/b/c"} {
+		if _, err := RepoFromURL(repoURL); err == nil {
+			t.Errorf("Expected error for repository URL %q, got nil", repoURL)
+		}
+	}
+}
+
+// TestListWorkflowRuns tests fetching the runs of a workflow
+func TestListWorkflowRuns(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/workflows/ci.yml/runs" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			t.Errorf("Expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Query().Get("per_page") != "5" {
+			t.Errorf("Expected per_page=5, got %q", r.URL.Query().Get("per_page"))
+		}
+		w.Write([]byte(`{"workflow_runs": [
+			{"id": 2, "status": "completed", "conclusion": "success",
+			 "created_at": "2025-01-01T10:00:00Z", "run_started_at": "2025-01-01T10:00:00Z", "updated_at": "2025-01-01T10:05:00Z"}
+		]}`))
+	})
+
+	runs, err := client.ListWorkflowRuns(context.Background(), "owner", "repo", "ci.yml", 5)
+	if err != nil {
+		t.Fatalf("ListWorkflowRuns failed: %v", err)
+	}
+	if len(runs) != 1 || runs[0].ID != 2 || runs[0].Conclusion != "success" {
+		t.Fatalf("Unexpected runs: %+v", runs)
+	}
+	if runs[0].Duration().Minutes() != 5 {
+		t.Errorf("Expected a 5 minute run, got %v", runs[0].Duration())
+	}
+
+	// Workflows unknown to GitHub have no runs
+	runs, err = client.ListWorkflowRuns(context.Background(), "owner", "repo", "missing.yml", 5)
+	if err != nil || len(runs) != 0 {
+		t.Errorf("Expected no runs and no error for unknown workflow, got %+v, %v", runs, err)
+	}
+}
+
+// TestAPIError tests error reporting for failed requests
+func TestAPIError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Bad credentials"}`))
+	})
+
+	_, err := client.ListWorkflowRuns(context.Background(), "owner", "repo", "ci.yml", 5)
+	if err == nil {
+		t.Fatal("Expected error for unauthorized request, got nil")
+	}
+	if err.Error() != "GitHub API returned 401: Bad credentials" {
+		t.Errorf("Unexpected error message: %v", err)
+	}
+}
+
+// TestListWorkflowRunsSince tests fetching the runs of a workflow in a time window
+func TestListWorkflowRunsSince(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("created") != ">=2025-01-01" || query.Get("status") != "completed" {
+			t.Errorf("Unexpected query %q", r.URL.RawQuery)
+		}
+
+		// A full first page followed by a partial second page
+		count := runsPerPage
+		if query.Get("page") == "2" {
+			count = 1
+		}
+		var runs []string
+		for i := 0; i < count; i++ {
+			runs = append(runs, fmt.Sprintf(`{"id": %d}`, i))
+		}
+		fmt.Fprintf(w, `{"workflow_runs": [%s]}`, strings.Join(runs, ","))
+	})
+
+	runs, err := client.ListWorkflowRunsSince(context.Background(), "owner", "repo", "ci.yml", time.Date(2025, 1, 1, 15, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("ListWorkflowRunsSince failed: %v", err)
+	}
+	if len(runs) != runsPerPage+1 {
+		t.Errorf("Expected %d runs, got %d", runsPerPage+1, len(runs))
+	}
+}
+
+// TestGetRunTiming tests fetching the usage of a run
+func TestGetRunTiming(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/runs/42/timing" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"billable": {"UBUNTU": {"total_ms": 180000, "jobs": 2}}, "run_duration_ms": 120000}`))
+	})
+
+	timing, err := client.GetRunTiming(context.Background(), "owner", "repo", 42)
+	if err != nil {
+		t.Fatalf("GetRunTiming failed: %v", err)
+	}
+	if timing.Billable["UBUNTU"].TotalMS != 180000 || timing.Billable["UBUNTU"].Jobs != 2 || timing.RunDurationMS != 120000 {
+		t.Errorf("Unexpected timing: %+v", timing)
+	}
+}
+
+// TestListWorkflows tests fetching the workflows of a repository
+func TestListWorkflows(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/actions/workflows" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"total_count": 2, "workflows": [
+			{"id": 1, "name": "CI", "path": ".github/workflows/ci.yml", "state": "active"},
+			{"id": 2, "name": "Nightly", "path": ".github/workflows/nightly.yml", "state": "disabled_inactivity"}
+		]}`))
+	})
+
+	workflows, err := client.ListWorkflows(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("ListWorkflows failed: %v", err)
+	}
+	if len(workflows) != 2 || workflows[1].Path != ".github/workflows/nightly.yml" || workflows[1].State != WorkflowDisabledInactivity {
+		t.Errorf("Unexpected workflows: %+v", workflows)
+	}
+}
+
+// TestStorage tests fetching the artifacts and caches of a repository
+func TestStorage(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/artifacts":
+			w.Write([]byte(`{"artifacts": [{"id": 1, "name": "dist", "size_in_bytes": 2048, "workflow_run": {"id": 7}}]}`))
+		case "/repos/owner/repo/actions/caches":
+			w.Write([]byte(`{"actions_caches": [{"id": 2, "ref": "refs/heads/main", "key": "setup-go-Linux-abc", "size_in_bytes": 4096}]}`))
+		case "/repos/owner/repo/actions/runs/7":
+			w.Write([]byte(`{"id": 7, "path": ".github/workflows/build.yml"}`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	artifacts, err := client.ListArtifacts(context.Background(), "owner", "repo")
+	if err != nil || len(artifacts) != 1 || artifacts[0].WorkflowRun.ID != 7 || artifacts[0].SizeInBytes != 2048 {
+		t.Errorf("Unexpected artifacts %+v, %v", artifacts, err)
+	}
+
+	caches, err := client.ListCaches(context.Background(), "owner", "repo")
+	if err != nil || len(caches) != 1 || caches[0].Key != "setup-go-Linux-abc" {
+		t.Errorf("Unexpected caches %+v, %v", caches, err)
+	}
+
+	run, err := client.GetRun(context.Background(), "owner", "repo", 7)
+	if err != nil || run.Path != ".github/workflows/build.yml" {
+		t.Errorf("Unexpected run %+v, %v", run, err)
+	}
+}
+
+// TestListEnvironments tests fetching the environments of a repository
+func TestListEnvironments(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo/environments" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"total_count": 1, "environments": [{
+			"name": "production",
+			"protection_rules": [
+				{"type": "wait_timer", "wait_timer": 30},
+				{"type": "required_reviewers", "reviewers": [
+					{"type": "User", "reviewer": {"login": "octocat"}},
+					{"type": "Team", "reviewer": {"slug": "ops"}}
+				]}
+			],
+			"deployment_branch_policy": {"protected_branches": true, "custom_branch_policies": false}
+		}]}`))
+	})
+
+	environments, err := client.ListEnvironments(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("ListEnvironments failed: %v", err)
+	}
+	if len(environments) != 1 {
+		t.Fatalf("Expected 1 environment, got %+v", environments)
+	}
+	production := environments[0]
+	if len(production.ProtectionRules) != 2 || production.ProtectionRules[0].WaitTimer != 30 ||
+		production.ProtectionRules[1].Reviewers[1].Reviewer.Slug != "ops" ||
+		production.DeploymentBranchPolicy == nil || !production.DeploymentBranchPolicy.ProtectedBranches {
+		t.Errorf("Unexpected environment: %+v", production)
+	}
+}
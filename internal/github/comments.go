@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// IssueComment is a comment on an issue or pull request.
+type IssueComment struct {
+	ID   int64  `json:"id"`
+	Body string `json:"body"`
+}
+
+// ListIssueComments returns all comments on the issue or pull request number
+// of owner/repo.
+func (c *Client) ListIssueComments(ctx context.Context, owner, repo string, number int) ([]IssueComment, error) {
+	var comments []IssueComment
+	for page := 1; ; page++ {
+		var pageComments []IssueComment
+		err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, number), pageQuery(page), &pageComments)
+		if err != nil {
+			return nil, err
+		}
+
+		comments = append(comments, pageComments...)
+		if len(pageComments) < runsPerPage {
+			return comments, nil
+		}
+	}
+}
+
+// CreateIssueComment comments body on the issue or pull request number of
+// owner/repo.
+func (c *Client) CreateIssueComment(ctx context.Context, owner, repo string, number int, body string) error {
+	_, err := c.do(ctx, http.MethodPost, fmt.Sprintf("/repos/%s/%s/issues/%d/comments", owner, repo, number), nil, map[string]string{"body": body}, nil)
+	return err
+}
+
+// UpdateIssueComment replaces the body of the comment id of owner/repo.
+func (c *Client) UpdateIssueComment(ctx context.Context, owner, repo string, id int64, body string) error {
+	_, err := c.do(ctx, http.MethodPatch, fmt.Sprintf("/repos/%s/%s/issues/comments/%d", owner, repo, id), nil, map[string]string{"body": body}, nil)
+	return err
+}
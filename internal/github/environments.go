@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// Environment is a deployment environment of a repository.
+type Environment struct {
+	Name                   string           `json:"name"`
+	HTMLURL                string           `json:"html_url"`
+	ProtectionRules        []ProtectionRule `json:"protection_rules"`
+	DeploymentBranchPolicy *struct {
+		ProtectedBranches    bool `json:"protected_branches"`
+		CustomBranchPolicies bool `json:"custom_branch_policies"`
+	} `json:"deployment_branch_policy"` // nil if all branches can deploy
+}
+
+// ProtectionRule is a protection rule of an environment.
+type ProtectionRule struct {
+	Type      string     `json:"type"`       // required_reviewers, wait_timer, or branch_policy
+	WaitTimer int        `json:"wait_timer"` // Minutes, for wait_timer rules
+	Reviewers []Reviewer `json:"reviewers"`  // For required_reviewers rules
+}
+
+// Reviewer is a user or team that must approve deployments.
+type Reviewer struct {
+	Type     string `json:"type"` // User or Team
+	Reviewer struct {
+		Login string `json:"login"` // Users
+		Slug  string `json:"slug"`  // Teams
+	} `json:"reviewer"`
+}
+
+// ListEnvironments returns all deployment environments of owner/repo.
+func (c *Client) ListEnvironments(ctx context.Context, owner, repo string) ([]Environment, error) {
+	var environments []Environment
+	for page := 1; ; page++ {
+		var response struct {
+			Environments []Environment `json:"environments"`
+		}
+		err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/environments", owner, repo), pageQuery(page), &response)
+		if err != nil {
+			return nil, err
+		}
+
+		environments = append(environments, response.Environments...)
+		if len(response.Environments) < runsPerPage {
+			return environments, nil
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// reposPerPage is the page size used when listing repositories.
+const reposPerPage = 100
+
+// Repository is a GitHub repository.
+type Repository struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	HTMLURL       string   `json:"html_url"`
+	DefaultBranch string   `json:"default_branch"`
+	Private       bool     `json:"private"`
+	Visibility    string   `json:"visibility"` // public, private, or internal
+	Archived      bool     `json:"archived"`
+	Fork          bool     `json:"fork"`
+	Topics        []string `json:"topics"`
+}
+
+// ListOrgRepos returns all repositories of the organization org.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) ([]Repository, error) {
+	var repos []Repository
+	for page := 1; ; page++ {
+		var pageRepos []Repository
+		query := url.Values{
+			"per_page": {strconv.Itoa(reposPerPage)},
+			"page":     {strconv.Itoa(page)},
+		}
+		err := c.get(ctx, fmt.Sprintf("/orgs/%s/repos", url.PathEscape(org)), query, &pageRepos)
+		if err != nil {
+			return nil, err
+		}
+
+		repos = append(repos, pageRepos...)
+		if len(pageRepos) < reposPerPage {
+			return repos, nil
+		}
+	}
+}
+
+// GetRepo returns the repository owner/repo.
+func (c *Client) GetRepo(ctx context.Context, owner, repo string) (Repository, error) {
+	var repository Repository
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s", owner, repo), nil, &repository)
+	return repository, err
+}
+
+// GetCommitSHA returns the SHA of the commit ref points to in owner/repo.
+func (c *Client) GetCommitSHA(ctx context.Context, owner, repo, ref string) (string, error) {
+	var commit struct {
+		SHA string `json:"sha"`
+	}
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/commits/%s", owner, repo, url.PathEscape(ref)), nil, &commit)
+	return commit.SHA, err
+}
+
+// Content is an entry of a repository directory or a file.
+type Content struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"` // file, dir, symlink, or submodule
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// ListDirectory returns the entries of dir in owner/repo at ref. An empty ref
+// selects the default branch. A directory that does not exist yields no
+// entries.
+func (c *Client) ListDirectory(ctx context.Context, owner, repo, dir, ref string) ([]Content, error) {
+	var entries []Content
+	err := c.get(ctx, contentsPath(owner, repo, dir), refQuery(ref), &entries)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// GetFile returns the content of file in owner/repo at ref. An empty ref
+// selects the default branch.
+func (c *Client) GetFile(ctx context.Context, owner, repo, file, ref string) ([]byte, error) {
+	var content Content
+	err := c.get(ctx, contentsPath(owner, repo, file), refQuery(ref), &content)
+	if err != nil {
+		return nil, err
+	}
+
+	if content.Encoding != "base64" {
+		return nil, fmt.Errorf("unsupported content encoding %q for %s", content.Encoding, file)
+	}
+	// The API wraps the base64 content across lines
+	return base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+}
+
+// contentsPath returns the API path of file in owner/repo.
+func contentsPath(owner, repo, file string) string {
+	var segments []string
+	for _, segment := range strings.Split(strings.Trim(file, "/"), "/") {
+		segments = append(segments, url.PathEscape(segment))
+	}
+	return fmt.Sprintf("/repos/%s/%s/contents/%s", owner, repo, strings.Join(segments, "/"))
+}
+
+// refQuery returns the query selecting ref, if set.
+func refQuery(ref string) url.Values {
+	if ref == "" {
+		return nil
+	}
+	return url.Values{"ref": {ref}}
+}
@@ -0,0 +1,124 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// TestListOrgRepos tests paginated listing of organization repositories
+func TestListOrgRepos(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/octo-org/repos" {
+			http.NotFound(w, r)
+			return
+		}
+
+		// The first page is full, the second one is not
+		count := reposPerPage
+		if r.URL.Query().Get("page") == "2" {
+			count = 1
+		}
+		var repos []string
+		for i := 0; i < count; i++ {
+			repos = append(repos, fmt.Sprintf(`{"name": "repo-%s-%d", "topics": ["ci"]}`, r.URL.Query().Get("page"), i))
+		}
+		w.Write([]byte("[" + strings.Join(repos, ",") + "]"))
+	})
+
+	repos, err := client.ListOrgRepos(context.Background(), "octo-org")
+	if err != nil {
+		t.Fatalf("ListOrgRepos failed: %v", err)
+	}
+	if len(repos) != reposPerPage+1 {
+		t.Fatalf("Expected %d repositories, got %d", reposPerPage+1, len(repos))
+	}
+	if repos[reposPerPage].Name != "repo-2-0" || repos[0].Topics[0] != "ci" {
+		t.Errorf("Unexpected repositories: %+v, %+v", repos[0], repos[reposPerPage])
+	}
+}
+
+// TestContents tests listing directories and reading files
+func TestContents(t *testing.T) {
+	encoded := base64.StdEncoding.EncodeToString([]byte("on: push\n"))
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "main" {
+			t.Errorf("Expected ref=main, got %q", r.URL.Query().Get("ref"))
+		}
+		switch r.URL.Path {
+		case "/repos/owner/repo/contents/.github/workflows":
+			w.Write([]byte(`[{"name": "ci.yml", "path": ".github/workflows/ci.yml", "type": "file"}]`))
+		case "/repos/owner/repo/contents/.github/workflows/ci.yml":
+			fmt.Fprintf(w, `{"name": "ci.yml", "type": "file", "encoding": "base64", "content": "%s\n"}`, encoded)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	entries, err := client.ListDirectory(context.Background(), "owner", "repo", ".github/workflows", "main")
+	if err != nil {
+		t.Fatalf("ListDirectory failed: %v", err)
+	}
+	if len(entries) != 1 || entries[0].Name != "ci.yml" || entries[0].Type != "file" {
+		t.Fatalf("Unexpected entries: %+v", entries)
+	}
+
+	content, err := client.GetFile(context.Background(), "owner", "repo", ".github/workflows/ci.yml", "main")
+	if err != nil {
+		t.Fatalf("GetFile failed: %v", err)
+	}
+	if string(content) != "on: push\n" {
+		t.Errorf("Unexpected content %q", content)
+	}
+
+	// Missing directories have no entries
+	entries, err = client.ListDirectory(context.Background(), "owner", "repo", "missing", "main")
+	if err != nil || len(entries) != 0 {
+		t.Errorf("Expected no entries and no error for missing directory, got %+v, %v", entries, err)
+	}
+}
+
+// TestGetRepo tests fetching a single repository
+func TestGetRepo(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/owner/repo" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name": "repo", "full_name": "owner/repo", "html_url": "https://github.com/owner/repo", "default_branch": "trunk"}`))
+	})
+
+	repo, err := client.GetRepo(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("GetRepo failed: %v", err)
+	}
+	if repo.DefaultBranch != "trunk" || repo.HTMLURL != "https://github.com/owner/repo" {
+		t.Errorf("Unexpected repository: %+v", repo)
+	}
+
+	if _, err := client.GetRepo(context.Background(), "owner", "missing"); !IsNotFound(err) {
+		t.Errorf("Expected not found error, got %v", err)
+	}
+}
+
+// TestGetCommitSHA tests resolving a ref to a commit SHA
+func TestGetCommitSHA(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/repos/owner/repo/commits/release%2Fv1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"sha": "0123456789abcdef0123456789abcdef01234567"}`))
+	})
+
+	sha, err := client.GetCommitSHA(context.Background(), "owner", "repo", "release/v1")
+	if err != nil {
+		t.Fatalf("GetCommitSHA failed: %v", err)
+	}
+	if sha != "0123456789abcdef0123456789abcdef01234567" {
+		t.Errorf("Unexpected SHA %q", sha)
+	}
+}
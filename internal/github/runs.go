@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WorkflowRun is a single run of a workflow.
+type WorkflowRun struct {
+	ID           int64     `json:"id"`
+	Event        string    `json:"event"`
+	HeadBranch   string    `json:"head_branch"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+	Path         string    `json:"path"` // Path of the workflow file, e.g. .github/workflows/ci.yml
+}
+
+// Duration returns how long a completed run took.
+func (r WorkflowRun) Duration() time.Duration {
+	start := r.RunStartedAt
+	if start.IsZero() {
+		start = r.CreatedAt
+	}
+	return r.UpdatedAt.Sub(start)
+}
+
+// ListWorkflowRuns returns up to count of the most recent runs of the
+// workflow file (e.g. "ci.yml") in owner/repo, newest first. A workflow
+// unknown to GitHub yields no runs.
+func (c *Client) ListWorkflowRuns(ctx context.Context, owner, repo, workflowFile string, count int) ([]WorkflowRun, error) {
+	if count > 100 {
+		count = 100
+	}
+
+	var response struct {
+		WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+	}
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/runs", owner, repo, url.PathEscape(workflowFile))
+	err := c.get(ctx, path, url.Values{"per_page": {strconv.Itoa(count)}}, &response)
+	if IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return response.WorkflowRuns, nil
+}
+
+// runsPerPage is the page size used when listing runs in a time window.
+const runsPerPage = 100
+
+// ListWorkflowRunsSince returns the completed runs of the workflow file in
+// owner/repo created on or after the day of since, newest first. A workflow
+// unknown to GitHub yields no runs.
+func (c *Client) ListWorkflowRunsSince(ctx context.Context, owner, repo, workflowFile string, since time.Time) ([]WorkflowRun, error) {
+	path := fmt.Sprintf("/repos/%s/%s/actions/workflows/%s/runs", owner, repo, url.PathEscape(workflowFile))
+
+	var runs []WorkflowRun
+	for page := 1; ; page++ {
+		var response struct {
+			WorkflowRuns []WorkflowRun `json:"workflow_runs"`
+		}
+		query := url.Values{
+			"created":  {">=" + since.UTC().Format("2006-01-02")},
+			"status":   {"completed"},
+			"per_page": {strconv.Itoa(runsPerPage)},
+			"page":     {strconv.Itoa(page)},
+		}
+		err := c.get(ctx, path, query, &response)
+		if IsNotFound(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		runs = append(runs, response.WorkflowRuns...)
+		if len(response.WorkflowRuns) < runsPerPage {
+			return runs, nil
+		}
+	}
+}
+
+// RunTiming is the usage of a workflow run.
+type RunTiming struct {
+	// Billable maps runner operating systems (UBUNTU, MACOS, or WINDOWS) to
+	// the billable time of the jobs that ran on them. Runs in public
+	// repositories and on self-hosted runners are not billable.
+	Billable      map[string]BillableTime `json:"billable"`
+	RunDurationMS int64                   `json:"run_duration_ms"`
+}
+
+// BillableTime is the billable time of the jobs of a run on a runner
+// operating system.
+type BillableTime struct {
+	TotalMS int64 `json:"total_ms"`
+	Jobs    int   `json:"jobs"`
+}
+
+// GetRunTiming returns the usage of the run runID in owner/repo.
+func (c *Client) GetRunTiming(ctx context.Context, owner, repo string, runID int64) (RunTiming, error) {
+	var timing RunTiming
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d/timing", owner, repo, runID), nil, &timing)
+	return timing, err
+}
+
+// Workflow states reported by the API.
+const (
+	WorkflowActive             = "active"
+	WorkflowDisabledManually   = "disabled_manually"
+	WorkflowDisabledInactivity = "disabled_inactivity"
+	WorkflowDisabledFork       = "disabled_fork"
+)
+
+// Workflow is a workflow registered in a repository.
+type Workflow struct {
+	ID    int64  `json:"id"`
+	Name  string `json:"name"`
+	Path  string `json:"path"`  // e.g. .github/workflows/ci.yml
+	State string `json:"state"` // One of the Workflow state constants
+}
+
+// ListWorkflows returns all workflows registered in owner/repo.
+func (c *Client) ListWorkflows(ctx context.Context, owner, repo string) ([]Workflow, error) {
+	var workflows []Workflow
+	for page := 1; ; page++ {
+		var response struct {
+			Workflows []Workflow `json:"workflows"`
+		}
+		err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/actions/workflows", owner, repo), pageQuery(page), &response)
+		if err != nil {
+			return nil, err
+		}
+
+		workflows = append(workflows, response.Workflows...)
+		if len(response.Workflows) < runsPerPage {
+			return workflows, nil
+		}
+	}
+}
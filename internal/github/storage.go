@@ -0,0 +1,83 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Artifact is an artifact uploaded by a workflow run.
+type Artifact struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	SizeInBytes int64     `json:"size_in_bytes"`
+	Expired     bool      `json:"expired"`
+	CreatedAt   time.Time `json:"created_at"`
+	WorkflowRun struct {
+		ID int64 `json:"id"`
+	} `json:"workflow_run"`
+}
+
+// ListArtifacts returns all artifacts of owner/repo.
+func (c *Client) ListArtifacts(ctx context.Context, owner, repo string) ([]Artifact, error) {
+	var artifacts []Artifact
+	for page := 1; ; page++ {
+		var response struct {
+			Artifacts []Artifact `json:"artifacts"`
+		}
+		err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/actions/artifacts", owner, repo), pageQuery(page), &response)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, response.Artifacts...)
+		if len(response.Artifacts) < runsPerPage {
+			return artifacts, nil
+		}
+	}
+}
+
+// ActionsCache is an entry of the Actions cache of a repository.
+type ActionsCache struct {
+	ID             int64     `json:"id"`
+	Ref            string    `json:"ref"`
+	Key            string    `json:"key"`
+	SizeInBytes    int64     `json:"size_in_bytes"`
+	LastAccessedAt time.Time `json:"last_accessed_at"`
+}
+
+// ListCaches returns all Actions cache entries of owner/repo.
+func (c *Client) ListCaches(ctx context.Context, owner, repo string) ([]ActionsCache, error) {
+	var caches []ActionsCache
+	for page := 1; ; page++ {
+		var response struct {
+			ActionsCaches []ActionsCache `json:"actions_caches"`
+		}
+		err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/actions/caches", owner, repo), pageQuery(page), &response)
+		if err != nil {
+			return nil, err
+		}
+
+		caches = append(caches, response.ActionsCaches...)
+		if len(response.ActionsCaches) < runsPerPage {
+			return caches, nil
+		}
+	}
+}
+
+// GetRun returns the workflow run runID of owner/repo.
+func (c *Client) GetRun(ctx context.Context, owner, repo string, runID int64) (WorkflowRun, error) {
+	var run WorkflowRun
+	err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/actions/runs/%d", owner, repo, runID), nil, &run)
+	return run, err
+}
+
+// pageQuery returns the query of a page of a paginated list.
+func pageQuery(page int) url.Values {
+	return url.Values{
+		"per_page": {strconv.Itoa(runsPerPage)},
+		"page":     {strconv.Itoa(page)},
+	}
+}
@@ -0,0 +1,134 @@
+package history
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/git"
+)
+
+// Entry is a changelog entry for a single workflow.
+type Entry struct {
+	diff.Change
+	Commits []git.Commit
+}
+
+// Snapshot parses the workflow files in workflowsDir as they exist at ref.
+// workflowsDir is relative to the root of the repository at repoDir. git is
+// stopped once ctx is done.
+func Snapshot(ctx context.Context, repoDir, ref, workflowsDir string) ([]generate.WorkflowInfo, error) {
+	names, err := git.ListFiles(ctx, repoDir, ref, workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error listing workflows at %s: %v", ref, err)
+	}
+
+	var workflows []generate.WorkflowInfo
+	for _, name := range names {
+		if !generate.IsWorkflowFile(name) {
+			continue
+		}
+
+		content, err := git.Show(ctx, repoDir, ref, path.Join(workflowsDir, name))
+		if err != nil {
+			return nil, fmt.Errorf("error reading workflow %s at %s: %v", name, ref, err)
+		}
+
+		workflow, err := generate.ParseWorkflow(content)
+		if err != nil {
+			slog.Warn("Error parsing workflow file", "file", name, "ref", ref, "error", err)
+			continue
+		}
+		workflow.Filename = name
+		workflows = append(workflows, workflow)
+	}
+
+	return workflows, nil
+}
+
+// Changelog returns an entry for every workflow in workflowsDir that changed
+// between the from and to refs, along with the commits that touched it.
+// workflowsDir may be relative to the current directory or absolute. git is
+// stopped once ctx is done.
+func Changelog(ctx context.Context, workflowsDir, from, to string) ([]Entry, error) {
+	repoDir, err := git.TopLevel(ctx, workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error locating git repository: %v", err)
+	}
+
+	relativeDir, err := git.RelativePath(repoDir, workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error resolving workflows directory: %v", err)
+	}
+
+	before, err := Snapshot(ctx, repoDir, from, relativeDir)
+	if err != nil {
+		return nil, err
+	}
+	after, err := Snapshot(ctx, repoDir, to, relativeDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	for _, change := range diff.Diff(before, after) {
+		commits, err := git.Log(ctx, repoDir, from, to, path.Join(relativeDir, change.Filename))
+		if err != nil {
+			return nil, fmt.Errorf("error reading history of %s: %v", change.Filename, err)
+		}
+		entries = append(entries, Entry{Change: change, Commits: commits})
+	}
+
+	return entries, nil
+}
+
+// RenderChangelog renders changelog entries as a markdown document.
+func RenderChangelog(entries []Entry, workflowsDir, from, to string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Workflow Changelog\n\n")
+	sb.WriteString(fmt.Sprintf("Changes to workflows in `%s` between `%s` and `%s`.\n",
+		filepath.ToSlash(workflowsDir), from, to))
+
+	if len(entries) == 0 {
+		sb.WriteString("\nNo workflow changes.\n")
+		return sb.String()
+	}
+
+	for _, entry := range entries {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", entry.Filename))
+
+		switch entry.Kind {
+		case diff.Added:
+			sb.WriteString("- Added\n")
+		case diff.Removed:
+			sb.WriteString("- Removed\n")
+		}
+
+		if entry.Kind == diff.Modified {
+			if len(entry.AddedTriggers) > 0 {
+				sb.WriteString(fmt.Sprintf("- Triggers added: %s\n", strings.Join(entry.AddedTriggers, ", ")))
+			}
+			if len(entry.RemovedTriggers) > 0 {
+				sb.WriteString(fmt.Sprintf("- Triggers removed: %s\n", strings.Join(entry.RemovedTriggers, ", ")))
+			}
+			if entry.DescriptionChanged() {
+				sb.WriteString(fmt.Sprintf("- Description changed from %q to %q\n", entry.OldDescription, entry.NewDescription))
+			}
+		}
+
+		if len(entry.Commits) > 0 {
+			sb.WriteString("- Commits:\n")
+			for _, commit := range entry.Commits {
+				sb.WriteString(fmt.Sprintf("  - %s %s\n", commit.Hash, commit.Subject))
+			}
+		}
+	}
+
+	return sb.String()
+}
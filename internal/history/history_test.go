@@ -0,0 +1,131 @@
+package history
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// writeWorkflow writes a workflow file into the workflows directory of repo
+func writeWorkflow(t *testing.T, repo, name, content string) {
+	t.Helper()
+	err := os.WriteFile(filepath.Join(repo, ".github", "workflows", name), []byte(content), 0644)
+	if err != nil {
+		t.Fatalf("Failed to write workflow %s: %v", name, err)
+	}
+}
+
+// createRepo creates a git repository with two tagged revisions of a
+// workflows directory
+func createRepo(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	repo := t.TempDir()
+	err := os.MkdirAll(filepath.Join(repo, ".github", "workflows"), 0755)
+	if err != nil {
+		t.Fatalf("Failed to create workflows dir: %v", err)
+	}
+
+	runGit(t, repo, "init", "-q")
+	writeWorkflow(t, repo, "ci.yml", "## Runs CI.\non: push\n")
+	writeWorkflow(t, repo, "old.yml", "## Old workflow.\non: push\n")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "Initial workflows")
+	runGit(t, repo, "tag", "v1")
+
+	writeWorkflow(t, repo, "ci.yml", "## Runs CI on pushes and PRs.\non: [push, pull_request]\n")
+	runGit(t, repo, "rm", "-q", ".github/workflows/old.yml")
+	writeWorkflow(t, repo, "release.yml", "## Publishes releases.\non: workflow_dispatch\n")
+	runGit(t, repo, "add", "-A")
+	runGit(t, repo, "commit", "-q", "-m", "Rework workflows")
+	runGit(t, repo, "tag", "v2")
+
+	return repo
+}
+
+// TestSnapshot tests parsing workflows at a specific ref
+func TestSnapshot(t *testing.T) {
+	repo := createRepo(t)
+
+	workflows, err := Snapshot(context.Background(), repo, "v1", ".github/workflows")
+	if err != nil {
+		t.Fatalf("Snapshot failed: %v", err)
+	}
+
+	if len(workflows) != 2 {
+		t.Fatalf("Expected 2 workflows at v1, got %d", len(workflows))
+	}
+	if workflows[0].Filename != "ci.yml" || workflows[0].Description != "Runs CI." {
+		t.Errorf("Unexpected first workflow: %+v", workflows[0])
+	}
+
+	workflows, err = Snapshot(context.Background(), repo, "v1", "missing")
+	if err != nil {
+		t.Fatalf("Snapshot of missing directory failed: %v", err)
+	}
+	if len(workflows) != 0 {
+		t.Errorf("Expected no workflows for missing directory, got %d", len(workflows))
+	}
+}
+
+// TestChangelog tests generating a changelog between two tags
+func TestChangelog(t *testing.T) {
+	repo := createRepo(t)
+	workflowsDir := filepath.Join(repo, ".github", "workflows")
+
+	entries, err := Changelog(context.Background(), workflowsDir, "v1", "v2")
+	if err != nil {
+		t.Fatalf("Changelog failed: %v", err)
+	}
+
+	changelog := RenderChangelog(entries, ".github/workflows", "v1", "v2")
+	expectedStrings := []string{
+		"# Workflow Changelog",
+		"between `v1` and `v2`",
+		"## ci.yml\n\n- Triggers added: pull_request\n- Description changed from \"Runs CI.\" to \"Runs CI on pushes and PRs.\"\n",
+		"## old.yml\n\n- Removed\n",
+		"## release.yml\n\n- Added\n",
+		" Rework workflows\n",
+	}
+
+	for _, str := range expectedStrings {
+		if !strings.Contains(changelog, str) {
+			t.Errorf("Expected changelog to contain %q, got:\n%s", str, changelog)
+		}
+	}
+}
+
+// TestChangelogNoChanges tests a changelog between identical refs
+func TestChangelogNoChanges(t *testing.T) {
+	repo := createRepo(t)
+
+	entries, err := Changelog(context.Background(), filepath.Join(repo, ".github", "workflows"), "v2", "v2")
+	if err != nil {
+		t.Fatalf("Changelog failed: %v", err)
+	}
+
+	changelog := RenderChangelog(entries, ".github/workflows", "v2", "v2")
+	if !strings.Contains(changelog, "No workflow changes.") {
+		t.Errorf("Expected changelog to report no changes, got:\n%s", changelog)
+	}
+}
@@ -0,0 +1,171 @@
+package hooks
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Marker identifies the git hooks written by gha-docs, so that they can be
+// replaced without overwriting hooks of other tools.
+const Marker = "# ghadoc:hook"
+
+// PreCommitRepo is the repository of the pre-commit framework hooks of
+// gha-docs, and PreCommitHookID the hook checking the documentation.
+const (
+	PreCommitRepo   = "https://github.com/droctothorpe/gha-docs"
+	PreCommitHookID = "gha-docs-check"
+)
+
+// ErrHookExists is returned when a hook that gha-docs did not write is in
+// the way.
+var ErrHookExists = errors.New("hook exists")
+
+// Script returns a pre-commit hook running "gha-docs generate --check" with
+// args, which fails the commit if the documentation is out of date.
+func Script(args []string) string {
+	command := "gha-docs generate"
+	for _, arg := range args {
+		command += " " + quote(arg)
+	}
+
+	return fmt.Sprintf(`#!/bin/sh
+%s
+# Installed by gha-docs hooks install: fails the commit if the documentation
+# of the GitHub Actions workflows is out of date.
+%s --check
+status=$?
+if [ "$status" -eq 2 ]; then
+	echo "Regenerate the documentation with: %s" >&2
+fi
+exit $status
+`, Marker, command, command)
+}
+
+// quote quotes arg for the shell, unless it is safe as it is.
+func quote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=,:@") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// Install writes script as the pre-commit hook of hooksDir. A pre-commit
+// hook gha-docs did not write is only replaced with force.
+func Install(hooksDir, script string, force bool) (string, error) {
+	path := filepath.Join(hooksDir, "pre-commit")
+	existing, err := os.ReadFile(path)
+	if err == nil && !force && !strings.Contains(string(existing), Marker) {
+		return "", fmt.Errorf("%w: %s", ErrHookExists, path)
+	}
+
+	if err := os.MkdirAll(hooksDir, 0755); err != nil {
+		return "", fmt.Errorf("error creating hooks directory: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(script), 0755); err != nil {
+		return "", fmt.Errorf("error writing hook: %v", err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0755); err != nil {
+		return "", fmt.Errorf("error making hook executable: %v", err)
+	}
+	return path, nil
+}
+
+// AddPreCommitEntry adds the gha-docs-check hook at rev, run with args, to
+// the pre-commit framework configuration at path, creating the file if
+// needed. It reports whether the hook was added, which it is not if the
+// configuration already has it.
+func AddPreCommitEntry(path, rev string, args []string) (bool, error) {
+	var document yaml.Node
+	content, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return false, fmt.Errorf("error reading %s: %v", path, err)
+	}
+	if len(content) > 0 {
+		if err := yaml.Unmarshal(content, &document); err != nil {
+			return false, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+	}
+	if len(document.Content) == 0 {
+		document = yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{{Kind: yaml.MappingNode}}}
+	}
+	root := document.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return false, fmt.Errorf("error parsing %s: expected a mapping", path)
+	}
+
+	repos := mappingValue(root, "repos")
+	if repos == nil {
+		repos = &yaml.Node{Kind: yaml.SequenceNode}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: "repos"}, repos)
+	}
+	if repos.Kind != yaml.SequenceNode {
+		return false, fmt.Errorf("error parsing %s: expected repos to be a list", path)
+	}
+	for _, repo := range repos.Content {
+		hooks := mappingValue(repo, "hooks")
+		if hooks == nil {
+			continue
+		}
+		for _, hook := range hooks.Content {
+			if id := mappingValue(hook, "id"); id != nil && id.Value == PreCommitHookID {
+				return false, nil
+			}
+		}
+	}
+
+	entry := preCommitRepo{
+		Repo:  PreCommitRepo,
+		Rev:   rev,
+		Hooks: []preCommitHook{{ID: PreCommitHookID, Args: args}},
+	}
+	var node yaml.Node
+	if err := node.Encode(entry); err != nil {
+		return false, err
+	}
+	repos.Content = append(repos.Content, &node)
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&document); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		return false, fmt.Errorf("error writing %s: %v", path, err)
+	}
+	return true, nil
+}
+
+// preCommitRepo is a repository of a pre-commit framework configuration.
+type preCommitRepo struct {
+	Repo  string          `yaml:"repo"`
+	Rev   string          `yaml:"rev"`
+	Hooks []preCommitHook `yaml:"hooks"`
+}
+
+// preCommitHook is a hook of a repository of a pre-commit framework
+// configuration.
+type preCommitHook struct {
+	ID   string   `yaml:"id"`
+	Args []string `yaml:"args,omitempty"`
+}
+
+// mappingValue returns the value of key in node, or nil if node is not a
+// mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
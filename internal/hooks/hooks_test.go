@@ -0,0 +1,105 @@
+package hooks
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestScript tests the hook script running generate --check with quoted
+// arguments.
+func TestScript(t *testing.T) {
+	script := Script([]string{"-w", ".github/workflows", "--header", "It's docs"})
+	if !strings.HasPrefix(script, "#!/bin/sh\n"+Marker+"\n") {
+		t.Errorf("Expected a shell script with the marker, got:\n%s", script)
+	}
+	expected := `gha-docs generate -w .github/workflows --header 'It'\''s docs' --check`
+	if !strings.Contains(script, expected) {
+		t.Errorf("Expected script to contain %q, got:\n%s", expected, script)
+	}
+}
+
+// TestInstall tests installing the hook, replacing a hook of gha-docs, and
+// refusing to replace other hooks without force.
+func TestInstall(t *testing.T) {
+	hooksDir := filepath.Join(t.TempDir(), "hooks")
+
+	path, err := Install(hooksDir, Script(nil), false)
+	if err != nil {
+		t.Fatalf("Install failed: %v", err)
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Hook not written: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected hook to be executable, got mode %v", info.Mode())
+	}
+	if _, err := Install(hooksDir, Script([]string{"-o", "docs.md"}), false); err != nil {
+		t.Errorf("Expected the hook of gha-docs to be replaced, got %v", err)
+	}
+
+	if err := os.WriteFile(path, []byte("#!/bin/sh\nmake lint\n"), 0644); err != nil {
+		t.Fatalf("Failed to write hook: %v", err)
+	}
+	if _, err := Install(hooksDir, Script(nil), false); !errors.Is(err, ErrHookExists) {
+		t.Errorf("Expected ErrHookExists, got %v", err)
+	}
+	if _, err := Install(hooksDir, Script(nil), true); err != nil {
+		t.Errorf("Expected force to replace the hook, got %v", err)
+	}
+	info, _ = os.Stat(path)
+	if info.Mode().Perm()&0100 == 0 {
+		t.Errorf("Expected replaced hook to be executable, got mode %v", info.Mode())
+	}
+}
+
+// TestAddPreCommitEntry tests adding the hook to a new and an existing
+// pre-commit configuration, once.
+func TestAddPreCommitEntry(t *testing.T) {
+	path := filepath.Join(t.TempDir(), ".pre-commit-config.yaml")
+
+	added, err := AddPreCommitEntry(path, "v1.2.3", []string{"-o", "docs/workflows.md"})
+	if err != nil || !added {
+		t.Fatalf("Expected the entry to be added, got %v, %v", added, err)
+	}
+	content, _ := os.ReadFile(path)
+	expected := `repos:
+  - repo: https://github.com/droctothorpe/gha-docs
+    rev: v1.2.3
+    hooks:
+      - id: gha-docs-check
+        args:
+          - -o
+          - docs/workflows.md
+`
+	if string(content) != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, content)
+	}
+
+	added, err = AddPreCommitEntry(path, "v1.2.3", nil)
+	if err != nil || added {
+		t.Errorf("Expected the entry not to be added twice, got %v, %v", added, err)
+	}
+
+	existing := `# Hooks of the repository
+repos:
+  - repo: https://github.com/pre-commit/pre-commit-hooks
+    rev: v4.6.0
+    hooks:
+      - id: trailing-whitespace
+`
+	if err := os.WriteFile(path, []byte(existing), 0644); err != nil {
+		t.Fatalf("Failed to write configuration: %v", err)
+	}
+	added, err = AddPreCommitEntry(path, "main", nil)
+	if err != nil || !added {
+		t.Fatalf("Expected the entry to be added, got %v, %v", added, err)
+	}
+	content, _ = os.ReadFile(path)
+	if !strings.HasPrefix(string(content), existing) || !strings.Contains(string(content), "      - id: gha-docs-check\n") {
+		t.Errorf("Expected the entry appended to the existing configuration, got:\n%s", content)
+	}
+}
@@ -0,0 +1,164 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// deprecatedRunners maps the labels of runner images GitHub deprecated or
+// retired to the labels replacing them.
+var deprecatedRunners = map[string]string{
+	"ubuntu-16.04": "ubuntu-latest",
+	"ubuntu-18.04": "ubuntu-latest",
+	"ubuntu-20.04": "ubuntu-latest",
+	"macos-10.15":  "macos-latest",
+	"macos-11":     "macos-latest",
+	"macos-12":     "macos-latest",
+	"macos-13":     "macos-latest",
+	"windows-2016": "windows-latest",
+	"windows-2019": "windows-latest",
+}
+
+// deprecatedRunner reports jobs running on deprecated runner images, which
+// GitHub removes, leaving the jobs queued until they time out.
+var deprecatedRunner = Rule{
+	ID:          "deprecated-runner",
+	Description: "Jobs must not run on deprecated runner images",
+	Severity:    SeverityError,
+	check:       checkDeprecatedRunners,
+}
+
+// matrixExpression matches runs-on values taken from the matrix, e.g.
+// "${{ matrix.os }}".
+var matrixExpression = regexp.MustCompile(`^\$\{\{\s*matrix\.([\w-]+)\s*\}\}$`)
+
+func checkDeprecatedRunners(wf *Workflow, opts Options) []Violation {
+	var violations []Violation
+	for _, job := range wf.jobs() {
+		_, runsOn := mappingEntry(job.node, "runs-on")
+		for _, label := range runnerLabels(job, runsOn) {
+			replacement, ok := deprecatedRunners[strings.ToLower(strings.TrimSpace(label.Value))]
+			if !ok {
+				continue
+			}
+			violations = append(violations, Violation{
+				Line:    label.Line,
+				Message: fmt.Sprintf("job %s runs on the deprecated runner image %s, use %s or a newer version", job.id, label.Value, replacement),
+			})
+		}
+	}
+	return violations
+}
+
+// runnerLabels returns the label nodes of the runs-on value of job, given as
+// a label, a list of labels, or a mapping with labels. Labels taken from the
+// matrix, e.g. ${{ matrix.os }}, are looked up in the matrix of the job.
+func runnerLabels(job job, runsOn *yaml.Node) []*yaml.Node {
+	if runsOn == nil {
+		return nil
+	}
+	switch runsOn.Kind {
+	case yaml.ScalarNode:
+		match := matrixExpression.FindStringSubmatch(strings.TrimSpace(runsOn.Value))
+		if match == nil {
+			return []*yaml.Node{runsOn}
+		}
+		_, strategy := mappingEntry(job.node, "strategy")
+		_, matrix := mappingEntry(strategy, "matrix")
+		_, values := mappingEntry(matrix, match[1])
+		var labels []*yaml.Node
+		if values != nil && values.Kind == yaml.SequenceNode {
+			labels = append(labels, values.Content...)
+		}
+		_, include := mappingEntry(matrix, "include")
+		if include != nil && include.Kind == yaml.SequenceNode {
+			for _, entry := range include.Content {
+				if _, value := mappingEntry(entry, match[1]); value != nil {
+					labels = append(labels, value)
+				}
+			}
+		}
+		return scalars(labels)
+	case yaml.SequenceNode:
+		return scalars(runsOn.Content)
+	case yaml.MappingNode:
+		_, labels := mappingEntry(runsOn, "labels")
+		if labels != nil && labels.Kind == yaml.SequenceNode {
+			return scalars(labels.Content)
+		}
+		if labels != nil {
+			return scalars([]*yaml.Node{labels})
+		}
+	}
+	return nil
+}
+
+// scalars returns the scalar nodes of nodes.
+func scalars(nodes []*yaml.Node) []*yaml.Node {
+	var result []*yaml.Node
+	for _, node := range nodes {
+		if node.Kind == yaml.ScalarNode {
+			result = append(result, node)
+		}
+	}
+	return result
+}
+
+// deprecatedCommands maps the workflow commands GitHub deprecated to the
+// environment files replacing them.
+var deprecatedCommands = map[string]string{
+	"set-output": "GITHUB_OUTPUT",
+	"save-state": "GITHUB_STATE",
+	"set-env":    "GITHUB_ENV",
+	"add-path":   "GITHUB_PATH",
+}
+
+// workflowCommand matches the deprecated workflow commands printed by run
+// steps, e.g. echo "::set-output name=version::1.0".
+var workflowCommand = regexp.MustCompile(`::(set-output|save-state|set-env|add-path)[\s:]`)
+
+// deprecatedCommand reports run steps printing deprecated workflow commands,
+// which runners ignore or warn about, rather than writing to the
+// environment files replacing them.
+var deprecatedCommand = Rule{
+	ID:          "deprecated-command",
+	Description: "Run steps must not use deprecated workflow commands",
+	Severity:    SeverityError,
+	check:       checkDeprecatedCommands,
+}
+
+func checkDeprecatedCommands(wf *Workflow, opts Options) []Violation {
+	var violations []Violation
+	for _, job := range wf.jobs() {
+		for _, step := range job.steps() {
+			_, run := mappingEntry(step, "run")
+			if run == nil || run.Kind != yaml.ScalarNode {
+				continue
+			}
+			for i, line := range strings.Split(run.Value, "\n") {
+				match := workflowCommand.FindStringSubmatch(line)
+				if match == nil {
+					continue
+				}
+				violations = append(violations, Violation{
+					Line:    scriptLine(run, i),
+					Message: fmt.Sprintf("the %s workflow command is deprecated, write to the file of $%s instead", match[1], deprecatedCommands[match[1]]),
+				})
+			}
+		}
+	}
+	return violations
+}
+
+// scriptLine returns the line in the workflow file of line i of the script
+// run. The lines of literal blocks start on the line after the run key,
+// while other scalars are reported on the line of the run key.
+func scriptLine(run *yaml.Node, i int) int {
+	if run.Style&yaml.LiteralStyle != 0 {
+		return run.Line + 1 + i
+	}
+	return run.Line
+}
@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestDeprecatedRunner tests reporting jobs running on deprecated runner
+// images given as labels, lists, label mappings, and matrix values.
+func TestDeprecatedRunner(t *testing.T) {
+	content := `on: push
+jobs:
+  old:
+    runs-on: ubuntu-18.04
+  current:
+    runs-on: ubuntu-latest
+  list:
+    runs-on: [self-hosted, macos-11]
+  group:
+    runs-on:
+      group: large
+      labels: windows-2019
+  matrix:
+    runs-on: ${{ matrix.os }}
+    strategy:
+      matrix:
+        os: [ubuntu-24.04, ubuntu-20.04]
+        include:
+          - os: macos-12
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "deprecated-runner")
+	expected := []int{4, 8, 12, 17, 19}
+	if len(violations) != len(expected) {
+		t.Fatalf("Expected violations on lines %v, got %v", expected, violations)
+	}
+	for i, line := range expected {
+		if violations[i].Line != line {
+			t.Errorf("Expected a violation on line %d, got %v", line, violations[i])
+		}
+	}
+	if !strings.Contains(violations[0].Message, "job old runs on the deprecated runner image ubuntu-18.04, use ubuntu-latest") {
+		t.Errorf("Unexpected message: %s", violations[0].Message)
+	}
+}
+
+// TestDeprecatedCommand tests reporting deprecated workflow commands in run
+// steps on the lines of the workflow file they are on.
+func TestDeprecatedCommand(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo "::set-output name=version::1.0"
+      - run: |
+          make build
+
+          echo "::save-state name=pid::$PID"
+          echo "version=1.0" >> "$GITHUB_OUTPUT"
+      - run: echo "::warning::not deprecated"
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "deprecated-command")
+	if len(violations) != 2 || violations[0].Line != 6 || violations[1].Line != 10 {
+		t.Fatalf("Expected violations on lines 6 and 10, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, "set-output workflow command is deprecated, write to the file of $GITHUB_OUTPUT") {
+		t.Errorf("Unexpected violation: %v", violations[0])
+	}
+	if !strings.Contains(violations[1].Message, "$GITHUB_STATE") {
+		t.Errorf("Unexpected message: %s", violations[1].Message)
+	}
+}
@@ -0,0 +1,273 @@
+package lint
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"gopkg.in/yaml.v3"
+)
+
+// Severity is how serious a violation of a rule is.
+type Severity string
+
+const (
+	SeverityError   Severity = "error"   // Fails the lint
+	SeverityWarning Severity = "warning" // Reported without failing the lint
+	SeverityOff     Severity = "off"     // Not checked
+)
+
+// Rule is a check of the workflows, such as that actions are pinned.
+type Rule struct {
+	ID          string
+	Description string
+	Severity    Severity // Default severity of the violations
+	check       func(wf *Workflow, opts Options) []Violation
+}
+
+// Rules lists the rules in the order they are checked.
+var Rules = []Rule{
+	unpinnedAction,
+	deprecatedRunner,
+	deprecatedCommand,
+	writeAllPermissions,
+	missingPermissions,
+	missingTimeout,
+}
+
+// findRule returns the rule with id.
+func findRule(id string) (Rule, bool) {
+	for _, rule := range Rules {
+		if rule.ID == id {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+// Violation is a place a workflow breaks a rule.
+type Violation struct {
+	File     string   `json:"file"`
+	Line     int      `json:"line"`
+	Rule     string   `json:"rule"`
+	Severity Severity `json:"severity"`
+	Message  string   `json:"message"`
+}
+
+// String formats the violation as file:line: severity: message (rule).
+func (v Violation) String() string {
+	return fmt.Sprintf("%s:%d: %s: %s (%s)", v.File, v.Line, v.Severity, v.Message, v.Rule)
+}
+
+// Options configure the rules.
+type Options struct {
+	// Severities override the default severity of rules by ID. SeverityOff
+	// disables a rule.
+	Severities map[string]Severity
+	// TrustedOwners are the owners, e.g. "actions", or repositories, e.g.
+	// "docker/build-push-action", whose actions may be referenced by a tag
+	// or branch rather than a commit SHA.
+	TrustedOwners []string
+	// MaxTimeoutMinutes is the longest timeout-minutes jobs may set, or 0 for
+	// no maximum.
+	MaxTimeoutMinutes int
+	// ScanOptions select the workflow files of a directory by extension.
+	ScanOptions generate.ScanOptions
+}
+
+// severity returns the severity of rule with opts.
+func (opts Options) severity(rule Rule) Severity {
+	if severity, ok := opts.Severities[rule.ID]; ok {
+		return severity
+	}
+	return rule.Severity
+}
+
+// ParseSeverities parses severities given as rule=severity, e.g.
+// "unpinned-action=warning".
+func ParseSeverities(specs []string) (map[string]Severity, error) {
+	severities := make(map[string]Severity)
+	for _, spec := range specs {
+		id, value, ok := strings.Cut(spec, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid severity %q, expected RULE=SEVERITY", spec)
+		}
+		id = strings.TrimSpace(id)
+		if _, ok := findRule(id); !ok {
+			return nil, fmt.Errorf("unknown rule %q in severity %q", id, spec)
+		}
+		severity := Severity(strings.ToLower(strings.TrimSpace(value)))
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityOff:
+		default:
+			return nil, fmt.Errorf("invalid severity %q of rule %s, expected error, warning, or off", value, id)
+		}
+		severities[id] = severity
+	}
+	return severities, nil
+}
+
+// Workflow is a workflow file being linted.
+type Workflow struct {
+	Filename string
+	root     *yaml.Node // Mapping of the workflow
+}
+
+// job is a job of a workflow.
+type job struct {
+	id   string
+	key  *yaml.Node // The key of the job, for its line
+	node *yaml.Node // The mapping of the job
+}
+
+// jobs returns the jobs of the workflow in the order they are defined.
+func (wf *Workflow) jobs() []job {
+	_, jobsNode := mappingEntry(wf.root, "jobs")
+	if jobsNode == nil || jobsNode.Kind != yaml.MappingNode {
+		return nil
+	}
+	var jobs []job
+	for i := 0; i+1 < len(jobsNode.Content); i += 2 {
+		if jobsNode.Content[i+1].Kind == yaml.MappingNode {
+			jobs = append(jobs, job{id: jobsNode.Content[i].Value, key: jobsNode.Content[i], node: jobsNode.Content[i+1]})
+		}
+	}
+	return jobs
+}
+
+// steps returns the mappings of the steps of the job.
+func (j job) steps() []*yaml.Node {
+	_, stepsNode := mappingEntry(j.node, "steps")
+	if stepsNode == nil || stepsNode.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var steps []*yaml.Node
+	for _, step := range stepsNode.Content {
+		if step.Kind == yaml.MappingNode {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// mappingEntry returns the key and value nodes of key in node, or nils if
+// node is not a mapping or has no such key.
+func mappingEntry(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// LintWorkflow checks the content of the workflow file filename against the
+// rules enabled by opts.
+func LintWorkflow(filename string, content []byte, opts Options) ([]Violation, error) {
+	var document yaml.Node
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, err
+	}
+	if len(document.Content) == 0 || document.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("not a workflow: expected a mapping")
+	}
+	wf := &Workflow{Filename: filename, root: document.Content[0]}
+
+	var violations []Violation
+	for _, rule := range Rules {
+		severity := opts.severity(rule)
+		if severity == SeverityOff {
+			continue
+		}
+		for _, violation := range rule.check(wf, opts) {
+			violation.File = filename
+			violation.Rule = rule.ID
+			violation.Severity = severity
+			violations = append(violations, violation)
+		}
+	}
+	return violations, nil
+}
+
+// Lint checks the workflow files of workflowsDir against the rules enabled
+// by opts. The violations are sorted by file and line. Files that fail to
+// parse are returned as parse errors rather than failing the lint.
+func Lint(workflowsDir string, opts Options) ([]Violation, []generate.ParseError, error) {
+	entries, err := os.ReadDir(workflowsDir)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error reading workflows directory: %v", err)
+	}
+
+	var violations []Violation
+	var parseErrors []generate.ParseError
+	for _, entry := range entries {
+		if entry.IsDir() || !opts.ScanOptions.IsWorkflowFile(entry.Name()) {
+			continue
+		}
+		content, err := os.ReadFile(filepath.Join(workflowsDir, entry.Name()))
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading workflow file: %v", err)
+		}
+		found, err := LintWorkflow(entry.Name(), content, opts)
+		if err != nil {
+			parseErrors = append(parseErrors, generate.ParseError{Filename: entry.Name(), Err: err})
+			continue
+		}
+		violations = append(violations, found...)
+	}
+
+	sort.SliceStable(violations, func(i, j int) bool {
+		if violations[i].File != violations[j].File {
+			return violations[i].File < violations[j].File
+		}
+		return violations[i].Line < violations[j].Line
+	})
+	return violations, parseErrors, nil
+}
+
+// Errors returns the number of violations of severity error.
+func Errors(violations []Violation) int {
+	count := 0
+	for _, violation := range violations {
+		if violation.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+// Formats of Render.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Render renders the violations as text, one per line, or as a JSON list.
+func Render(violations []Violation, format string) (string, error) {
+	switch format {
+	case FormatText:
+		var sb strings.Builder
+		for _, violation := range violations {
+			sb.WriteString(violation.String() + "\n")
+		}
+		return sb.String(), nil
+	case FormatJSON:
+		if violations == nil {
+			violations = []Violation{}
+		}
+		content, err := json.MarshalIndent(violations, "", "  ")
+		if err != nil {
+			return "", err
+		}
+		return string(content) + "\n", nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected %s or %s", format, FormatText, FormatJSON)
+	}
+}
@@ -0,0 +1,115 @@
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestParseSeverities tests parsing rule=severity overrides.
+func TestParseSeverities(t *testing.T) {
+	severities, err := ParseSeverities([]string{"unpinned-action=Warning"})
+	if err != nil {
+		t.Fatalf("ParseSeverities failed: %v", err)
+	}
+	if severities["unpinned-action"] != SeverityWarning {
+		t.Errorf("Expected warning, got %q", severities["unpinned-action"])
+	}
+
+	for _, spec := range []string{"unpinned-action", "no-such-rule=error", "unpinned-action=fatal"} {
+		if _, err := ParseSeverities([]string{spec}); err == nil {
+			t.Errorf("Expected an error for %q", spec)
+		}
+	}
+}
+
+// TestLint tests linting a directory: violations are sorted by file and
+// line, severities are overridden, and files that fail to parse are
+// returned as parse errors.
+func TestLint(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"b.yml": `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`,
+		"a.yml": `on: push
+jobs:
+  build:
+    uses: octo/workflows/.github/workflows/build.yml@main
+`,
+		"broken.yml": "on: [push\n",
+		"notes.txt":  "not a workflow",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	violations, parseErrors, err := Lint(dir, Options{})
+	if err != nil {
+		t.Fatalf("Lint failed: %v", err)
+	}
+	violations = ruleViolations(violations, "unpinned-action")
+	if len(parseErrors) != 1 || parseErrors[0].Filename != "broken.yml" {
+		t.Errorf("Expected broken.yml to fail to parse, got %v", parseErrors)
+	}
+	if len(violations) != 2 || violations[0].File != "a.yml" || violations[0].Line != 4 || violations[1].File != "b.yml" || violations[1].Line != 6 {
+		t.Fatalf("Expected violations in a.yml:4 and b.yml:6, got %v", violations)
+	}
+	if Errors(violations) != 2 {
+		t.Errorf("Expected 2 errors, got %d", Errors(violations))
+	}
+
+	violations, _, _ = Lint(dir, Options{Severities: map[string]Severity{"unpinned-action": SeverityWarning}})
+	violations = ruleViolations(violations, "unpinned-action")
+	if len(violations) != 2 || Errors(violations) != 0 {
+		t.Errorf("Expected 2 warnings, got %v", violations)
+	}
+	violations, _, _ = Lint(dir, Options{Severities: map[string]Severity{"unpinned-action": SeverityOff}})
+	violations = ruleViolations(violations, "unpinned-action")
+	if len(violations) != 0 {
+		t.Errorf("Expected the rule to be off, got %v", violations)
+	}
+}
+
+// TestRender tests rendering violations as text and JSON.
+func TestRender(t *testing.T) {
+	violations := []Violation{{File: "ci.yml", Line: 7, Rule: "unpinned-action", Severity: SeverityError, Message: "pin it"}}
+
+	text, err := Render(violations, FormatText)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if text != "ci.yml:7: error: pin it (unpinned-action)\n" {
+		t.Errorf("Unexpected text: %q", text)
+	}
+
+	content, err := Render(violations, FormatJSON)
+	if err != nil || !strings.Contains(content, `"rule": "unpinned-action"`) {
+		t.Errorf("Unexpected JSON: %s, %v", content, err)
+	}
+	if empty, _ := Render(nil, FormatJSON); empty != "[]\n" {
+		t.Errorf("Expected an empty list, got %q", empty)
+	}
+	if _, err := Render(violations, "xml"); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
+
+// ruleViolations returns the violations of the rule id, so that tests of a
+// rule are not affected by the others.
+func ruleViolations(violations []Violation, id string) []Violation {
+	var result []Violation
+	for _, violation := range violations {
+		if violation.Rule == id {
+			result = append(result, violation)
+		}
+	}
+	return result
+}
@@ -0,0 +1,68 @@
+package lint
+
+import (
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// writeAllPermissions reports workflows and jobs granting the GITHUB_TOKEN
+// write access to every scope with "permissions: write-all".
+var writeAllPermissions = Rule{
+	ID:          "write-all-permissions",
+	Description: "Workflows and jobs must not grant write-all permissions",
+	Severity:    SeverityError,
+	check:       checkWriteAllPermissions,
+}
+
+func checkWriteAllPermissions(wf *Workflow, opts Options) []Violation {
+	var violations []Violation
+	if _, permissions := mappingEntry(wf.root, "permissions"); writeAll(permissions) {
+		violations = append(violations, Violation{
+			Line:    permissions.Line,
+			Message: "the workflow grants write-all permissions, grant only the scopes it needs",
+		})
+	}
+	for _, job := range wf.jobs() {
+		if _, permissions := mappingEntry(job.node, "permissions"); writeAll(permissions) {
+			violations = append(violations, Violation{
+				Line:    permissions.Line,
+				Message: fmt.Sprintf("job %s grants write-all permissions, grant only the scopes it needs", job.id),
+			})
+		}
+	}
+	return violations
+}
+
+// writeAll reports whether permissions is "write-all".
+func writeAll(permissions *yaml.Node) bool {
+	return permissions != nil && permissions.Kind == yaml.ScalarNode && strings.TrimSpace(permissions.Value) == "write-all"
+}
+
+// missingPermissions reports jobs whose permissions neither the workflow nor
+// the job sets, so that they inherit the default permissions of the
+// repository or organization, which may allow writes.
+var missingPermissions = Rule{
+	ID:          "missing-permissions",
+	Description: "Workflows or their jobs must set permissions",
+	Severity:    SeverityWarning,
+	check:       checkMissingPermissions,
+}
+
+func checkMissingPermissions(wf *Workflow, opts Options) []Violation {
+	if permissions, _ := mappingEntry(wf.root, "permissions"); permissions != nil {
+		return nil
+	}
+	var violations []Violation
+	for _, job := range wf.jobs() {
+		if permissions, _ := mappingEntry(job.node, "permissions"); permissions != nil {
+			continue
+		}
+		violations = append(violations, Violation{
+			Line:    job.key.Line,
+			Message: fmt.Sprintf("job %s has no permissions and inherits the default permissions, set permissions on the workflow or the job", job.id),
+		})
+	}
+	return violations
+}
@@ -0,0 +1,67 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestWriteAllPermissions tests reporting write-all permissions of the
+// workflow and of jobs.
+func TestWriteAllPermissions(t *testing.T) {
+	content := `on: push
+permissions: write-all
+jobs:
+  build:
+    permissions: write-all
+    runs-on: ubuntu-latest
+  test:
+    permissions:
+      contents: write
+    runs-on: ubuntu-latest
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "write-all-permissions")
+	if len(violations) != 2 || violations[0].Line != 2 || violations[1].Line != 5 {
+		t.Fatalf("Expected violations on lines 2 and 5, got %v", violations)
+	}
+	if violations[0].Severity != SeverityError || !strings.Contains(violations[1].Message, "job build grants write-all permissions") {
+		t.Errorf("Unexpected violations: %v", violations)
+	}
+}
+
+// TestMissingPermissions tests reporting jobs without permissions of their
+// own or of the workflow.
+func TestMissingPermissions(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  test:
+    permissions: {}
+    runs-on: ubuntu-latest
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "missing-permissions")
+	if len(violations) != 1 || violations[0].Line != 3 || violations[0].Severity != SeverityWarning {
+		t.Fatalf("Expected a warning on line 3, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, "job build has no permissions") {
+		t.Errorf("Unexpected message: %s", violations[0].Message)
+	}
+
+	violations, _ = LintWorkflow("ci.yml", []byte("on: push\npermissions:\n  contents: read\n"+content[len("on: push\n"):]), Options{})
+	if violations = ruleViolations(violations, "missing-permissions"); len(violations) != 0 {
+		t.Errorf("Expected the permissions of the workflow to apply to its jobs, got %v", violations)
+	}
+
+	violations, _ = LintWorkflow("ci.yml", []byte(content), Options{Severities: map[string]Severity{"missing-permissions": SeverityError}})
+	if violations = ruleViolations(violations, "missing-permissions"); len(violations) != 1 || violations[0].Severity != SeverityError {
+		t.Errorf("Expected the severity to be overridden, got %v", violations)
+	}
+}
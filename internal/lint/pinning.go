@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// commitSHA matches full commit SHAs, of SHA-1 or SHA-256 repositories.
+var commitSHA = regexp.MustCompile(`^(?:[0-9a-f]{40}|[0-9a-f]{64})$`)
+
+// unpinnedAction reports actions and reusable workflows of other
+// repositories referenced by a branch or tag, which their owners can move to
+// different code at any time, rather than by a full commit SHA.
+var unpinnedAction = Rule{
+	ID:          "unpinned-action",
+	Description: "Actions of other repositories must be pinned to a full commit SHA",
+	Severity:    SeverityError,
+	check:       checkUnpinnedActions,
+}
+
+func checkUnpinnedActions(wf *Workflow, opts Options) []Violation {
+	var violations []Violation
+	check := func(uses *yaml.Node) {
+		if uses == nil || uses.Kind != yaml.ScalarNode {
+			return
+		}
+		repo, ref, ok := remoteAction(uses.Value)
+		if !ok || commitSHA.MatchString(ref) || trusted(repo, opts.TrustedOwners) {
+			return
+		}
+		message := fmt.Sprintf("%s is pinned to the mutable ref %q, pin it to a full commit SHA", uses.Value, ref)
+		if ref == "" {
+			message = fmt.Sprintf("%s is not pinned to a ref, pin it to a full commit SHA", uses.Value)
+		}
+		violations = append(violations, Violation{Line: uses.Line, Message: message})
+	}
+
+	for _, job := range wf.jobs() {
+		// Reusable workflows
+		_, uses := mappingEntry(job.node, "uses")
+		check(uses)
+		for _, step := range job.steps() {
+			_, uses := mappingEntry(step, "uses")
+			check(uses)
+		}
+	}
+	return violations
+}
+
+// remoteAction splits uses, e.g. "actions/checkout@v4", into the repository
+// of the action and its ref. Local actions and Docker images are not remote
+// actions.
+func remoteAction(uses string) (repo, ref string, ok bool) {
+	uses = strings.TrimSpace(uses)
+	if uses == "" || strings.HasPrefix(uses, "./") || strings.HasPrefix(uses, "docker://") || strings.Contains(uses, "${{") {
+		return "", "", false
+	}
+	path, ref, _ := strings.Cut(uses, "@")
+	parts := strings.SplitN(path, "/", 3)
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	return parts[0] + "/" + parts[1], ref, true
+}
+
+// trusted reports whether repo, e.g. "actions/checkout", belongs to one of
+// the trusted owners or repositories.
+func trusted(repo string, trustedOwners []string) bool {
+	owner, _, _ := strings.Cut(repo, "/")
+	for _, entry := range trustedOwners {
+		entry = strings.TrimSuffix(strings.TrimSpace(entry), "/")
+		if strings.EqualFold(entry, owner) || strings.EqualFold(entry, repo) {
+			return true
+		}
+	}
+	return false
+}
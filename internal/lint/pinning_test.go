@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestUnpinnedAction tests reporting actions and reusable workflows not
+// pinned to a commit SHA, and allowing trusted owners.
+func TestUnpinnedAction(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: actions/setup-go@0a12ed9d6a96ab950c8f026ed9f722fe0da7ef32
+      - uses: docker/build-push-action@master
+      - uses: ./.github/actions/local
+      - uses: docker://alpine:3.20
+      - uses: octo/tools/lint@v1.2.3
+      - run: make
+  deploy:
+    uses: octo/workflows/.github/workflows/deploy.yml@main
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "unpinned-action")
+	var lines []int
+	for _, violation := range violations {
+		lines = append(lines, violation.Line)
+	}
+	if len(lines) != 4 || lines[0] != 6 || lines[1] != 8 || lines[2] != 11 || lines[3] != 14 {
+		t.Fatalf("Expected violations on lines 6, 8, 11, and 14, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, `actions/checkout@v4 is pinned to the mutable ref "v4"`) {
+		t.Errorf("Unexpected message: %s", violations[0].Message)
+	}
+
+	violations, _ = LintWorkflow("ci.yml", []byte(content), Options{TrustedOwners: []string{"actions", "Docker/Build-Push-Action", "octo"}})
+	violations = ruleViolations(violations, "unpinned-action")
+	if len(violations) != 0 {
+		t.Errorf("Expected trusted owners to be allowed, got %v", violations)
+	}
+}
@@ -0,0 +1,54 @@
+package lint
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultTimeoutMinutes is how long GitHub lets jobs without timeout-minutes
+// run before canceling them.
+const defaultTimeoutMinutes = 360
+
+// missingTimeout reports jobs without timeout-minutes, which a hung step
+// keeps running for six hours, and with Options.MaxTimeoutMinutes jobs whose
+// timeout exceeds it.
+var missingTimeout = Rule{
+	ID:          "missing-timeout",
+	Description: "Jobs must set timeout-minutes",
+	Severity:    SeverityWarning,
+	check:       checkMissingTimeouts,
+}
+
+func checkMissingTimeouts(wf *Workflow, opts Options) []Violation {
+	var violations []Violation
+	for _, job := range wf.jobs() {
+		// Jobs calling reusable workflows cannot set timeout-minutes
+		if uses, _ := mappingEntry(job.node, "uses"); uses != nil {
+			continue
+		}
+		_, timeout := mappingEntry(job.node, "timeout-minutes")
+		if timeout == nil {
+			violations = append(violations, Violation{
+				Line:    job.key.Line,
+				Message: fmt.Sprintf("job %s has no timeout-minutes and may run for %d minutes, set timeout-minutes", job.id, defaultTimeoutMinutes),
+			})
+			continue
+		}
+		if opts.MaxTimeoutMinutes <= 0 {
+			continue
+		}
+		// Expressions are only known when the workflow runs
+		minutes, err := strconv.ParseFloat(strings.TrimSpace(timeout.Value), 64)
+		if err != nil {
+			continue
+		}
+		if minutes > float64(opts.MaxTimeoutMinutes) {
+			violations = append(violations, Violation{
+				Line:    timeout.Line,
+				Message: fmt.Sprintf("job %s has a timeout of %s minutes, more than the maximum of %d", job.id, timeout.Value, opts.MaxTimeoutMinutes),
+			})
+		}
+	}
+	return violations
+}
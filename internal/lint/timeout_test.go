@@ -0,0 +1,47 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestMissingTimeout tests reporting jobs without timeout-minutes, and with
+// a maximum jobs whose timeout exceeds it.
+func TestMissingTimeout(t *testing.T) {
+	content := `on: push
+jobs:
+  build:
+    runs-on: ubuntu-latest
+  test:
+    runs-on: ubuntu-latest
+    timeout-minutes: 30
+  e2e:
+    runs-on: ubuntu-latest
+    timeout-minutes: 120
+  nightly:
+    runs-on: ubuntu-latest
+    timeout-minutes: ${{ inputs.timeout }}
+  deploy:
+    uses: ./.github/workflows/deploy.yml
+`
+	violations, err := LintWorkflow("ci.yml", []byte(content), Options{})
+	if err != nil {
+		t.Fatalf("LintWorkflow failed: %v", err)
+	}
+	violations = ruleViolations(violations, "missing-timeout")
+	if len(violations) != 1 || violations[0].Line != 3 || violations[0].Severity != SeverityWarning {
+		t.Fatalf("Expected a warning on line 3, got %v", violations)
+	}
+	if !strings.Contains(violations[0].Message, "job build has no timeout-minutes and may run for 360 minutes") {
+		t.Errorf("Unexpected message: %s", violations[0].Message)
+	}
+
+	violations, _ = LintWorkflow("ci.yml", []byte(content), Options{MaxTimeoutMinutes: 60})
+	violations = ruleViolations(violations, "missing-timeout")
+	if len(violations) != 2 || violations[1].Line != 10 {
+		t.Fatalf("Expected violations on lines 3 and 10, got %v", violations)
+	}
+	if !strings.Contains(violations[1].Message, "job e2e has a timeout of 120 minutes, more than the maximum of 60") {
+		t.Errorf("Unexpected message: %s", violations[1].Message)
+	}
+}
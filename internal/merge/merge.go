@@ -0,0 +1,72 @@
+package merge
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// Load reads the JSON documents written by `generate --format json` from
+// each of paths. Documents without a source are named after their file.
+func Load(paths []string) ([]generate.Document, error) {
+	var documents []generate.Document
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading %s: %v", path, err)
+		}
+
+		var document generate.Document
+		if err := json.Unmarshal(content, &document); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %v", path, err)
+		}
+		if document.Source == "" {
+			document.Source = path
+		}
+		documents = append(documents, document)
+	}
+	return documents, nil
+}
+
+// Render renders the documents as a single markdown report with an overview
+// of all sources followed by the workflow table of each source.
+func Render(documents []generate.Document) string {
+	var sb strings.Builder
+
+	total := 0
+	for _, document := range documents {
+		total += len(document.Workflows)
+	}
+
+	sb.WriteString("# GitHub Workflows Report\n\n")
+	sb.WriteString(fmt.Sprintf("%d workflows across %d sources.\n\n", total, len(documents)))
+
+	sb.WriteString("| Source | Workflows |\n")
+	sb.WriteString("| --- | --- |\n")
+	for _, document := range documents {
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", document.Source, len(document.Workflows)))
+	}
+
+	for _, document := range documents {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", document.Source))
+
+		if len(document.Workflows) == 0 {
+			sb.WriteString("_No workflows._\n")
+			continue
+		}
+
+		sb.WriteString("| Filename | Description | Triggers |\n")
+		sb.WriteString("| --- | --- | --- |\n")
+		for _, workflow := range document.Workflows {
+			sb.WriteString(fmt.Sprintf("| %s | %s | %s |\n",
+				workflow.Filename,
+				workflow.Description,
+				strings.Join(workflow.Triggers, ", ")))
+		}
+	}
+
+	return sb.String()
+}
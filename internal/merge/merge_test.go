@@ -0,0 +1,67 @@
+package merge
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeDocument writes a JSON document into dir and returns its path
+func writeDocument(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestMerge tests merging JSON outputs into a single report
+func TestMerge(t *testing.T) {
+	dir := t.TempDir()
+	api := writeDocument(t, dir, "api.json", `{
+  "source": "services/api/.github/workflows",
+  "workflows": [
+    {"filename": "ci.yml", "description": "Runs CI.", "triggers": ["pull_request", "push"]},
+    {"filename": "deploy.yml", "description": "Deploys.", "triggers": ["workflow_dispatch"]}
+  ]
+}`)
+	web := writeDocument(t, dir, "web.json", `{"workflows": []}`)
+
+	documents, err := Load([]string{api, web})
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if documents[1].Source != web {
+		t.Errorf("Expected source to default to the file path %q, got %q", web, documents[1].Source)
+	}
+
+	content := Render(documents)
+	for _, expected := range []string{
+		"2 workflows across 2 sources.",
+		"| services/api/.github/workflows | 2 |",
+		"## services/api/.github/workflows\n\n| Filename | Description | Triggers |",
+		"| ci.yml | Runs CI. | pull_request, push |",
+		"| deploy.yml | Deploys. | workflow_dispatch |",
+		"## " + web + "\n\n_No workflows._\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// TestLoadErrors tests errors for missing and invalid inputs
+func TestLoadErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	if _, err := Load([]string{filepath.Join(dir, "missing.json")}); err == nil || !strings.Contains(err.Error(), "error reading") {
+		t.Errorf("Expected read error, got %v", err)
+	}
+
+	invalid := writeDocument(t, dir, "invalid.json", `not json`)
+	if _, err := Load([]string{invalid}); err == nil || !strings.Contains(err.Error(), "error parsing") {
+		t.Errorf("Expected parse error, got %v", err)
+	}
+}
@@ -0,0 +1,133 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Supported export formats.
+const (
+	FormatGrafana    = "grafana"
+	FormatPrometheus = "prometheus"
+)
+
+// grafanaColumn describes a column of a Grafana JSON datasource table.
+type grafanaColumn struct {
+	Text string `json:"text"`
+	Type string `json:"type"`
+}
+
+// grafanaTable is a table response of a Grafana JSON datasource.
+type grafanaTable struct {
+	Type    string          `json:"type"`
+	Columns []grafanaColumn `json:"columns"`
+	Rows    [][]interface{} `json:"rows"`
+}
+
+// RenderGrafana renders stats as a Grafana JSON datasource table response.
+// Durations are in seconds and timestamps in milliseconds since the epoch.
+func RenderGrafana(stats []Stats) (string, error) {
+	table := grafanaTable{
+		Type: "table",
+		Columns: []grafanaColumn{
+			{Text: "Workflow", Type: "string"},
+			{Text: "Runs", Type: "number"},
+			{Text: "Successes", Type: "number"},
+			{Text: "Failures", Type: "number"},
+			{Text: "Success Rate", Type: "number"},
+			{Text: "Average Duration", Type: "number"},
+			{Text: "Median Duration", Type: "number"},
+			{Text: "Last Conclusion", Type: "string"},
+			{Text: "Last Run", Type: "time"},
+		},
+		Rows: [][]interface{}{},
+	}
+
+	for _, s := range stats {
+		var lastRun interface{}
+		if !s.LastRunAt.IsZero() {
+			lastRun = s.LastRunAt.UnixMilli()
+		}
+		table.Rows = append(table.Rows, []interface{}{
+			s.Workflow,
+			s.Runs,
+			s.Successes,
+			s.Failures,
+			s.SuccessRate,
+			s.AverageDuration.Seconds(),
+			s.MedianDuration.Seconds(),
+			s.LastConclusion,
+			lastRun,
+		})
+	}
+
+	content, err := json.MarshalIndent([]grafanaTable{table}, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
+
+// labelEscaper escapes label values for the Prometheus text format.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// RenderPrometheus renders stats in the Prometheus text exposition format,
+// labelled with the repository and workflow.
+func RenderPrometheus(repo string, stats []Stats) string {
+	metrics := []struct {
+		name  string
+		help  string
+		value func(Stats) float64
+	}{
+		{"ghadoc_workflow_runs", "Completed workflow runs considered.", func(s Stats) float64 { return float64(s.Runs) }},
+		{"ghadoc_workflow_successes", "Workflow runs that concluded successfully.", func(s Stats) float64 { return float64(s.Successes) }},
+		{"ghadoc_workflow_failures", "Workflow runs that failed or timed out.", func(s Stats) float64 { return float64(s.Failures) }},
+		{"ghadoc_workflow_success_ratio", "Ratio of successful to decided workflow runs.", func(s Stats) float64 { return s.SuccessRate }},
+		{"ghadoc_workflow_duration_average_seconds", "Average duration of workflow runs.", func(s Stats) float64 { return s.AverageDuration.Seconds() }},
+		{"ghadoc_workflow_duration_median_seconds", "Median duration of workflow runs.", func(s Stats) float64 { return s.MedianDuration.Seconds() }},
+		{"ghadoc_workflow_last_run_timestamp_seconds", "Creation time of the latest workflow run.", func(s Stats) float64 {
+			if s.LastRunAt.IsZero() {
+				return 0
+			}
+			return float64(s.LastRunAt.Unix())
+		}},
+	}
+
+	var sb strings.Builder
+	for _, metric := range metrics {
+		sb.WriteString(fmt.Sprintf("# HELP %s %s\n", metric.name, metric.help))
+		sb.WriteString(fmt.Sprintf("# TYPE %s gauge\n", metric.name))
+		for _, s := range stats {
+			sb.WriteString(fmt.Sprintf("%s{repository=\"%s\",workflow=\"%s\"} %g\n",
+				metric.name, labelEscaper.Replace(repo), labelEscaper.Replace(s.Workflow), metric.value(s)))
+		}
+	}
+	return sb.String()
+}
+
+// Push replaces the metrics of the ghadoc job on the Prometheus Pushgateway
+// at gatewayURL with content in the text exposition format, giving up once
+// ctx is done.
+func Push(ctx context.Context, gatewayURL string, content string) error {
+	endpoint := strings.TrimSuffix(gatewayURL, "/") + "/metrics/job/ghadoc"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, endpoint, strings.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("error pushing metrics: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("error pushing metrics: pushgateway returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,88 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// Stats summarizes the recent runs of a workflow.
+type Stats struct {
+	Workflow        string
+	Runs            int     // Completed runs considered
+	Successes       int     // Runs that concluded successfully
+	Failures        int     // Runs that failed or timed out
+	SuccessRate     float64 // Successes divided by successes plus failures
+	AverageDuration time.Duration
+	MedianDuration  time.Duration
+	LastConclusion  string
+	LastRunAt       time.Time
+}
+
+// Compute summarizes runs, which are expected newest first. Runs that have
+// not completed are ignored, and cancelled or skipped runs do not count
+// towards the success rate.
+func Compute(workflow string, runs []github.WorkflowRun) Stats {
+	stats := Stats{Workflow: workflow}
+
+	var durations []time.Duration
+	var total time.Duration
+	for _, run := range runs {
+		if run.Status != "completed" {
+			continue
+		}
+
+		if stats.Runs == 0 {
+			stats.LastConclusion = run.Conclusion
+			stats.LastRunAt = run.CreatedAt
+		}
+		stats.Runs++
+
+		switch run.Conclusion {
+		case "success":
+			stats.Successes++
+		case "failure", "timed_out":
+			stats.Failures++
+		}
+
+		duration := run.Duration()
+		durations = append(durations, duration)
+		total += duration
+	}
+
+	if decided := stats.Successes + stats.Failures; decided > 0 {
+		stats.SuccessRate = float64(stats.Successes) / float64(decided)
+	}
+
+	if len(durations) > 0 {
+		stats.AverageDuration = total / time.Duration(len(durations))
+
+		sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+		middle := len(durations) / 2
+		if len(durations)%2 == 0 {
+			stats.MedianDuration = (durations[middle-1] + durations[middle]) / 2
+		} else {
+			stats.MedianDuration = durations[middle]
+		}
+	}
+
+	return stats
+}
+
+// Collect fetches the last count runs of every workflow in owner/repo and
+// summarizes them.
+func Collect(ctx context.Context, client *github.Client, owner, repo string, workflows []generate.WorkflowInfo, count int) ([]Stats, error) {
+	var allStats []Stats
+	for _, workflow := range workflows {
+		runs, err := client.ListWorkflowRuns(ctx, owner, repo, workflow.Filename, count)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching runs of %s: %v", workflow.Filename, err)
+		}
+		allStats = append(allStats, Compute(workflow.Filename, runs))
+	}
+	return allStats, nil
+}
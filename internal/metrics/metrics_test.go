@@ -0,0 +1,131 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// run creates a completed workflow run with the given conclusion and duration
+func run(conclusion string, created time.Time, duration time.Duration) github.WorkflowRun {
+	return github.WorkflowRun{
+		Status:       "completed",
+		Conclusion:   conclusion,
+		CreatedAt:    created,
+		RunStartedAt: created,
+		UpdatedAt:    created.Add(duration),
+	}
+}
+
+// TestCompute tests summarizing workflow runs
+func TestCompute(t *testing.T) {
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	runs := []github.WorkflowRun{
+		{Status: "in_progress", CreatedAt: now},
+		run("failure", now.Add(-time.Hour), 4*time.Minute),
+		run("success", now.Add(-2*time.Hour), 2*time.Minute),
+		run("cancelled", now.Add(-3*time.Hour), 1*time.Minute),
+		run("success", now.Add(-4*time.Hour), 10*time.Minute),
+	}
+
+	stats := Compute("ci.yml", runs)
+
+	if stats.Runs != 4 || stats.Successes != 2 || stats.Failures != 1 {
+		t.Errorf("Unexpected counts: %+v", stats)
+	}
+	if stats.SuccessRate < 0.66 || stats.SuccessRate > 0.67 {
+		t.Errorf("Expected success rate of 2/3, got %v", stats.SuccessRate)
+	}
+	if stats.MedianDuration != 3*time.Minute {
+		t.Errorf("Expected median duration of 3m, got %v", stats.MedianDuration)
+	}
+	if stats.AverageDuration != 4*time.Minute+15*time.Second {
+		t.Errorf("Expected average duration of 4m15s, got %v", stats.AverageDuration)
+	}
+	if stats.LastConclusion != "failure" || !stats.LastRunAt.Equal(now.Add(-time.Hour)) {
+		t.Errorf("Expected latest completed run to be the failure, got %q at %v", stats.LastConclusion, stats.LastRunAt)
+	}
+
+	empty := Compute("empty.yml", nil)
+	if empty.Runs != 0 || empty.SuccessRate != 0 || empty.MedianDuration != 0 {
+		t.Errorf("Expected zero stats without runs, got %+v", empty)
+	}
+}
+
+// TestRenderGrafana tests the Grafana JSON datasource table
+func TestRenderGrafana(t *testing.T) {
+	stats := []Stats{
+		{Workflow: "ci.yml", Runs: 2, Successes: 1, Failures: 1, SuccessRate: 0.5, MedianDuration: time.Minute,
+			LastConclusion: "success", LastRunAt: time.UnixMilli(1736510400000)},
+		{Workflow: "idle.yml"},
+	}
+
+	content, err := RenderGrafana(stats)
+	if err != nil {
+		t.Fatalf("RenderGrafana failed: %v", err)
+	}
+
+	var tables []grafanaTable
+	if err := json.Unmarshal([]byte(content), &tables); err != nil {
+		t.Fatalf("Failed to decode Grafana table: %v", err)
+	}
+	if len(tables) != 1 || tables[0].Type != "table" || len(tables[0].Columns) != 9 {
+		t.Fatalf("Unexpected Grafana response: %s", content)
+	}
+
+	expectedRows := [][]interface{}{
+		{"ci.yml", 2.0, 1.0, 1.0, 0.5, 0.0, 60.0, "success", 1736510400000.0},
+		{"idle.yml", 0.0, 0.0, 0.0, 0.0, 0.0, 0.0, "", nil},
+	}
+	if !reflect.DeepEqual(tables[0].Rows, expectedRows) {
+		t.Errorf("Expected rows %v, got %v", expectedRows, tables[0].Rows)
+	}
+}
+
+// TestRenderPrometheus tests the Prometheus text exposition format
+func TestRenderPrometheus(t *testing.T) {
+	stats := []Stats{{Workflow: "ci.yml", Runs: 3, SuccessRate: 0.5, LastRunAt: time.Unix(1736510400, 0)}}
+
+	content := RenderPrometheus("owner/repo", stats)
+
+	expectedLines := []string{
+		"# TYPE ghadoc_workflow_runs gauge",
+		`ghadoc_workflow_runs{repository="owner/repo",workflow="ci.yml"} 3`,
+		`ghadoc_workflow_success_ratio{repository="owner/repo",workflow="ci.yml"} 0.5`,
+		`ghadoc_workflow_last_run_timestamp_seconds{repository="owner/repo",workflow="ci.yml"} 1.7365104e+09`,
+	}
+	for _, line := range expectedLines {
+		if !strings.Contains(content, line) {
+			t.Errorf("Expected Prometheus output to contain %q, got:\n%s", line, content)
+		}
+	}
+}
+
+// TestPush tests pushing metrics to a Pushgateway
+func TestPush(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut || r.URL.Path != "/metrics/job/ghadoc" {
+			t.Errorf("Unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+	}))
+	defer server.Close()
+
+	err := Push(context.Background(), server.URL+"/", "ghadoc_workflow_runs 1\n")
+	if err != nil {
+		t.Fatalf("Push failed: %v", err)
+	}
+	if body != "ghadoc_workflow_runs 1\n" {
+		t.Errorf("Unexpected pushed body %q", body)
+	}
+}
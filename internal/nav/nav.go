@@ -0,0 +1,118 @@
+package nav
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"gopkg.in/yaml.v3"
+)
+
+// Supported navigation formats.
+const (
+	FormatMkDocs     = "mkdocs"
+	FormatDocusaurus = "docusaurus"
+)
+
+// Entry is a single page in the navigation.
+type Entry struct {
+	Title string
+	Path  string // Path of the page relative to the docs directory, with forward slashes
+}
+
+// Entries returns a navigation entry for the page of every workflow. Pages
+// are expected in pagesDir at generate.PagePath, as written by
+// generate.GeneratePages, and paths
+// are made relative to docsDir. If index is set, it is included first as an
+// overview page.
+func Entries(workflows []generate.WorkflowInfo, pagesDir, docsDir, index string) ([]Entry, error) {
+	var entries []Entry
+
+	if index != "" {
+		path, err := docsRelative(docsDir, index)
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Title: "Overview", Path: path})
+	}
+
+	for _, workflow := range workflows {
+		path, err := docsRelative(docsDir, filepath.Join(pagesDir, filepath.FromSlash(generate.PagePath(workflow))))
+		if err != nil {
+			return nil, err
+		}
+		entries = append(entries, Entry{Title: workflow.Filename, Path: path})
+	}
+
+	return entries, nil
+}
+
+// docsRelative returns path relative to docsDir with forward slashes.
+func docsRelative(docsDir, path string) (string, error) {
+	relativePath, err := filepath.Rel(docsDir, path)
+	if err != nil || strings.HasPrefix(relativePath, "..") {
+		return "", fmt.Errorf("page %s is outside of docs directory %s", path, docsDir)
+	}
+	return filepath.ToSlash(relativePath), nil
+}
+
+// Render renders the entries as a navigation section titled section in the
+// given format.
+func Render(entries []Entry, section, format string) (string, error) {
+	switch format {
+	case FormatMkDocs:
+		return renderMkDocs(entries, section)
+	case FormatDocusaurus:
+		return renderDocusaurus(entries, section)
+	default:
+		return "", fmt.Errorf("unsupported navigation format %q", format)
+	}
+}
+
+// renderMkDocs renders a `nav:` fragment for mkdocs.yml.
+func renderMkDocs(entries []Entry, section string) (string, error) {
+	var items []map[string]string
+	for _, entry := range entries {
+		items = append(items, map[string]string{entry.Title: entry.Path})
+	}
+
+	fragment := map[string]interface{}{
+		"nav": []map[string]interface{}{{section: items}},
+	}
+
+	var sb strings.Builder
+	encoder := yaml.NewEncoder(&sb)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(fragment); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// docusaurusCategory is a category item of a Docusaurus sidebar.
+type docusaurusCategory struct {
+	Type  string   `json:"type"`
+	Label string   `json:"label"`
+	Items []string `json:"items"`
+}
+
+// renderDocusaurus renders a sidebars.json document with a single category.
+// Docusaurus identifies docs by their path without extension.
+func renderDocusaurus(entries []Entry, section string) (string, error) {
+	category := docusaurusCategory{Type: "category", Label: section, Items: []string{}}
+	for _, entry := range entries {
+		category.Items = append(category.Items, strings.TrimSuffix(entry.Path, filepath.Ext(entry.Path)))
+	}
+
+	sidebar := map[string][]docusaurusCategory{
+		"workflowsSidebar": {category},
+	}
+
+	content, err := json.MarshalIndent(sidebar, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
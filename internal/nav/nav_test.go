@@ -0,0 +1,97 @@
+package nav
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+var workflows = []generate.WorkflowInfo{
+	{Filename: "ci.yml"},
+	{Filename: "release.yaml"},
+}
+
+// TestRenderMkDocs tests the MkDocs nav fragment
+func TestRenderMkDocs(t *testing.T) {
+	entries, err := Entries(workflows, "docs/workflows", "docs", "docs/workflows.md")
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+
+	content, err := Render(entries, "Workflows", FormatMkDocs)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := `nav:
+  - Workflows:
+      - Overview: workflows.md
+      - ci.yml: workflows/ci.md
+      - release.yaml: workflows/release.md
+`
+	if content != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+// TestRenderDocusaurus tests the Docusaurus sidebar
+func TestRenderDocusaurus(t *testing.T) {
+	entries, err := Entries(workflows, "docs/ci/workflows", "docs", "")
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+
+	content, err := Render(entries, "CI", FormatDocusaurus)
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+
+	expected := `{
+  "workflowsSidebar": [
+    {
+      "type": "category",
+      "label": "CI",
+      "items": [
+        "ci/workflows/ci",
+        "ci/workflows/release"
+      ]
+    }
+  ]
+}
+`
+	if content != expected {
+		t.Errorf("Expected:\n%s\ngot:\n%s", expected, content)
+	}
+}
+
+// TestEntriesDirs tests linking to the pages of workflows of several
+// directories, kept in a subdirectory per workflows directory
+func TestEntriesDirs(t *testing.T) {
+	entries, err := Entries([]generate.WorkflowInfo{
+		{Filename: "ci.yml", Dir: "api/.github/workflows"},
+		{Filename: "ci.yml.disabled", Dir: "web/.github/workflows"},
+	}, "docs/workflows", "docs", "")
+	if err != nil {
+		t.Fatalf("Entries failed: %v", err)
+	}
+
+	expected := []Entry{
+		{Title: "ci.yml", Path: "workflows/api/.github/workflows/ci.md"},
+		{Title: "ci.yml.disabled", Path: "workflows/web/.github/workflows/ci.md"},
+	}
+	if !reflect.DeepEqual(entries, expected) {
+		t.Errorf("Expected entries %v, got %v", expected, entries)
+	}
+}
+
+// TestEntriesErrors tests error handling for pages outside the docs directory
+func TestEntriesErrors(t *testing.T) {
+	if _, err := Entries(workflows, "site/workflows", "docs", ""); err == nil {
+		t.Error("Expected error for pages outside of docs directory, got nil")
+	}
+
+	if _, err := Render(nil, "Workflows", "hugo"); err == nil {
+		t.Error("Expected error for unsupported format, got nil")
+	}
+}
@@ -0,0 +1,173 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// Webhook types supported by Post.
+const (
+	TypeAuto  = "auto"
+	TypeSlack = "slack"
+	TypeTeams = "teams"
+)
+
+// LoadState reads the workflows recorded by SaveState at path. It reports
+// false if there is no state yet.
+func LoadState(path string) ([]generate.WorkflowInfo, bool, error) {
+	content, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error reading state: %v", err)
+	}
+
+	var document generate.Document
+	if err := json.Unmarshal(content, &document); err != nil {
+		return nil, false, fmt.Errorf("error parsing state %s: %v", path, err)
+	}
+	return document.Workflows, true, nil
+}
+
+// SaveState records the workflows of workflowsDir at path, in the JSON
+// format of `generate --format json`.
+func SaveState(path, workflowsDir string, workflows []generate.WorkflowInfo) error {
+	document := generate.Document{Source: filepath.ToSlash(workflowsDir), Workflows: workflows}
+	content, err := json.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(path, append(content, '\n'), 0644)
+	if err != nil {
+		return fmt.Errorf("error writing state: %v", err)
+	}
+	return nil
+}
+
+// Summary describes changes to the workflows of workflowsDir as a message
+// with a line per workflow.
+func Summary(changes []diff.Change, workflowsDir string) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("Workflow documentation changed in `%s`:\n", filepath.ToSlash(workflowsDir)))
+
+	for _, change := range changes {
+		var details []string
+		switch change.Kind {
+		case diff.Added:
+			if change.NewDescription != "" {
+				details = append(details, change.NewDescription)
+			}
+		case diff.Modified:
+			if len(change.AddedTriggers) > 0 {
+				details = append(details, "triggers added: "+strings.Join(change.AddedTriggers, ", "))
+			}
+			if len(change.RemovedTriggers) > 0 {
+				details = append(details, "triggers removed: "+strings.Join(change.RemovedTriggers, ", "))
+			}
+			if change.DescriptionChanged() {
+				details = append(details, "description changed")
+			}
+		}
+
+		line := fmt.Sprintf("- %s `%s`", kindLabel(change.Kind), change.Filename)
+		if len(details) > 0 {
+			line += ": " + strings.Join(details, "; ")
+		}
+		sb.WriteString(line + "\n")
+	}
+
+	return sb.String()
+}
+
+// kindLabel returns the capitalized kind of change.
+func kindLabel(kind diff.Kind) string {
+	switch kind {
+	case diff.Added:
+		return "Added"
+	case diff.Removed:
+		return "Removed"
+	default:
+		return "Modified"
+	}
+}
+
+// DetectType returns the type of the webhook at webhookURL from its host:
+// TypeSlack for Slack, and TypeTeams otherwise.
+func DetectType(webhookURL string) string {
+	parsed, err := url.Parse(webhookURL)
+	if err == nil && strings.HasSuffix(parsed.Hostname(), "slack.com") {
+		return TypeSlack
+	}
+	return TypeTeams
+}
+
+// Post posts message to the Slack or Microsoft Teams webhook at webhookURL,
+// giving up once ctx is done. Teams webhooks receive the message as an
+// Adaptive Card.
+func Post(ctx context.Context, webhookURL, webhookType, message string) error {
+	if webhookType == "" || webhookType == TypeAuto {
+		webhookType = DetectType(webhookURL)
+	}
+
+	var payload interface{}
+	switch webhookType {
+	case TypeSlack:
+		// Slack has no list syntax, so list items are shown with bullets
+		payload = map[string]string{"text": strings.ReplaceAll(message, "\n- ", "\n• ")}
+	case TypeTeams:
+		payload = map[string]interface{}{
+			"type": "message",
+			"attachments": []interface{}{map[string]interface{}{
+				"contentType": "application/vnd.microsoft.card.adaptive",
+				"content": map[string]interface{}{
+					"$schema": "http://adaptivecards.io/schemas/adaptive-card.json",
+					"type":    "AdaptiveCard",
+					"version": "1.4",
+					"body": []interface{}{map[string]interface{}{
+						"type": "TextBlock",
+						"text": message,
+						"wrap": true,
+					}},
+				},
+			}},
+		}
+	default:
+		return fmt.Errorf("unsupported webhook type %q", webhookType)
+	}
+
+	content, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("error posting to webhook: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return nil
+}
@@ -0,0 +1,97 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// TestState tests saving and loading the workflows of the previous run
+func TestState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	_, ok, err := LoadState(path)
+	if err != nil || ok {
+		t.Errorf("Expected no state, got %v, %v", ok, err)
+	}
+
+	workflows := []generate.WorkflowInfo{{Filename: "ci.yml", Description: "Runs CI.", Triggers: []string{"push"}}}
+	if err := SaveState(path, ".github/workflows", workflows); err != nil {
+		t.Fatalf("SaveState failed: %v", err)
+	}
+
+	loaded, ok, err := LoadState(path)
+	if err != nil || !ok {
+		t.Fatalf("Expected state, got %v, %v", ok, err)
+	}
+	if !reflect.DeepEqual(loaded, workflows) {
+		t.Errorf("Expected %+v, got %+v", workflows, loaded)
+	}
+}
+
+// TestSummary tests describing workflow changes
+func TestSummary(t *testing.T) {
+	changes := []diff.Change{
+		{Filename: "ci.yml", Kind: diff.Modified, AddedTriggers: []string{"pull_request"}, OldDescription: "a", NewDescription: "b"},
+		{Filename: "new.yml", Kind: diff.Added, NewDescription: "Deploys."},
+		{Filename: "old.yml", Kind: diff.Removed},
+	}
+
+	expected := "Workflow documentation changed in `.github/workflows`:\n" +
+		"- Modified `ci.yml`: triggers added: pull_request; description changed\n" +
+		"- Added `new.yml`: Deploys.\n" +
+		"- Removed `old.yml`\n"
+	if summary := Summary(changes, ".github/workflows"); summary != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, summary)
+	}
+}
+
+// TestPost tests posting to Slack and Teams webhooks
+func TestPost(t *testing.T) {
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		payload = nil
+		json.Unmarshal(body, &payload)
+	}))
+	defer server.Close()
+
+	if err := Post(context.Background(), server.URL, TypeSlack, "Changed:\n- Added `a.yml`\n"); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if payload["text"] != "Changed:\n• Added `a.yml`\n" {
+		t.Errorf("Unexpected Slack payload: %v", payload)
+	}
+
+	if err := Post(context.Background(), server.URL, TypeAuto, "Changed"); err != nil {
+		t.Fatalf("Post failed: %v", err)
+	}
+	if payload["type"] != "message" {
+		t.Errorf("Expected a Teams message, got %v", payload)
+	}
+
+	if err := Post(context.Background(), server.URL, "email", "Changed"); err == nil {
+		t.Error("Expected error for unsupported webhook type, got nil")
+	}
+}
+
+// TestDetectType tests detecting the webhook type from its URL
+func TestDetectType(t *testing.T) {
+	for webhookURL, expected := range map[string]string{
+		"https://hooks.slack.com/services/T0/B0/x":                 TypeSlack,
+		"https://example.webhook.office.com/webhookb2/x":           TypeTeams,
+		"https://prod-00.westus.logic.azure.com/workflows/x/paths": TypeTeams,
+	} {
+		if webhookType := DetectType(webhookURL); webhookType != expected {
+			t.Errorf("DetectType(%q) = %q, expected %q", webhookURL, webhookType, expected)
+		}
+	}
+}
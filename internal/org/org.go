@@ -0,0 +1,355 @@
+package org
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+	"github.com/droctothorpe/gha-docs/internal/remote"
+)
+
+// Supported report formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatJSON     = "json"
+)
+
+// Repository visibilities accepted by Filter.
+const (
+	VisibilityAll      = "all"
+	VisibilityPublic   = "public"
+	VisibilityPrivate  = "private"
+	VisibilityInternal = "internal"
+)
+
+// Filter selects the repositories of an organization to scan.
+type Filter struct {
+	Topics          []string // Repositories must have all of these topics
+	Visibility      string   // One of the Visibility constants; empty means all
+	IncludeArchived bool     // Archived repositories are skipped unless set
+	NamePatterns    []string // Repository names must match one of these glob patterns
+}
+
+// Validate checks the filter for unsupported values and invalid patterns.
+func (f Filter) Validate() error {
+	switch f.Visibility {
+	case "", VisibilityAll, VisibilityPublic, VisibilityPrivate, VisibilityInternal:
+	default:
+		return fmt.Errorf("unsupported visibility %q", f.Visibility)
+	}
+
+	for _, pattern := range f.NamePatterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid name pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// Match reports whether repo passes the filter.
+func (f Filter) Match(repo github.Repository) bool {
+	if repo.Archived && !f.IncludeArchived {
+		return false
+	}
+
+	if f.Visibility != "" && f.Visibility != VisibilityAll && visibility(repo) != f.Visibility {
+		return false
+	}
+
+	for _, topic := range f.Topics {
+		if !contains(repo.Topics, topic) {
+			return false
+		}
+	}
+
+	if len(f.NamePatterns) == 0 {
+		return true
+	}
+	for _, pattern := range f.NamePatterns {
+		if matched, _ := path.Match(pattern, repo.Name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// Select returns the repositories that pass the filter, in order.
+func (f Filter) Select(repos []github.Repository) []github.Repository {
+	var selected []github.Repository
+	for _, repo := range repos {
+		if f.Match(repo) {
+			selected = append(selected, repo)
+		}
+	}
+	return selected
+}
+
+// visibility returns the visibility of repo. Older GitHub Enterprise Server
+// versions only report whether a repository is private.
+func visibility(repo github.Repository) string {
+	if repo.Visibility != "" {
+		return repo.Visibility
+	}
+	if repo.Private {
+		return VisibilityPrivate
+	}
+	return VisibilityPublic
+}
+
+// contains reports whether items contains item, ignoring case.
+func contains(items []string, item string) bool {
+	for _, candidate := range items {
+		if strings.EqualFold(candidate, item) {
+			return true
+		}
+	}
+	return false
+}
+
+// Defaults for ScanOptions.
+const (
+	DefaultConcurrency = 8
+	DefaultTimeout     = 2 * time.Minute
+)
+
+// ScanOptions configures an organization scan.
+type ScanOptions struct {
+	WorkflowsDir string          // Directory of the workflows in each repository
+	Concurrency  int             // Repositories scanned at once; DefaultConcurrency if not positive
+	Timeout      time.Duration   // Time allowed per repository; DefaultTimeout if not positive
+	Cache        *generate.Cache // Optional cache of parsed workflows
+}
+
+// Result holds the workflows of a scanned repository, or the error that
+// made its scan fail.
+type Result struct {
+	Repo      github.Repository
+	Workflows []generate.WorkflowInfo
+	Err       error
+}
+
+// Scan fetches the workflows of every repository of org that passes the
+// filter. Repositories are scanned concurrently, and a repository that fails
+// or times out is reported in its result instead of failing the whole scan.
+// Results are in the order the API lists the repositories. Once ctx is
+// done, the repositories left to scan fail with its error.
+func Scan(ctx context.Context, client *github.Client, org string, filter Filter, opts ScanOptions) ([]Result, error) {
+	if err := filter.Validate(); err != nil {
+		return nil, err
+	}
+	if opts.Concurrency <= 0 {
+		opts.Concurrency = DefaultConcurrency
+	}
+	if opts.Timeout <= 0 {
+		opts.Timeout = DefaultTimeout
+	}
+
+	repos, err := client.ListOrgRepos(ctx, org)
+	if err != nil {
+		return nil, fmt.Errorf("error listing repositories of %s: %v", org, err)
+	}
+
+	selected := filter.Select(repos)
+	results := make([]Result, len(selected))
+	semaphore := make(chan struct{}, opts.Concurrency)
+
+	var wg sync.WaitGroup
+	for i, repo := range selected {
+		wg.Add(1)
+		go func(i int, repo github.Repository) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = Result{Repo: repo, Err: ctx.Err()}
+				return
+			}
+			defer func() { <-semaphore }()
+
+			workflows, err := scanRepo(ctx, client, repo, opts)
+			results[i] = Result{Repo: repo, Workflows: workflows, Err: err}
+		}(i, repo)
+	}
+	wg.Wait()
+
+	return results, nil
+}
+
+// scanRepo fetches the workflows of repo, giving up after opts.Timeout.
+func scanRepo(ctx context.Context, client *github.Client, repo github.Repository, opts ScanOptions) ([]generate.WorkflowInfo, error) {
+	owner, name, err := github.ParseRepo(repo.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	repoCtx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+	workflows, err := remote.Workflows(repoCtx, client, owner, name, repo.DefaultBranch, opts.WorkflowsDir, generate.ScanOptions{Parse: generate.ParseOptions{Partial: true}, Cache: opts.Cache})
+	if err != nil && ctx.Err() == nil && errors.Is(repoCtx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("timed out after %v", opts.Timeout)
+	}
+	return workflows, err
+}
+
+// Failed returns the results of the repositories that failed to scan.
+func Failed(results []Result) []Result {
+	var failed []Result
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// Render renders the workflows of every scanned repository of org as a
+// markdown document with an overview of all repositories followed by a
+// section per repository.
+func Render(org string, results []Result, workflowsDir string) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# GitHub Workflows in %s\n\n", org))
+	failed := Failed(results)
+	sb.WriteString(fmt.Sprintf("Repositories scanned: %d\n", len(results)))
+	if len(failed) > 0 {
+		sb.WriteString(fmt.Sprintf("Repositories that failed to scan: %d\n", len(failed)))
+	}
+
+	writeOverview(&sb, results)
+
+	for _, result := range results {
+		sb.WriteString(fmt.Sprintf("\n## [%s](%s)\n\n", result.Repo.FullName, result.Repo.HTMLURL))
+
+		if result.Err != nil {
+			sb.WriteString(fmt.Sprintf("_Scan failed: %v_\n", result.Err))
+			continue
+		}
+		if len(result.Workflows) == 0 {
+			sb.WriteString("_No workflows._\n")
+			continue
+		}
+
+		sb.WriteString("| Filename | Description | Triggers |\n")
+		sb.WriteString("| --- | --- | --- |\n")
+		for _, workflow := range result.Workflows {
+			link := fmt.Sprintf("%s/blob/%s/%s", result.Repo.HTMLURL, result.Repo.DefaultBranch, path.Join(workflowsDir, workflow.Filename))
+			sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s |\n",
+				workflow.Filename,
+				link,
+				workflow.Description,
+				strings.Join(workflow.Triggers, ", ")))
+		}
+	}
+
+	if len(failed) > 0 {
+		sb.WriteString("\n## Failed repositories\n\n")
+		sb.WriteString("| Repository | Error |\n")
+		sb.WriteString("| --- | --- |\n")
+		for _, result := range failed {
+			sb.WriteString(fmt.Sprintf("| %s | %v |\n", result.Repo.FullName, result.Err))
+		}
+	}
+
+	return sb.String()
+}
+
+// writeOverview writes a table with the workflow count and triggers of every
+// repository, followed by the number of workflows using each trigger across
+// the organization.
+func writeOverview(sb *strings.Builder, results []Result) {
+	sb.WriteString("\n## Overview\n\n")
+	sb.WriteString("| Repository | Workflows | Triggers |\n")
+	sb.WriteString("| --- | --- | --- |\n")
+
+	usage := make(map[string]int)
+	for _, result := range results {
+		if result.Err != nil {
+			sb.WriteString(fmt.Sprintf("| %s | _scan failed_ | |\n", result.Repo.FullName))
+			continue
+		}
+
+		var triggers []string
+		seen := make(map[string]bool)
+		for _, workflow := range result.Workflows {
+			for _, trigger := range workflow.Triggers {
+				usage[trigger]++
+				if !seen[trigger] {
+					seen[trigger] = true
+					triggers = append(triggers, trigger)
+				}
+			}
+		}
+		sort.Strings(triggers)
+
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", result.Repo.FullName, len(result.Workflows), strings.Join(triggers, ", ")))
+	}
+
+	if len(usage) == 0 {
+		return
+	}
+
+	var triggers []string
+	for trigger := range usage {
+		triggers = append(triggers, trigger)
+	}
+	sort.Slice(triggers, func(i, j int) bool {
+		if usage[triggers[i]] != usage[triggers[j]] {
+			return usage[triggers[i]] > usage[triggers[j]]
+		}
+		return triggers[i] < triggers[j]
+	})
+
+	sb.WriteString("\n| Trigger | Workflows |\n")
+	sb.WriteString("| --- | --- |\n")
+	for _, trigger := range triggers {
+		sb.WriteString(fmt.Sprintf("| %s | %d |\n", trigger, usage[trigger]))
+	}
+}
+
+// jsonReport is the JSON representation of an organization scan.
+type jsonReport struct {
+	Organization string           `json:"organization"`
+	Repositories []jsonRepository `json:"repositories"`
+}
+
+// jsonRepository is the JSON representation of a scanned repository.
+type jsonRepository struct {
+	Repository string                  `json:"repository"`
+	URL        string                  `json:"url"`
+	Source     string                  `json:"source"`
+	Workflows  []generate.WorkflowInfo `json:"workflows"`
+	Error      string                  `json:"error,omitempty"`
+}
+
+// RenderJSON renders the workflows of every scanned repository of org as a
+// JSON document, including the errors of repositories that failed to scan.
+func RenderJSON(org string, results []Result, workflowsDir string) (string, error) {
+	report := jsonReport{Organization: org, Repositories: []jsonRepository{}}
+	for _, result := range results {
+		repository := jsonRepository{
+			Repository: result.Repo.FullName,
+			URL:        result.Repo.HTMLURL,
+			Source:     workflowsDir,
+			Workflows:  []generate.WorkflowInfo{},
+		}
+		if result.Err != nil {
+			repository.Error = result.Err.Error()
+		}
+		repository.Workflows = append(repository.Workflows, result.Workflows...)
+		report.Repositories = append(report.Repositories, repository)
+	}
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
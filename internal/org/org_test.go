@@ -0,0 +1,218 @@
+package org
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestFilterMatch tests repository filtering
+func TestFilterMatch(t *testing.T) {
+	repos := map[string]github.Repository{
+		"api":      {Name: "api", Visibility: "private", Topics: []string{"go", "Service"}},
+		"web":      {Name: "web", Visibility: "public", Topics: []string{"service"}},
+		"old-api":  {Name: "old-api", Visibility: "private", Archived: true, Topics: []string{"go", "service"}},
+		"legacy":   {Name: "legacy", Private: true},
+		"api-docs": {Name: "api-docs", Visibility: "internal"},
+	}
+
+	testCases := []struct {
+		name     string
+		filter   Filter
+		expected []string
+	}{
+		{"default skips archived", Filter{}, []string{"api", "api-docs", "legacy", "web"}},
+		{"include archived", Filter{IncludeArchived: true}, []string{"api", "api-docs", "legacy", "old-api", "web"}},
+		{"all topics required", Filter{Topics: []string{"go", "service"}}, []string{"api"}},
+		{"visibility", Filter{Visibility: VisibilityPrivate}, []string{"api", "legacy"}},
+		{"name patterns", Filter{NamePatterns: []string{"api*", "web"}}, []string{"api", "api-docs", "web"}},
+	}
+
+	for _, tc := range testCases {
+		var matched []string
+		for _, name := range []string{"api", "api-docs", "legacy", "old-api", "web"} {
+			if tc.filter.Match(repos[name]) {
+				matched = append(matched, name)
+			}
+		}
+		if strings.Join(matched, ",") != strings.Join(tc.expected, ",") {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.expected, matched)
+		}
+	}
+}
+
+// TestFilterValidate tests rejection of invalid filters
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{Visibility: "secret"}).Validate(); err == nil {
+		t.Error("Expected error for unsupported visibility, got nil")
+	}
+	if err := (Filter{NamePatterns: []string{"[a-"}}).Validate(); err == nil {
+		t.Error("Expected error for invalid name pattern, got nil")
+	}
+	if err := (Filter{Visibility: VisibilityInternal, NamePatterns: []string{"api-*"}}).Validate(); err != nil {
+		t.Errorf("Expected valid filter, got %v", err)
+	}
+}
+
+// TestScan tests scanning the workflows of an organization
+func TestScan(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/octo-org/repos":
+			w.Write([]byte(`[
+				{"name": "api", "full_name": "octo-org/api", "html_url": "https://github.com/octo-org/api", "default_branch": "main"},
+				{"name": "old", "full_name": "octo-org/old", "archived": true}
+			]`))
+		case "/repos/octo-org/api/contents/.github/workflows":
+			w.Write([]byte(`[{"name": "ci.yml", "type": "file"}, {"name": "README.md", "type": "file"}]`))
+		case "/repos/octo-org/api/contents/.github/workflows/ci.yml":
+			content := base64.StdEncoding.EncodeToString([]byte("## Runs CI.\non: [push, pull_request]\n"))
+			fmt.Fprintf(w, `{"encoding": "base64", "content": "%s"}`, content)
+		default:
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClient(server.URL, "")
+	results, err := Scan(context.Background(), client, "octo-org", Filter{}, ScanOptions{WorkflowsDir: ".github/workflows"})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(results) != 1 || len(results[0].Workflows) != 1 {
+		t.Fatalf("Expected one repository with one workflow, got %+v", results)
+	}
+
+	content := Render("octo-org", results, ".github/workflows")
+	expected := "| [ci.yml](https://github.com/octo-org/api/blob/main/.github/workflows/ci.yml) | Runs CI. | pull_request, push |"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+	}
+}
+
+// TestScanIsolatesFailures tests that failing and slow repositories are
+// reported without failing the scan
+func TestScanIsolatesFailures(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/orgs/octo-org/repos":
+			w.Write([]byte(`[
+				{"name": "ok", "full_name": "octo-org/ok"},
+				{"name": "broken", "full_name": "octo-org/broken"},
+				{"name": "slow", "full_name": "octo-org/slow"}
+			]`))
+		case "/repos/octo-org/ok/contents/.github/workflows":
+			w.Write([]byte(`[]`))
+		case "/repos/octo-org/broken/contents/.github/workflows":
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "Resource not accessible by integration"}`))
+		case "/repos/octo-org/slow/contents/.github/workflows":
+			time.Sleep(500 * time.Millisecond)
+			w.Write([]byte(`[]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	client := github.NewClient(server.URL, "")
+	results, err := Scan(context.Background(), client, "octo-org", Filter{}, ScanOptions{
+		WorkflowsDir: ".github/workflows",
+		Concurrency:  2,
+		Timeout:      100 * time.Millisecond,
+	})
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	if len(results) != 3 || results[0].Repo.Name != "ok" || results[0].Err != nil {
+		t.Fatalf("Expected ok to scan successfully first, got %+v", results)
+	}
+	if results[1].Err == nil || !strings.Contains(results[1].Err.Error(), "Resource not accessible") {
+		t.Errorf("Expected API error for broken, got %v", results[1].Err)
+	}
+	if results[2].Err == nil || !strings.Contains(results[2].Err.Error(), "timed out") {
+		t.Errorf("Expected timeout for slow, got %v", results[2].Err)
+	}
+
+	content := Render("octo-org", results, ".github/workflows")
+	for _, expected := range []string{
+		"Repositories that failed to scan: 2",
+		"## Failed repositories",
+		"| octo-org/slow | timed out after 100ms |",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
+
+// testResults returns the results of a scan with two repositories, one of
+// which failed
+func testResults() []Result {
+	return []Result{
+		{
+			Repo: github.Repository{FullName: "octo-org/api", HTMLURL: "https://github.com/octo-org/api", DefaultBranch: "main"},
+			Workflows: []generate.WorkflowInfo{
+				{Filename: "ci.yml", Triggers: []string{"pull_request", "push"}},
+				{Filename: "release.yml", Triggers: []string{"push"}},
+			},
+		},
+		{
+			Repo: github.Repository{FullName: "octo-org/web", HTMLURL: "https://github.com/octo-org/web"},
+			Err:  fmt.Errorf("timed out after 2m0s"),
+		},
+	}
+}
+
+// TestRenderOverview tests the consolidated overview of the report
+func TestRenderOverview(t *testing.T) {
+	content := Render("octo-org", testResults(), ".github/workflows")
+
+	expected := "## Overview\n\n" +
+		"| Repository | Workflows | Triggers |\n" +
+		"| --- | --- | --- |\n" +
+		"| octo-org/api | 2 | pull_request, push |\n" +
+		"| octo-org/web | _scan failed_ | |\n" +
+		"\n| Trigger | Workflows |\n" +
+		"| --- | --- |\n" +
+		"| push | 2 |\n" +
+		"| pull_request | 1 |\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected output to contain:\n%s\nGot:\n%s", expected, content)
+	}
+}
+
+// TestRenderJSON tests the JSON report
+func TestRenderJSON(t *testing.T) {
+	content, err := RenderJSON("octo-org", testResults(), ".github/workflows")
+	if err != nil {
+		t.Fatalf("RenderJSON failed: %v", err)
+	}
+
+	var report jsonReport
+	if err := json.Unmarshal([]byte(content), &report); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+
+	if report.Organization != "octo-org" || len(report.Repositories) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if api := report.Repositories[0]; api.Repository != "octo-org/api" || len(api.Workflows) != 2 || api.Error != "" {
+		t.Errorf("Unexpected repository: %+v", api)
+	}
+	if web := report.Repositories[1]; web.Error != "timed out after 2m0s" || web.Workflows == nil {
+		t.Errorf("Unexpected failed repository: %+v", web)
+	}
+}
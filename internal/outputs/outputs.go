@@ -0,0 +1,450 @@
+package outputs
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of findings.
+const (
+	Unused     = "unused"
+	Undeclared = "undeclared"
+)
+
+var (
+	// stepsOutputPattern matches steps.<id>.outputs.<name> references.
+	stepsOutputPattern = regexp.MustCompile(`steps\.([A-Za-z0-9_-]+)\.outputs\.([A-Za-z0-9_-]+)`)
+	// needsOutputPattern matches needs.<job>.outputs.<name> references.
+	needsOutputPattern = regexp.MustCompile(`needs\.([A-Za-z0-9_-]+)\.outputs\.([A-Za-z0-9_-]+)`)
+	// jobsOutputPattern matches jobs.<job>.outputs.<name> references in
+	// workflow_call outputs.
+	jobsOutputPattern = regexp.MustCompile(`jobs\.([A-Za-z0-9_-]+)\.outputs\.([A-Za-z0-9_-]+)`)
+	// outputWritePattern matches outputs written by run steps, either to
+	// $GITHUB_OUTPUT as name=value or name<<DELIMITER, or with the
+	// deprecated set-output command.
+	outputWritePattern = regexp.MustCompile(`(?:echo\s+["']?|printf\s+["']?)([A-Za-z_][A-Za-z0-9_-]*)(?:=|<<)|::set-output name=([A-Za-z0-9_-]+)::`)
+)
+
+// Finding is an output that nothing consumes, or a consumed output that is
+// never declared.
+type Finding struct {
+	Workflow string
+	Kind     string // Unused or Undeclared
+	Output   string // The output, e.g. "jobs.build.outputs.version", or the reference
+	Detail   string
+}
+
+// workflow is a parsed workflow file.
+type workflow struct {
+	filename string
+	jobs     map[string]job
+	order    []string          // Job IDs, sorted
+	outputs  map[string]string // workflow_call outputs and their values
+}
+
+// job is a parsed job of a workflow.
+type job struct {
+	uses    string
+	outputs map[string]string
+	steps   []step
+	text    string // All values of the job, for finding references
+}
+
+// step is a parsed step of a job.
+type step struct {
+	id     string
+	uses   string
+	writes []string // Outputs written by a run step, sorted
+}
+
+// Analyze reports the unused and undeclared workflow, job, and step outputs
+// of the workflows in workflowsDir.
+func Analyze(workflowsDir string) ([]Finding, error) {
+	entries, err := os.ReadDir(workflowsDir)
+	if err != nil {
+		return nil, fmt.Errorf("error reading workflows directory: %v", err)
+	}
+
+	workflows := make(map[string]workflow)
+	var filenames []string
+	for _, entry := range entries {
+		if entry.IsDir() || !generate.IsWorkflowFile(entry.Name()) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(workflowsDir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("error reading workflow file %s: %v", entry.Name(), err)
+		}
+
+		parsed, err := parse(content)
+		if err != nil {
+			slog.Warn("Error parsing workflow file", "file", entry.Name(), "error", err)
+			continue
+		}
+		parsed.filename = entry.Name()
+		workflows[entry.Name()] = parsed
+		filenames = append(filenames, entry.Name())
+	}
+
+	var findings []Finding
+	for _, filename := range filenames {
+		findings = append(findings, analyzeWorkflow(workflows[filename], workflows)...)
+	}
+	return findings, nil
+}
+
+// parse extracts the jobs, steps, and outputs of a workflow.
+func parse(content []byte) (workflow, error) {
+	var data map[string]interface{}
+	if err := yaml.Unmarshal(content, &data); err != nil {
+		return workflow{}, err
+	}
+
+	parsed := workflow{jobs: make(map[string]job)}
+
+	// YAML 1.1 tools may have written the "on" key as the boolean true
+	onField, ok := data["on"]
+	if !ok {
+		onField = data["true"]
+	}
+	if on, ok := onField.(map[string]interface{}); ok {
+		if call, ok := on["workflow_call"].(map[string]interface{}); ok {
+			parsed.outputs = valueMap(call["outputs"], true)
+		}
+	}
+
+	jobs, _ := data["jobs"].(map[string]interface{})
+	for id, value := range jobs {
+		jobMap, ok := value.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		parsedJob := job{
+			uses:    fmt.Sprint(jobMap["uses"]),
+			outputs: valueMap(jobMap["outputs"], false),
+			text:    text(jobMap),
+		}
+		if jobMap["uses"] == nil {
+			parsedJob.uses = ""
+		}
+
+		steps, _ := jobMap["steps"].([]interface{})
+		for _, value := range steps {
+			stepMap, ok := value.(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			parsedStep := step{}
+			if id, ok := stepMap["id"].(string); ok {
+				parsedStep.id = id
+			}
+			if uses, ok := stepMap["uses"].(string); ok {
+				parsedStep.uses = uses
+			}
+			if run, ok := stepMap["run"].(string); ok {
+				parsedStep.writes = writtenOutputs(run)
+			}
+			parsedJob.steps = append(parsedJob.steps, parsedStep)
+		}
+
+		parsed.jobs[id] = parsedJob
+		parsed.order = append(parsed.order, id)
+	}
+	sort.Strings(parsed.order)
+
+	return parsed, nil
+}
+
+// valueMap converts an outputs map into names and values. Workflow outputs
+// hold their value under a value key.
+func valueMap(outputs interface{}, nested bool) map[string]string {
+	outputsMap, ok := outputs.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	values := make(map[string]string)
+	for name, value := range outputsMap {
+		if nested {
+			valueMap, _ := value.(map[string]interface{})
+			value = valueMap["value"]
+		}
+		values[name] = text(value)
+	}
+	return values
+}
+
+// text concatenates all strings within value.
+func text(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case map[string]interface{}:
+		var parts []string
+		for _, item := range v {
+			parts = append(parts, text(item))
+		}
+		return strings.Join(parts, "\n")
+	case []interface{}:
+		var parts []string
+		for _, item := range v {
+			parts = append(parts, text(item))
+		}
+		return strings.Join(parts, "\n")
+	}
+	return ""
+}
+
+// writtenOutputs returns the sorted outputs written by a run script.
+func writtenOutputs(run string) []string {
+	var writes []string
+	for _, line := range strings.Split(run, "\n") {
+		// Environment variables are written the same way
+		if strings.Contains(line, "GITHUB_ENV") {
+			continue
+		}
+
+		for _, match := range outputWritePattern.FindAllStringSubmatch(line, -1) {
+			name := match[2]
+			if name == "" && strings.Contains(run, "GITHUB_OUTPUT") {
+				name = match[1]
+			}
+			if name != "" && !contains(writes, name) {
+				writes = append(writes, name)
+			}
+		}
+	}
+	sort.Strings(writes)
+	return writes
+}
+
+// contains reports whether items contains item.
+func contains(items []string, item string) bool {
+	for _, existing := range items {
+		if existing == item {
+			return true
+		}
+	}
+	return false
+}
+
+// localWorkflow returns the filename of the reusable workflow in the same
+// repository called by uses, or "" if uses refers to another repository.
+func localWorkflow(uses string) string {
+	if !strings.HasPrefix(uses, "./") {
+		return ""
+	}
+	return path.Base(uses)
+}
+
+// analyzeWorkflow reports the findings of a single workflow. all holds every
+// workflow of the directory, to resolve calls to local reusable workflows.
+func analyzeWorkflow(wf workflow, all map[string]workflow) []Finding {
+	var findings []Finding
+	add := func(kind, output, detail string) {
+		findings = append(findings, Finding{Workflow: wf.filename, Kind: kind, Output: output, Detail: detail})
+	}
+
+	for _, jobID := range wf.order {
+		j := wf.jobs[jobID]
+
+		// Step outputs consumed within the job
+		ids := make(map[string]step)
+		for _, s := range j.steps {
+			if s.id != "" {
+				ids[s.id] = s
+			}
+		}
+		consumed := make(map[string]bool)
+		for _, match := range stepsOutputPattern.FindAllStringSubmatch(j.text, -1) {
+			stepID, name := match[1], match[2]
+			consumed[stepID+"."+name] = true
+
+			reference := fmt.Sprintf("jobs.%s: steps.%s.outputs.%s", jobID, stepID, name)
+			s, ok := ids[stepID]
+			switch {
+			case !ok:
+				add(Undeclared, reference, fmt.Sprintf("no step with id `%s` in job `%s`", stepID, jobID))
+			case s.uses == "" && !contains(s.writes, name):
+				add(Undeclared, reference, fmt.Sprintf("step `%s` does not write output `%s`", stepID, name))
+			}
+		}
+		for i, s := range j.steps {
+			for _, name := range s.writes {
+				if s.id == "" {
+					add(Unused, fmt.Sprintf("jobs.%s: step %d output %s", jobID, i+1, name), "the step has no id, so its outputs cannot be read")
+				} else if !consumed[s.id+"."+name] {
+					add(Unused, fmt.Sprintf("jobs.%s: steps.%s.outputs.%s", jobID, s.id, name), "not consumed within the job")
+				}
+			}
+		}
+
+		// Job outputs consumed by other jobs and workflow outputs
+		for _, name := range sortedKeys(j.outputs) {
+			if !jobOutputConsumed(wf, jobID, name) {
+				add(Unused, fmt.Sprintf("jobs.%s.outputs.%s", jobID, name), "not consumed by any job or workflow output")
+			}
+		}
+
+		for _, match := range needsOutputPattern.FindAllStringSubmatch(j.text, -1) {
+			neededID, name := match[1], match[2]
+			reference := fmt.Sprintf("jobs.%s: needs.%s.outputs.%s", jobID, neededID, name)
+			if detail := undeclaredJobOutput(wf, all, neededID, name); detail != "" {
+				add(Undeclared, reference, detail)
+			}
+		}
+	}
+
+	// Workflow outputs consumed by callers in the same directory
+	for _, name := range sortedKeys(wf.outputs) {
+		for _, match := range jobsOutputPattern.FindAllStringSubmatch(wf.outputs[name], -1) {
+			jobID, output := match[1], match[2]
+			reference := fmt.Sprintf("on.workflow_call.outputs.%s: jobs.%s.outputs.%s", name, jobID, output)
+			if detail := undeclaredJobOutput(wf, all, jobID, output); detail != "" {
+				add(Undeclared, reference, detail)
+			}
+		}
+
+		if !workflowOutputConsumed(wf.filename, name, all) {
+			add(Unused, "on.workflow_call.outputs."+name, "not consumed by any workflow in the directory")
+		}
+	}
+
+	return dedupe(findings)
+}
+
+// jobOutputConsumed reports whether output name of job jobID is referenced
+// by another job or a workflow output.
+func jobOutputConsumed(wf workflow, jobID, name string) bool {
+	for otherID, other := range wf.jobs {
+		if otherID == jobID {
+			continue
+		}
+		for _, match := range needsOutputPattern.FindAllStringSubmatch(other.text, -1) {
+			if match[1] == jobID && match[2] == name {
+				return true
+			}
+		}
+	}
+	for _, value := range wf.outputs {
+		for _, match := range jobsOutputPattern.FindAllStringSubmatch(value, -1) {
+			if match[1] == jobID && match[2] == name {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// undeclaredJobOutput explains why output name of job jobID is not declared,
+// or returns "" if it is or cannot be checked.
+func undeclaredJobOutput(wf workflow, all map[string]workflow, jobID, name string) string {
+	j, ok := wf.jobs[jobID]
+	if !ok {
+		return fmt.Sprintf("no job `%s`", jobID)
+	}
+
+	if j.uses != "" {
+		called, ok := all[localWorkflow(j.uses)]
+		if !ok {
+			// Outputs of workflows in other repositories are unknown
+			return ""
+		}
+		if _, declared := called.outputs[name]; !declared {
+			return fmt.Sprintf("reusable workflow `%s` does not declare output `%s`", called.filename, name)
+		}
+		return ""
+	}
+
+	if _, declared := j.outputs[name]; !declared {
+		return fmt.Sprintf("job `%s` does not declare output `%s`", jobID, name)
+	}
+	return ""
+}
+
+// workflowOutputConsumed reports whether output name of the reusable
+// workflow filename is consumed by a caller in all.
+func workflowOutputConsumed(filename, name string, all map[string]workflow) bool {
+	for _, caller := range all {
+		for callerJobID, callerJob := range caller.jobs {
+			if localWorkflow(callerJob.uses) != filename {
+				continue
+			}
+			if jobOutputConsumed(caller, callerJobID, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// dedupe removes repeated findings, keeping the first occurrence.
+func dedupe(findings []Finding) []Finding {
+	seen := make(map[Finding]bool)
+	var unique []Finding
+	for _, finding := range findings {
+		if !seen[finding] {
+			seen[finding] = true
+			unique = append(unique, finding)
+		}
+	}
+	return unique
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// Render renders the findings as a markdown document.
+func Render(findings []Finding) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Outputs Analysis\n")
+
+	sections := []struct {
+		kind, title, column string
+	}{
+		{Unused, "Unused outputs", "Output"},
+		{Undeclared, "Undeclared outputs", "Reference"},
+	}
+	for _, section := range sections {
+		sb.WriteString(fmt.Sprintf("\n## %s\n\n", section.title))
+
+		var rows []Finding
+		for _, finding := range findings {
+			if finding.Kind == section.kind {
+				rows = append(rows, finding)
+			}
+		}
+		if len(rows) == 0 {
+			sb.WriteString("None.\n")
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("| Workflow | %s | Detail |\n", section.column))
+		sb.WriteString("| --- | --- | --- |\n")
+		for _, finding := range rows {
+			sb.WriteString(fmt.Sprintf("| %s | `%s` | %s |\n", finding.Workflow, finding.Output, finding.Detail))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,120 @@
+package outputs
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeWorkflows writes workflow files into a new directory
+func writeWorkflows(t *testing.T, workflows map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range workflows {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+	return dir
+}
+
+// TestWrittenOutputs tests detecting outputs written by run scripts
+func TestWrittenOutputs(t *testing.T) {
+	run := `echo "version=$(cat VERSION)" >> "$GITHUB_OUTPUT"
+echo "DEBUG=1" >> "$GITHUB_ENV"
+{
+  echo "notes<<EOF"
+  cat NOTES.md
+  echo "EOF"
+} >> "$GITHUB_OUTPUT"
+echo "::set-output name=legacy::value"
+`
+	expected := []string{"legacy", "notes", "version"}
+	if writes := writtenOutputs(run); !reflect.DeepEqual(writes, expected) {
+		t.Errorf("Expected outputs %v, got %v", expected, writes)
+	}
+}
+
+// TestAnalyze tests finding unused and undeclared outputs
+func TestAnalyze(t *testing.T) {
+	dir := writeWorkflows(t, map[string]string{
+		"build.yml": `on:
+  workflow_call:
+    outputs:
+      image:
+        value: ${{ jobs.build.outputs.image }}
+      digest:
+        value: ${{ jobs.build.outputs.digest }}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    outputs:
+      image: ${{ steps.meta.outputs.image }}
+      tags: ${{ steps.meta.outputs.tags }}
+    steps:
+      - id: meta
+        run: |
+          echo "image=app" >> "$GITHUB_OUTPUT"
+          echo "tags=latest" >> "$GITHUB_OUTPUT"
+          echo "unused=1" >> "$GITHUB_OUTPUT"
+      - run: echo "orphan=1" >> "$GITHUB_OUTPUT"
+`,
+		"release.yml": `on: push
+jobs:
+  build:
+    uses: ./.github/workflows/build.yml
+  deploy:
+    needs: [build, test]
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+        id: checkout
+      - run: deploy ${{ needs.build.outputs.image }} ${{ needs.build.outputs.size }}
+      - run: echo ${{ needs.test.outputs.coverage }} ${{ steps.checkout.outputs.ref }} ${{ steps.missing.outputs.value }}
+  test:
+    runs-on: ubuntu-latest
+    steps:
+      - run: go test ./...
+`,
+	})
+
+	findings, err := Analyze(dir)
+	if err != nil {
+		t.Fatalf("Analyze failed: %v", err)
+	}
+
+	expected := []Finding{
+		{"build.yml", Unused, "jobs.build: steps.meta.outputs.unused", "not consumed within the job"},
+		{"build.yml", Unused, "jobs.build: step 2 output orphan", "the step has no id, so its outputs cannot be read"},
+		{"build.yml", Unused, "jobs.build.outputs.tags", "not consumed by any job or workflow output"},
+		{"build.yml", Undeclared, "on.workflow_call.outputs.digest: jobs.build.outputs.digest", "job `build` does not declare output `digest`"},
+		{"build.yml", Unused, "on.workflow_call.outputs.digest", "not consumed by any workflow in the directory"},
+		{"release.yml", Undeclared, "jobs.deploy: steps.missing.outputs.value", "no step with id `missing` in job `deploy`"},
+		{"release.yml", Undeclared, "jobs.deploy: needs.build.outputs.size", "reusable workflow `build.yml` does not declare output `size`"},
+		{"release.yml", Undeclared, "jobs.deploy: needs.test.outputs.coverage", "job `test` does not declare output `coverage`"},
+	}
+	if !reflect.DeepEqual(findings, expected) {
+		t.Errorf("Expected findings:\n%+v\nGot:\n%+v", expected, findings)
+	}
+
+	content := Render(findings)
+	for _, want := range []string{
+		"## Unused outputs\n\n| Workflow | Output | Detail |",
+		"| build.yml | `jobs.build.outputs.tags` | not consumed by any job or workflow output |",
+		"## Undeclared outputs\n\n| Workflow | Reference | Detail |",
+	} {
+		if !strings.Contains(content, want) {
+			t.Errorf("Expected output to contain %q, got:\n%s", want, content)
+		}
+	}
+}
+
+// TestRenderNoFindings tests the report without findings
+func TestRenderNoFindings(t *testing.T) {
+	expected := "# Outputs Analysis\n\n## Unused outputs\n\nNone.\n\n## Undeclared outputs\n\nNone.\n"
+	if content := Render(nil); content != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, content)
+	}
+}
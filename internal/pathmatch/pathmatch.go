@@ -0,0 +1,120 @@
+package pathmatch
+
+import (
+	"regexp"
+	"strings"
+	"sync"
+)
+
+var (
+	cacheMu sync.Mutex
+	cache   = make(map[string]*regexp.Regexp)
+)
+
+// Match reports whether path matches the GitHub Actions filter pattern.
+// Patterns support `*` (any characters except `/`), `**` (any characters),
+// `?` (one character except `/`), `+` (one or more of the preceding
+// character), and `[...]` character classes. A leading `!` is not
+// interpreted here; callers handle negation.
+func Match(pattern, path string) bool {
+	return compile(pattern).MatchString(path)
+}
+
+// compile converts a filter pattern into an anchored regular expression,
+// caching the result.
+func compile(pattern string) *regexp.Regexp {
+	cacheMu.Lock()
+	defer cacheMu.Unlock()
+
+	if re, ok := cache[pattern]; ok {
+		return re
+	}
+
+	var sb strings.Builder
+	sb.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				// "**/" also matches zero directories
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					i++
+					sb.WriteString("(?:.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '+':
+			sb.WriteString("+")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end == -1 {
+				sb.WriteString(regexp.QuoteMeta(string(c)))
+				continue
+			}
+			sb.WriteString(pattern[i : i+end+1])
+			i += end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+
+	re, err := regexp.Compile(sb.String())
+	if err != nil {
+		// Fall back to a literal match for patterns with invalid classes
+		re = regexp.MustCompile("^" + regexp.QuoteMeta(pattern) + "$")
+	}
+	cache[pattern] = re
+	return re
+}
+
+// MatchFilters evaluates path against an ordered list of filter patterns as
+// GitHub does for `paths`: patterns prefixed with `!` exclude, and the last
+// matching pattern decides.
+func MatchFilters(patterns []string, path string) bool {
+	matched := false
+	for _, pattern := range patterns {
+		if negated := strings.HasPrefix(pattern, "!"); negated {
+			if Match(pattern[1:], path) {
+				matched = false
+			}
+		} else if Match(pattern, path) {
+			matched = true
+		}
+	}
+	return matched
+}
+
+// MatchGitignore reports whether path matches a gitignore-style pattern as
+// used by CODEOWNERS files. Patterns without a slash other than a trailing
+// one match at any depth, a leading `/` anchors the pattern to the root, and
+// a pattern whose last segment has no wildcard also matches everything under
+// the directory it names.
+func MatchGitignore(pattern, path string) bool {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	dirOnly := strings.HasSuffix(pattern, "/")
+
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" {
+		return false
+	}
+	if !anchored {
+		pattern = "**/" + pattern
+	}
+
+	if !dirOnly && Match(pattern, path) {
+		return true
+	}
+	lastSegment := pattern[strings.LastIndex(pattern, "/")+1:]
+	if !strings.Contains(lastSegment, "*") || lastSegment == "**" {
+		return Match(pattern+"/**", path)
+	}
+	return false
+}
@@ -0,0 +1,82 @@
+package pathmatch
+
+import "testing"
+
+// TestMatch tests GitHub Actions filter pattern matching
+func TestMatch(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"backend/src/**", "backend/src/apiserver/main.go", true},
+		{"backend/src/**", "backend/test/main.go", false},
+		{"*.md", "README.md", true},
+		{"*.md", "docs/README.md", false},
+		{"**/*.md", "docs/README.md", true},
+		{"**/*.md", "README.md", true},
+		{"**/OWNERS", "backend/OWNERS", true},
+		{".github/workflows/ci.yml", ".github/workflows/ci.yml", true},
+		{".github/workflows/ci.yml", ".github/workflows/ciXyml", false},
+		{"docs/?.txt", "docs/a.txt", true},
+		{"docs/?.txt", "docs/ab.txt", false},
+		{"v[12].txt", "v2.txt", true},
+		{"v[12].txt", "v3.txt", false},
+		{"**", "any/path/at/all", true},
+	}
+
+	for _, tc := range testCases {
+		if actual := Match(tc.pattern, tc.path); actual != tc.match {
+			t.Errorf("Match(%q, %q) = %v, expected %v", tc.pattern, tc.path, actual, tc.match)
+		}
+	}
+}
+
+// TestMatchFilters tests ordered filters with negation
+func TestMatchFilters(t *testing.T) {
+	patterns := []string{"backend/**", "!**/*.md", "backend/docs/keep.md"}
+
+	testCases := map[string]bool{
+		"backend/main.go":      true,
+		"backend/README.md":    false,
+		"backend/docs/keep.md": true,
+		"frontend/main.go":     false,
+	}
+
+	for path, expected := range testCases {
+		if actual := MatchFilters(patterns, path); actual != expected {
+			t.Errorf("MatchFilters(%q) = %v, expected %v", path, actual, expected)
+		}
+	}
+}
+
+// TestMatchGitignore tests gitignore-style pattern matching
+func TestMatchGitignore(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		path    string
+		match   bool
+	}{
+		{"*", ".github/workflows/ci.yml", true},
+		{"*.yml", ".github/workflows/ci.yml", true},
+		{"*.yml", ".github/workflows/ci.yaml", false},
+		{"/.github/workflows/", ".github/workflows/ci.yml", true},
+		{"/.github/workflows/", "nested/.github/workflows/ci.yml", false},
+		{".github/workflows/", ".github/workflows/ci.yml", true},
+		{"workflows/", ".github/workflows/ci.yml", true},
+		{".github/", ".github", false},
+		{"/.github/workflows/ci.yml", ".github/workflows/ci.yml", true},
+		{".github/workflows/ci.yml", ".github/workflows/cd.yml", false},
+		{"docs/*", "docs/index.md", true},
+		{"docs/*", "docs/build/index.md", false},
+		{"/docs", "docs/build/index.md", true},
+		{"**/deploy*.yml", ".github/workflows/deploy-prod.yml", true},
+		{".github/**", ".github/workflows/ci.yml", true},
+	}
+
+	for _, tc := range testCases {
+		if actual := MatchGitignore(tc.pattern, tc.path); actual != tc.match {
+			t.Errorf("MatchGitignore(%q, %q) = %v, expected %v", tc.pattern, tc.path, actual, tc.match)
+		}
+	}
+}
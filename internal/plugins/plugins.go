@@ -0,0 +1,84 @@
+// Package plugins discovers the executables on PATH that extend gha-docs
+// with subcommands, kubectl-style: running "gha-docs audit" runs the
+// ghadoc-audit executable, as gha-docs has no audit command.
+package plugins
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+)
+
+// Prefix is the prefix of the names of plugin executables.
+const Prefix = "ghadoc-"
+
+// Plugin is an executable providing a subcommand.
+type Plugin struct {
+	Name string // Subcommand, the executable name without Prefix
+	Path string // Path of the executable
+}
+
+// Find returns the plugin executable on PATH providing the subcommand name.
+func Find(name string) (Plugin, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) || strings.HasPrefix(name, "-") {
+		return Plugin{}, false
+	}
+	path, err := exec.LookPath(Prefix + name)
+	if err != nil {
+		return Plugin{}, false
+	}
+	return Plugin{Name: name, Path: path}, true
+}
+
+// List returns the plugins in the directories of pathList, a list like the
+// PATH environment variable, sorted by name. Like Find, the first executable
+// of a name on the path wins.
+func List(pathList string) []Plugin {
+	var found []Plugin
+	seen := make(map[string]bool)
+	for _, dir := range filepath.SplitList(pathList) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := pluginName(entry.Name())
+			if !ok || seen[name] || !executable(filepath.Join(dir, entry.Name())) {
+				continue
+			}
+			seen[name] = true
+			found = append(found, Plugin{Name: name, Path: filepath.Join(dir, entry.Name())})
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool { return found[i].Name < found[j].Name })
+	return found
+}
+
+// pluginName returns the subcommand of the plugin executable filename.
+func pluginName(filename string) (string, bool) {
+	if runtime.GOOS == "windows" {
+		filename = strings.TrimSuffix(filename, filepath.Ext(filename))
+	}
+	name := strings.TrimPrefix(filename, Prefix)
+	return name, name != filename && name != ""
+}
+
+// executable reports whether the file at path is a regular file that can be
+// executed.
+func executable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return true
+	}
+	return info.Mode().Perm()&0111 != 0
+}
@@ -0,0 +1,52 @@
+package plugins
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeExecutable writes a script to dir/name with the given mode
+func writeExecutable(t *testing.T, dir, name string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"), mode); err != nil {
+		t.Fatalf("Failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// TestList tests discovering the plugins on PATH
+func TestList(t *testing.T) {
+	first, second := t.TempDir(), t.TempDir()
+	lint := writeExecutable(t, first, "ghadoc-lint", 0755)
+	writeExecutable(t, second, "ghadoc-lint", 0755)
+	audit := writeExecutable(t, second, "ghadoc-audit", 0755)
+	writeExecutable(t, second, "ghadoc-notes", 0644)
+	writeExecutable(t, second, "ghadoc-", 0755)
+	writeExecutable(t, second, "kubectl-ghadoc", 0755)
+
+	plugins := List(first + string(os.PathListSeparator) + second + string(os.PathListSeparator) + filepath.Join(first, "missing"))
+	expected := []Plugin{{Name: "audit", Path: audit}, {Name: "lint", Path: lint}}
+	if !reflect.DeepEqual(plugins, expected) {
+		t.Errorf("Expected plugins %+v, got %+v", expected, plugins)
+	}
+}
+
+// TestFind tests looking up the plugin of a subcommand on PATH
+func TestFind(t *testing.T) {
+	dir := t.TempDir()
+	lint := writeExecutable(t, dir, "ghadoc-lint", 0755)
+	t.Setenv("PATH", dir)
+
+	plugin, ok := Find("lint")
+	if !ok || plugin.Path != lint {
+		t.Errorf("Expected %s, got %+v", lint, plugin)
+	}
+	for _, name := range []string{"audit", "", "-v", "../lint"} {
+		if plugin, ok := Find(name); ok {
+			t.Errorf("Expected no plugin for %q, got %+v", name, plugin)
+		}
+	}
+}
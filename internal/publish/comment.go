@@ -0,0 +1,67 @@
+package publish
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/diff"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// commentMarker identifies the sticky comment among the comments of a pull
+// request.
+const commentMarker = "<!-- gha-docs:pr-comment -->"
+
+// CommentBody returns the body of the pull request comment reporting whether
+// the committed documentation file docsFile, with content current, matches
+// the regenerated documentation, and whether it is stale.
+func CommentBody(docsFile, current, regenerated, command string) (string, bool) {
+	var sb strings.Builder
+	sb.WriteString(commentMarker + "\n")
+	sb.WriteString("### Workflow documentation\n\n")
+
+	lines := diff.Lines(current, regenerated)
+	if lines == "" {
+		sb.WriteString(fmt.Sprintf("`%s` is up to date. :white_check_mark:\n", docsFile))
+		return sb.String(), false
+	}
+
+	sb.WriteString(fmt.Sprintf("`%s` is out of date with the workflows of this pull request. Regenerate it with:\n\n", docsFile))
+	sb.WriteString("```sh\n" + command + "\n```\n\n")
+	sb.WriteString("<details>\n<summary>Changes to the documentation</summary>\n\n")
+	sb.WriteString("```diff\n" + lines + "```\n\n")
+	sb.WriteString("</details>\n")
+	return sb.String(), true
+}
+
+// UpsertComment updates the sticky comment on the pull request number of
+// owner/repo with body, or posts it if there is none and create is set. It
+// reports whether a comment was posted or updated.
+func UpsertComment(ctx context.Context, client *github.Client, owner, repo string, number int, body string, create bool) (bool, error) {
+	comments, err := client.ListIssueComments(ctx, owner, repo, number)
+	if err != nil {
+		return false, fmt.Errorf("error listing comments: %v", err)
+	}
+
+	for _, comment := range comments {
+		if !strings.Contains(comment.Body, commentMarker) {
+			continue
+		}
+		if comment.Body == body {
+			return false, nil
+		}
+		if err := client.UpdateIssueComment(ctx, owner, repo, comment.ID, body); err != nil {
+			return false, fmt.Errorf("error updating comment: %v", err)
+		}
+		return true, nil
+	}
+
+	if !create {
+		return false, nil
+	}
+	if err := client.CreateIssueComment(ctx, owner, repo, number, body); err != nil {
+		return false, fmt.Errorf("error posting comment: %v", err)
+	}
+	return true, nil
+}
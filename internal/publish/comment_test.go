@@ -0,0 +1,96 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestCommentBody tests reporting stale and fresh documentation
+func TestCommentBody(t *testing.T) {
+	body, stale := CommentBody("workflows.md", "| a |\n", "| b |\n", "gha-docs generate -w .github/workflows")
+	if !stale {
+		t.Error("Expected changed documentation to be stale")
+	}
+	for _, expected := range []string{
+		commentMarker + "\n### Workflow documentation\n",
+		"`workflows.md` is out of date",
+		"```sh\ngha-docs generate -w .github/workflows\n```",
+		"```diff\n-| a |\n+| b |\n```",
+	} {
+		if !strings.Contains(body, expected) {
+			t.Errorf("Expected comment to contain %q, got:\n%s", expected, body)
+		}
+	}
+
+	body, stale = CommentBody("workflows.md", "| a |\n", "| a |\n", "")
+	if stale || !strings.Contains(body, "`workflows.md` is up to date.") {
+		t.Errorf("Expected up to date comment, got %v:\n%s", stale, body)
+	}
+}
+
+// TestUpsertComment tests posting and updating the sticky comment
+func TestUpsertComment(t *testing.T) {
+	comments := []github.IssueComment{{ID: 1, Body: "Looks good"}}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		var update struct {
+			Body string `json:"body"`
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /repos/owner/repo/issues/7/comments":
+			json.NewEncoder(w).Encode(comments)
+		case "POST /repos/owner/repo/issues/7/comments":
+			json.NewDecoder(r.Body).Decode(&update)
+			comments = append(comments, github.IssueComment{ID: 2, Body: update.Body})
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{}`))
+		case "PATCH /repos/owner/repo/issues/comments/2":
+			json.NewDecoder(r.Body).Decode(&update)
+			comments[1].Body = update.Body
+			w.Write([]byte(`{}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := github.NewClient(server.URL, "token")
+
+	// Fresh documentation without a sticky comment is not commented on
+	posted, err := UpsertComment(context.Background(), client, "owner", "repo", 7, commentMarker+"\nup to date", false)
+	if err != nil || posted {
+		t.Errorf("Expected no comment, got %v, %v", posted, err)
+	}
+
+	posted, err = UpsertComment(context.Background(), client, "owner", "repo", 7, commentMarker+"\nstale", true)
+	if err != nil || !posted {
+		t.Errorf("Expected a new comment, got %v, %v", posted, err)
+	}
+
+	posted, err = UpsertComment(context.Background(), client, "owner", "repo", 7, commentMarker+"\nup to date", false)
+	if err != nil || !posted {
+		t.Errorf("Expected the comment to be updated, got %v, %v", posted, err)
+	}
+	if len(comments) != 2 || comments[1].Body != commentMarker+"\nup to date" {
+		t.Errorf("Unexpected comments: %+v", comments)
+	}
+
+	// An unchanged comment is left alone
+	posted, err = UpsertComment(context.Background(), client, "owner", "repo", 7, commentMarker+"\nup to date", true)
+	if err != nil || posted {
+		t.Errorf("Expected no update, got %v, %v", posted, err)
+	}
+
+	expected := "GET /repos/owner/repo/issues/7/comments, GET /repos/owner/repo/issues/7/comments, POST /repos/owner/repo/issues/7/comments, " +
+		"GET /repos/owner/repo/issues/7/comments, PATCH /repos/owner/repo/issues/comments/2, GET /repos/owner/repo/issues/7/comments"
+	if strings.Join(requests, ", ") != expected {
+		t.Errorf("Unexpected requests: %v", requests)
+	}
+}
@@ -0,0 +1,132 @@
+package publish
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Confluence publishes pages to a Confluence site through its REST API.
+type Confluence struct {
+	BaseURL    string // e.g. https://example.atlassian.net/wiki
+	User       string // Email for Confluence Cloud API tokens; empty for personal access tokens
+	Token      string
+	HTTPClient *http.Client
+}
+
+// NewConfluence returns a Confluence client for the site at baseURL. With a
+// user, the token is sent with basic authentication as Confluence Cloud
+// expects, otherwise as a bearer token as Confluence Data Center expects.
+func NewConfluence(baseURL, user, token string) *Confluence {
+	return &Confluence{
+		BaseURL:    strings.TrimSuffix(baseURL, "/"),
+		User:       user,
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// confluencePage is the subset of a Confluence page used when updating it.
+type confluencePage struct {
+	ID    string `json:"id"`
+	Type  string `json:"type"`
+	Title string `json:"title"`
+	Space struct {
+		Key string `json:"key"`
+	} `json:"space"`
+	Version struct {
+		Number int `json:"number"`
+	} `json:"version"`
+	Body *confluenceBody `json:"body,omitempty"`
+}
+
+// confluenceBody is the body of a page in the storage format.
+type confluenceBody struct {
+	Storage struct {
+		Value          string `json:"value"`
+		Representation string `json:"representation"`
+	} `json:"storage"`
+}
+
+// UpdatePage replaces the content of the page pageID in the space spaceKey
+// with markdown converted to the storage format, keeping its title unless
+// title is set. The page must exist; publishing it again creates a new
+// version of it. The requests are given up once ctx is done.
+func (c *Confluence) UpdatePage(ctx context.Context, spaceKey, pageID, title, markdown string) error {
+	var page confluencePage
+	err := c.do(ctx, http.MethodGet, "/rest/api/content/"+pageID+"?expand=version,space", nil, &page)
+	if err != nil {
+		return fmt.Errorf("error reading page %s: %v", pageID, err)
+	}
+	if spaceKey != "" && page.Space.Key != spaceKey {
+		return fmt.Errorf("page %s is in space %s, not %s", pageID, page.Space.Key, spaceKey)
+	}
+
+	update := confluencePage{ID: pageID, Type: "page", Title: page.Title, Body: &confluenceBody{}}
+	if title != "" {
+		update.Title = title
+	}
+	update.Space.Key = page.Space.Key
+	update.Version.Number = page.Version.Number + 1
+	update.Body.Storage.Value = ToHTML(markdown)
+	update.Body.Storage.Representation = "storage"
+
+	err = c.do(ctx, http.MethodPut, "/rest/api/content/"+pageID, update, nil)
+	if err != nil {
+		return fmt.Errorf("error updating page %s: %v", pageID, err)
+	}
+	return nil
+}
+
+// do sends a request with an optional JSON body and decodes the JSON
+// response into v if v is not nil.
+func (c *Confluence) do(ctx context.Context, method, path string, body interface{}, v interface{}) error {
+	var reader io.Reader
+	if body != nil {
+		content, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(content)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.BaseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.User != "" {
+		req.SetBasicAuth(c.User, c.Token)
+	} else if c.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.Token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		var apiErr struct {
+			Message string `json:"message"`
+		}
+		json.NewDecoder(resp.Body).Decode(&apiErr)
+		return fmt.Errorf("Confluence API returned %d: %s", resp.StatusCode, apiErr.Message)
+	}
+
+	if v != nil {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			return fmt.Errorf("error decoding Confluence API response: %v", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package publish
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestConfluenceUpdatePage tests publishing markdown to a Confluence page
+func TestConfluenceUpdatePage(t *testing.T) {
+	var update confluencePage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if user, token, ok := r.BasicAuth(); !ok || user != "me@example.com" || token != "secret" {
+			t.Errorf("Expected basic authentication, got %q", r.Header.Get("Authorization"))
+		}
+		if r.URL.Path != "/wiki/rest/api/content/123" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"id": "123", "type": "page", "title": "Workflows", "space": {"key": "ENG"}, "version": {"number": 4}}`))
+		case http.MethodPut:
+			if err := json.NewDecoder(r.Body).Decode(&update); err != nil {
+				t.Errorf("Failed to decode update: %v", err)
+			}
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer server.Close()
+
+	confluence := NewConfluence(server.URL+"/wiki/", "me@example.com", "secret")
+	if err := confluence.UpdatePage(context.Background(), "ENG", "123", "", "# Workflows\n"); err != nil {
+		t.Fatalf("UpdatePage failed: %v", err)
+	}
+
+	if update.Title != "Workflows" || update.Space.Key != "ENG" || update.Version.Number != 5 {
+		t.Errorf("Unexpected update: %+v", update)
+	}
+	if update.Body == nil || update.Body.Storage.Value != "<h1>Workflows</h1>\n" || update.Body.Storage.Representation != "storage" {
+		t.Errorf("Unexpected body: %+v", update.Body)
+	}
+
+	err := confluence.UpdatePage(context.Background(), "OPS", "123", "", "# Workflows\n")
+	if err == nil || !strings.Contains(err.Error(), "is in space ENG, not OPS") {
+		t.Errorf("Expected space mismatch error, got %v", err)
+	}
+}
+
+// TestConfluenceError tests error reporting for failed requests
+func TestConfluenceError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer pat" {
+			t.Errorf("Expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "No content found with id 9"}`))
+	}))
+	defer server.Close()
+
+	err := NewConfluence(server.URL, "", "pat").UpdatePage(context.Background(), "", "9", "", "")
+	if err == nil || err.Error() != "error reading page 9: Confluence API returned 404: No content found with id 9" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
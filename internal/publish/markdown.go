@@ -0,0 +1,165 @@
+package publish
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Patterns of the inline markdown converted by ToHTML, applied to escaped text.
+var (
+	codePattern   = regexp.MustCompile("`([^`]+)`")
+	imagePattern  = regexp.MustCompile(`!\[([^\]]*)\]\(([^)\s]+)\)`)
+	linkPattern   = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+	boldPattern   = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	italicPattern = regexp.MustCompile(`(^|[\s(])_([^_]+)_([\s).,:;!?]|$)`)
+	listPattern   = regexp.MustCompile(`^\s*(?:[-*]|\d+\.)\s+`)
+)
+
+// ToHTML converts the markdown generated by gha-docs into well-formed XHTML:
+// headings, paragraphs, lists, tables, fenced code blocks, and inline code,
+// emphasis, links, and images. Other HTML in the markdown is escaped, except
+// for the <br> line breaks of workflow descriptions.
+func ToHTML(markdown string) string {
+	var sb strings.Builder
+	lines := strings.Split(strings.ReplaceAll(markdown, "\r\n", "\n"), "\n")
+
+	var paragraph []string
+	flush := func() {
+		if len(paragraph) > 0 {
+			sb.WriteString("<p>" + inline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flush()
+
+		case strings.HasPrefix(trimmed, "<!--"):
+			// Comments such as injection markers are not content
+			flush()
+			for !strings.Contains(lines[i], "-->") && i+1 < len(lines) {
+				i++
+			}
+
+		case strings.HasPrefix(trimmed, "```"):
+			flush()
+			var code []string
+			for i+1 < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i+1]), "```") {
+				i++
+				code = append(code, lines[i])
+			}
+			i++
+			sb.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case strings.HasPrefix(trimmed, "#"):
+			flush()
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			if level > 6 || !strings.HasPrefix(trimmed[level:], " ") {
+				paragraph = append(paragraph, trimmed)
+				continue
+			}
+			tag := "h" + string(rune('0'+level))
+			sb.WriteString("<" + tag + ">" + inline(strings.TrimSpace(trimmed[level:])) + "</" + tag + ">\n")
+
+		case strings.HasPrefix(trimmed, "|"):
+			flush()
+			var rows []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|"); i++ {
+				rows = append(rows, strings.TrimSpace(lines[i]))
+			}
+			i--
+			writeTable(&sb, rows)
+
+		case listPattern.MatchString(line):
+			flush()
+			tag := "ul"
+			if trimmed[0] >= '0' && trimmed[0] <= '9' {
+				tag = "ol"
+			}
+			sb.WriteString("<" + tag + ">\n")
+			for ; i < len(lines) && listPattern.MatchString(lines[i]); i++ {
+				sb.WriteString("<li>" + inline(listPattern.ReplaceAllString(lines[i], "")) + "</li>\n")
+			}
+			i--
+			sb.WriteString("</" + tag + ">\n")
+
+		default:
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flush()
+
+	return sb.String()
+}
+
+// writeTable writes the rows of a markdown table, whose second row separates
+// the header from the body.
+func writeTable(sb *strings.Builder, rows []string) {
+	sb.WriteString("<table>\n")
+	for i, row := range rows {
+		if i == 1 && strings.Trim(row, "|-: ") == "" {
+			continue
+		}
+
+		tag := "td"
+		if i == 0 {
+			tag = "th"
+		}
+		sb.WriteString("<tr>")
+		for _, cell := range splitRow(row) {
+			sb.WriteString("<" + tag + ">" + inline(cell) + "</" + tag + ">")
+		}
+		sb.WriteString("</tr>\n")
+	}
+	sb.WriteString("</table>\n")
+}
+
+// splitRow splits a table row into its trimmed cells. Escaped pipes are part
+// of the cells.
+func splitRow(row string) []string {
+	row = strings.TrimSuffix(strings.TrimPrefix(row, "|"), "|")
+
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(row); i++ {
+		switch {
+		case row[i] == '\\' && i+1 < len(row) && row[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case row[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(row[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// inline converts the inline markdown of text.
+func inline(text string) string {
+	// Code spans are converted first so that their content is left alone
+	var spans []string
+	text = codePattern.ReplaceAllStringFunc(text, func(match string) string {
+		spans = append(spans, "<code>"+html.EscapeString(match[1:len(match)-1])+"</code>")
+		return "\x00"
+	})
+
+	text = html.EscapeString(text)
+	text = strings.NewReplacer("&lt;br&gt;", "<br/>", "&lt;br/&gt;", "<br/>", "&lt;br /&gt;", "<br/>").Replace(text)
+	text = imagePattern.ReplaceAllString(text, `<img alt="$1" src="$2"/>`)
+	text = linkPattern.ReplaceAllString(text, `<a href="$2">$1</a>`)
+	text = boldPattern.ReplaceAllString(text, "<strong>$1</strong>")
+	text = italicPattern.ReplaceAllString(text, "$1<em>$2</em>$3")
+
+	for _, span := range spans {
+		text = strings.Replace(text, "\x00", span, 1)
+	}
+	return text
+}
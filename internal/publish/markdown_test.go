@@ -0,0 +1,44 @@
+package publish
+
+import "testing"
+
+// TestToHTML tests converting generated markdown into XHTML
+func TestToHTML(t *testing.T) {
+	markdown := `# GitHub Workflows Summary
+
+<!-- gha-docs:start -->
+| Filename | Description | Triggers |
+| --- | --- | --- |
+| [ci.yml](.github/workflows/ci.yml) | Runs **tests** & lint.<br>Second line | push, pull_request |
+| ` + "`a`" + ` | Pipe \| inside | _none_ |
+
+Some _emphasis_ and snake_case_name
+with [a link](https://example.com/?a=1&b=2).
+
+- ` + "`push`" + ` to main
+- ![badge](https://example.com/badge.svg)
+
+1. First
+
+` + "```yaml\non: <push>\n```\n"
+
+	expected := `<h1>GitHub Workflows Summary</h1>
+<table>
+<tr><th>Filename</th><th>Description</th><th>Triggers</th></tr>
+<tr><td><a href=".github/workflows/ci.yml">ci.yml</a></td><td>Runs <strong>tests</strong> &amp; lint.<br/>Second line</td><td>push, pull_request</td></tr>
+<tr><td><code>a</code></td><td>Pipe | inside</td><td><em>none</em></td></tr>
+</table>
+<p>Some <em>emphasis</em> and snake_case_name with <a href="https://example.com/?a=1&amp;b=2">a link</a>.</p>
+<ul>
+<li><code>push</code> to main</li>
+<li><img alt="badge" src="https://example.com/badge.svg"/></li>
+</ul>
+<ol>
+<li>First</li>
+</ol>
+<pre><code>on: &lt;push&gt;</code></pre>
+`
+	if html := ToHTML(markdown); html != expected {
+		t.Errorf("Expected:\n%s\nGot:\n%s", expected, html)
+	}
+}
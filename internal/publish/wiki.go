@@ -0,0 +1,85 @@
+package publish
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/git"
+)
+
+// Default author of the commits that publish wiki pages.
+const (
+	DefaultAuthorName  = "github-actions[bot]"
+	DefaultAuthorEmail = "41898282+github-actions[bot]@users.noreply.github.com"
+)
+
+// Wiki publishes pages to the GitHub wiki of a repository by pushing to the
+// wiki's git repository.
+type Wiki struct {
+	RemoteURL   string // e.g. https://github.com/owner/repo.wiki.git
+	Token       string // Optional token for HTTPS remotes
+	AuthorName  string // Defaults to DefaultAuthorName
+	AuthorEmail string // Defaults to DefaultAuthorEmail
+}
+
+// WikiURL returns the URL of the git repository of the wiki of the
+// repository at repoURL, e.g. https://github.com/owner/repo.
+func WikiURL(repoURL string) string {
+	return strings.TrimSuffix(strings.TrimSuffix(repoURL, "/"), ".git") + ".wiki.git"
+}
+
+// PageFile returns the file name of the wiki page titled page. GitHub shows
+// hyphens in file names as spaces in page titles.
+func PageFile(page string) string {
+	return strings.ReplaceAll(strings.TrimSpace(page), " ", "-") + ".md"
+}
+
+// Publish writes markdown to the wiki page titled page and pushes it with a
+// commit with message, stopping git once ctx is done. It reports whether the
+// page changed.
+func (w *Wiki) Publish(ctx context.Context, page, markdown, message string) (bool, error) {
+	dir, err := os.MkdirTemp("", "gha-docs-wiki")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(dir)
+
+	var config []string
+	if w.Token != "" {
+		// Sent as a header so that the token is not stored in the remote URL
+		credentials := base64.StdEncoding.EncodeToString([]byte("x-access-token:" + w.Token))
+		config = append(config, "http.extraHeader=Authorization: Basic "+credentials)
+	}
+
+	err = git.Clone(ctx, w.RemoteURL, dir, config...)
+	if err != nil {
+		return false, fmt.Errorf("error cloning wiki (create its first page on GitHub if the wiki is empty): %v", err)
+	}
+
+	err = os.WriteFile(filepath.Join(dir, PageFile(page)), []byte(markdown), 0644)
+	if err != nil {
+		return false, fmt.Errorf("error writing wiki page: %v", err)
+	}
+
+	authorName, authorEmail := w.AuthorName, w.AuthorEmail
+	if authorName == "" {
+		authorName = DefaultAuthorName
+	}
+	if authorEmail == "" {
+		authorEmail = DefaultAuthorEmail
+	}
+	changed, err := git.CommitAll(ctx, dir, message, authorName, authorEmail)
+	if err != nil || !changed {
+		return false, err
+	}
+
+	err = git.Push(ctx, dir, config...)
+	if err != nil {
+		return false, fmt.Errorf("error pushing wiki: %v", err)
+	}
+	return true, nil
+}
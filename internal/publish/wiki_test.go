@@ -0,0 +1,87 @@
+package publish
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// runGit runs a git command in dir and fails the test on error
+func runGit(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=test", "GIT_AUTHOR_EMAIL=test@example.com",
+		"GIT_COMMITTER_NAME=test", "GIT_COMMITTER_EMAIL=test@example.com",
+	)
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("git %s failed: %v\n%s", strings.Join(args, " "), err, out)
+	}
+}
+
+// createWiki creates a bare repository with a home page to publish to
+func createWiki(t *testing.T) string {
+	t.Helper()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	work := t.TempDir()
+	runGit(t, work, "init", "-q")
+	if err := os.WriteFile(filepath.Join(work, "Home.md"), []byte("Welcome\n"), 0644); err != nil {
+		t.Fatalf("Failed to write home page: %v", err)
+	}
+	runGit(t, work, "add", "-A")
+	runGit(t, work, "commit", "-q", "-m", "Initial page")
+
+	remote := filepath.Join(t.TempDir(), "repo.wiki.git")
+	runGit(t, work, "clone", "-q", "--bare", work, remote)
+	return remote
+}
+
+// TestWikiPublish tests pushing a page to a wiki repository
+func TestWikiPublish(t *testing.T) {
+	remote := createWiki(t)
+	wiki := &Wiki{RemoteURL: remote}
+
+	changed, err := wiki.Publish(context.Background(), "GitHub Workflows", "# Workflows\n", "Update workflow documentation")
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if !changed {
+		t.Error("Expected the first publish to change the wiki")
+	}
+
+	check := filepath.Join(t.TempDir(), "check")
+	runGit(t, "", "clone", "-q", remote, check)
+	content, err := os.ReadFile(filepath.Join(check, "GitHub-Workflows.md"))
+	if err != nil || string(content) != "# Workflows\n" {
+		t.Errorf("Unexpected page content %q, %v", content, err)
+	}
+
+	changed, err = wiki.Publish(context.Background(), "GitHub Workflows", "# Workflows\n", "Update workflow documentation")
+	if err != nil {
+		t.Fatalf("Publish failed: %v", err)
+	}
+	if changed {
+		t.Error("Expected publishing the same page again to change nothing")
+	}
+}
+
+// TestWikiURL tests deriving the wiki repository of a repository
+func TestWikiURL(t *testing.T) {
+	for repoURL, expected := range map[string]string{
+		"https://github.com/owner/repo":     "https://github.com/owner/repo.wiki.git",
+		"https://github.com/owner/repo.git": "https://github.com/owner/repo.wiki.git",
+		"https://github.com/owner/repo/":    "https://github.com/owner/repo.wiki.git",
+		"git@github.com:owner/repo.git":     "git@github.com:owner/repo.wiki.git",
+	} {
+		if url := WikiURL(repoURL); url != expected {
+			t.Errorf("WikiURL(%q) = %q, expected %q", repoURL, url, expected)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+package remote
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"path"
+	"sync"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// DefaultWorkflowsDir is the directory GitHub reads workflows from.
+const DefaultWorkflowsDir = ".github/workflows"
+
+// Workflows parses the workflow files in dir of owner/repo at ref through
+// the GitHub API, as generate.ScanDir does for a local directory. An empty
+// ref selects the default branch. The files are parsed as configured by
+// scanOpts.Parse and run through scanOpts.Hooks. Files that fail to parse are reported and skipped unless
+// scanOpts.Strict is set, which fails the scan with generate.ParseErrors.
+func Workflows(ctx context.Context, client *github.Client, owner, repo, ref, dir string, scanOpts generate.ScanOptions) ([]generate.WorkflowInfo, error) {
+	workflows, parseErrors, err := WorkflowsWithErrors(ctx, client, owner, repo, ref, dir, scanOpts)
+	for _, parseError := range parseErrors {
+		slog.Warn("Error parsing workflow file", "file", parseError.Filename, "repo", owner+"/"+repo, "error", parseError.Err)
+	}
+	return workflows, err
+}
+
+// WorkflowsWithErrors is Workflows, returning the workflow files skipped
+// because they failed to parse rather than reporting them.
+func WorkflowsWithErrors(ctx context.Context, client *github.Client, owner, repo, ref, dir string, scanOpts generate.ScanOptions) ([]generate.WorkflowInfo, []generate.ParseError, error) {
+	entries, err := client.ListDirectory(ctx, owner, repo, dir, ref)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error listing workflows of %s/%s: %v", owner, repo, err)
+	}
+
+	var files []github.Content
+	for _, entry := range entries {
+		if entry.Type == "file" && (scanOpts.IsWorkflowFile(entry.Name) || scanOpts.IsDisabledFile(entry.Name)) {
+			files = append(files, entry)
+		}
+	}
+
+	// Fetch and parse up to scanOpts.NumWorkers() files at once
+	type fetched struct {
+		workflow generate.WorkflowInfo
+		err      error // Error parsing the file
+		fetchErr error // Error fetching the file
+	}
+	results := make([]fetched, len(files))
+	semaphore := make(chan struct{}, scanOpts.NumWorkers())
+
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func(i int, file github.Content) {
+			defer wg.Done()
+			select {
+			case semaphore <- struct{}{}:
+			case <-ctx.Done():
+				results[i] = fetched{fetchErr: ctx.Err()}
+				return
+			}
+			defer func() { <-semaphore }()
+
+			content, err := client.GetFile(ctx, owner, repo, path.Join(dir, file.Name), ref)
+			if err != nil {
+				results[i] = fetched{fetchErr: err}
+				return
+			}
+			workflow, err := scanOpts.ParseWorkflow(content)
+			results[i] = fetched{workflow: workflow, err: err}
+		}(i, file)
+	}
+	wg.Wait()
+
+	var workflows []generate.WorkflowInfo
+	var parseErrors []generate.ParseError
+	for i, file := range files {
+		workflow, err := results[i].workflow, results[i].err
+		if results[i].fetchErr != nil {
+			return nil, nil, fmt.Errorf("error reading workflow %s of %s/%s: %v", file.Name, owner, repo, results[i].fetchErr)
+		}
+		if err != nil {
+			parseErrors = append(parseErrors, generate.ParseError{Filename: file.Name, Err: err})
+			continue
+		}
+		workflow.Filename = file.Name
+		if scanOpts.IsDisabledFile(file.Name) {
+			workflow.Disabled = true
+		}
+		keep, err := scanOpts.ApplyHooks(ctx, &workflow)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s/%s: %v", owner, repo, err)
+		}
+		if keep {
+			workflows = append(workflows, workflow)
+		}
+	}
+
+	if scanOpts.Strict && len(parseErrors) > 0 {
+		return nil, nil, fmt.Errorf("%s/%s: %w", owner, repo, generate.ParseErrors(parseErrors))
+	}
+	return workflows, parseErrors, nil
+}
@@ -0,0 +1,81 @@
+package remote
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestWorkflows tests fetching and parsing the workflows of a repository
+func TestWorkflows(t *testing.T) {
+	files := map[string]string{
+		"ci.yml":     "## Runs CI.\non: [push, pull_request]\n",
+		"broken.yml": "## Broken.\non: [push\n",
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("ref") != "v1" {
+			t.Errorf("Expected ref=v1, got %q", r.URL.Query().Get("ref"))
+		}
+
+		if r.URL.Path == "/repos/owner/repo/contents/.github/workflows" {
+			w.Write([]byte(`[
+				{"name": "broken.yml", "type": "file"},
+				{"name": "ci.yml", "type": "file"},
+				{"name": "README.md", "type": "file"},
+				{"name": "nested.yml", "type": "dir"}
+			]`))
+			return
+		}
+
+		name := strings.TrimPrefix(r.URL.Path, "/repos/owner/repo/contents/.github/workflows/")
+		content, ok := files[name]
+		if !ok {
+			t.Errorf("Unexpected request for %s", r.URL.Path)
+			http.NotFound(w, r)
+			return
+		}
+		fmt.Fprintf(w, `{"encoding": "base64", "content": "%s"}`, base64.StdEncoding.EncodeToString([]byte(content)))
+	}))
+	defer server.Close()
+
+	workflows, err := Workflows(context.Background(), github.NewClient(server.URL, ""), "owner", "repo", "v1", DefaultWorkflowsDir, generate.ScanOptions{})
+	if err != nil {
+		t.Fatalf("Workflows failed: %v", err)
+	}
+
+	// The unparseable workflow is skipped
+	if len(workflows) != 1 {
+		t.Fatalf("Expected 1 workflow, got %d", len(workflows))
+	}
+	if workflows[0].Filename != "ci.yml" || workflows[0].Description != "Runs CI." {
+		t.Errorf("Unexpected workflow: %+v", workflows[0])
+	}
+
+	// In strict mode it fails the scan
+	_, err = Workflows(context.Background(), github.NewClient(server.URL, ""), "owner", "repo", "v1", DefaultWorkflowsDir, generate.ScanOptions{Strict: true})
+	if err == nil || !strings.Contains(err.Error(), "broken.yml") {
+		t.Errorf("Expected error for broken.yml in strict mode, got %v", err)
+	}
+}
+
+// TestWorkflowsError tests that API errors are reported
+func TestWorkflowsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"message": "Bad credentials"}`))
+	}))
+	defer server.Close()
+
+	_, err := Workflows(context.Background(), github.NewClient(server.URL, ""), "owner", "repo", "", DefaultWorkflowsDir, generate.ScanOptions{})
+	if err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("Expected API error, got %v", err)
+	}
+}
@@ -0,0 +1,289 @@
+package report
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// Supported report formats.
+const (
+	FormatMarkdown = "markdown"
+	FormatCSV      = "csv"
+	FormatJSON     = "json"
+)
+
+// Column is a column of a report table.
+type Column struct {
+	Key   string // Key of the column in JSON output
+	Title string // Heading of the column in markdown and CSV output
+}
+
+// Table is the tabular result of an analysis report. Cells are strings,
+// integers, or lists of strings.
+type Table struct {
+	Title   string
+	Columns []Column
+	Rows    [][]interface{}
+}
+
+// Builder builds a report from a set of workflows.
+type Builder func(workflows []generate.WorkflowInfo) Table
+
+// Reports maps the name of each available report to its builder.
+var Reports = map[string]Builder{
+	"actions":   Actions,
+	"runners":   Runners,
+	"schedules": Schedules,
+	"secrets":   Secrets,
+}
+
+// Names returns the sorted names of the available reports.
+func Names() []string {
+	var names []string
+	for name := range Reports {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Render renders the table in the given format.
+func (t Table) Render(format string) (string, error) {
+	switch format {
+	case "", FormatMarkdown:
+		return t.renderMarkdown(), nil
+	case FormatCSV:
+		return t.renderCSV()
+	case FormatJSON:
+		return t.renderJSON()
+	default:
+		return "", fmt.Errorf("unsupported report format %q", format)
+	}
+}
+
+// renderMarkdown renders the table as a markdown document.
+func (t Table) renderMarkdown() string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# %s\n\n", t.Title))
+
+	var titles, separators []string
+	for _, column := range t.Columns {
+		titles = append(titles, column.Title)
+		separators = append(separators, "---")
+	}
+	sb.WriteString("| " + strings.Join(titles, " | ") + " |\n")
+	sb.WriteString("| " + strings.Join(separators, " | ") + " |\n")
+
+	for _, row := range t.Rows {
+		var cells []string
+		for _, cell := range row {
+			cells = append(cells, formatCell(cell))
+		}
+		sb.WriteString("| " + strings.Join(cells, " | ") + " |\n")
+	}
+
+	return sb.String()
+}
+
+// renderCSV renders the table as CSV with a header row.
+func (t Table) renderCSV() (string, error) {
+	var sb strings.Builder
+	writer := csv.NewWriter(&sb)
+
+	var titles []string
+	for _, column := range t.Columns {
+		titles = append(titles, column.Title)
+	}
+	writer.Write(titles)
+
+	for _, row := range t.Rows {
+		var cells []string
+		for _, cell := range row {
+			cells = append(cells, formatCell(cell))
+		}
+		writer.Write(cells)
+	}
+
+	writer.Flush()
+	return sb.String(), writer.Error()
+}
+
+// renderJSON renders the table as a JSON array with an object per row.
+func (t Table) renderJSON() (string, error) {
+	objects := []map[string]interface{}{}
+	for _, row := range t.Rows {
+		object := make(map[string]interface{})
+		for i, column := range t.Columns {
+			object[column.Key] = row[i]
+		}
+		objects = append(objects, object)
+	}
+
+	content, err := json.MarshalIndent(objects, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(content) + "\n", nil
+}
+
+// formatCell formats a cell for markdown and CSV output.
+func formatCell(cell interface{}) string {
+	if list, ok := cell.([]string); ok {
+		return strings.Join(list, ", ")
+	}
+	return fmt.Sprint(cell)
+}
+
+// Actions inventories the actions and reusable workflows used by the
+// workflows, by reference.
+func Actions(workflows []generate.WorkflowInfo) Table {
+	type usage struct {
+		action, ref string
+		count       int
+		workflows   []string
+	}
+	usages := make(map[string]*usage)
+
+	record := func(uses, workflow string) {
+		if uses == "" {
+			return
+		}
+		action, ref := uses, ""
+		// Docker image references keep their tags as part of the action
+		if !strings.HasPrefix(uses, "docker://") {
+			if at := strings.LastIndex(uses, "@"); at != -1 {
+				action, ref = uses[:at], uses[at+1:]
+			}
+		}
+
+		key := action + "@" + ref
+		if usages[key] == nil {
+			usages[key] = &usage{action: action, ref: ref}
+		}
+		usages[key].count++
+		usages[key].workflows = appendUnique(usages[key].workflows, workflow)
+	}
+
+	for _, workflow := range workflows {
+		for _, job := range workflow.Jobs {
+			record(job.Uses, workflow.Filename)
+			for _, step := range job.Steps {
+				record(step.Uses, workflow.Filename)
+			}
+		}
+	}
+
+	var keys []string
+	for key := range usages {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	table := Table{
+		Title: "Actions Inventory",
+		Columns: []Column{
+			{Key: "action", Title: "Action"},
+			{Key: "ref", Title: "Ref"},
+			{Key: "uses", Title: "Uses"},
+			{Key: "workflows", Title: "Workflows"},
+		},
+	}
+	for _, key := range keys {
+		u := usages[key]
+		table.Rows = append(table.Rows, []interface{}{u.action, u.ref, u.count, u.workflows})
+	}
+	return table
+}
+
+// Secrets lists the secrets referenced by the workflows.
+func Secrets(workflows []generate.WorkflowInfo) Table {
+	users := make(map[string][]string)
+	for _, workflow := range workflows {
+		for _, secret := range workflow.Secrets {
+			users[secret] = appendUnique(users[secret], workflow.Filename)
+		}
+	}
+
+	table := Table{
+		Title: "Secrets Usage",
+		Columns: []Column{
+			{Key: "secret", Title: "Secret"},
+			{Key: "workflows", Title: "Workflows"},
+		},
+	}
+	for _, secret := range sortedKeys(users) {
+		table.Rows = append(table.Rows, []interface{}{secret, users[secret]})
+	}
+	return table
+}
+
+// Runners lists the runners the jobs of the workflows run on.
+func Runners(workflows []generate.WorkflowInfo) Table {
+	jobs := make(map[string]int)
+	users := make(map[string][]string)
+	for _, workflow := range workflows {
+		for _, job := range workflow.Jobs {
+			if len(job.RunsOn) == 0 {
+				continue
+			}
+			runner := strings.Join(job.RunsOn, ", ")
+			jobs[runner]++
+			users[runner] = appendUnique(users[runner], workflow.Filename)
+		}
+	}
+
+	table := Table{
+		Title: "Runner Usage",
+		Columns: []Column{
+			{Key: "runner", Title: "Runner"},
+			{Key: "jobs", Title: "Jobs"},
+			{Key: "workflows", Title: "Workflows"},
+		},
+	}
+	for _, runner := range sortedKeys(users) {
+		table.Rows = append(table.Rows, []interface{}{runner, jobs[runner], users[runner]})
+	}
+	return table
+}
+
+// Schedules lists the cron schedules of the workflows.
+func Schedules(workflows []generate.WorkflowInfo) Table {
+	table := Table{
+		Title: "Scheduled Workflows",
+		Columns: []Column{
+			{Key: "workflow", Title: "Workflow"},
+			{Key: "cron", Title: "Cron"},
+		},
+	}
+	for _, workflow := range workflows {
+		for _, cron := range workflow.Schedules {
+			table.Rows = append(table.Rows, []interface{}{workflow.Filename, cron})
+		}
+	}
+	return table
+}
+
+// appendUnique appends item to items unless it is already the last item.
+// Workflows are processed in order, so this keeps the lists unique.
+func appendUnique(items []string, item string) []string {
+	if len(items) > 0 && items[len(items)-1] == item {
+		return items
+	}
+	return append(items, item)
+}
+
+// sortedKeys returns the sorted keys of m.
+func sortedKeys(m map[string][]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,154 @@
+package report
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// testWorkflows returns workflows exercising every report.
+func testWorkflows() []generate.WorkflowInfo {
+	return []generate.WorkflowInfo{
+		{
+			Filename:  "ci.yml",
+			Secrets:   []string{"NPM_TOKEN"},
+			Schedules: []string{"0 2 * * *"},
+			Jobs: []generate.JobInfo{
+				{ID: "lint", RunsOn: []string{"ubuntu-latest"}, Steps: []generate.StepInfo{
+					{Uses: "actions/checkout@v4"},
+					{Uses: "actions/setup-go@v5"},
+				}},
+				{ID: "test", RunsOn: []string{"ubuntu-latest"}, Steps: []generate.StepInfo{
+					{Uses: "actions/checkout@v4"},
+					{Run: "go test ./..."},
+				}},
+			},
+		},
+		{
+			Filename: "release.yml",
+			Secrets:  []string{"GITHUB_TOKEN", "NPM_TOKEN"},
+			Jobs: []generate.JobInfo{
+				{ID: "build", RunsOn: []string{"self-hosted", "linux"}, Steps: []generate.StepInfo{
+					{Uses: "actions/checkout@v3"},
+					{Uses: "docker://alpine:3.20"},
+				}},
+				{ID: "publish", Uses: "octo-org/workflows/.github/workflows/publish.yml@main"},
+			},
+		},
+	}
+}
+
+// TestActions tests the actions inventory
+func TestActions(t *testing.T) {
+	expected := [][]interface{}{
+		{"actions/checkout", "v3", 1, []string{"release.yml"}},
+		{"actions/checkout", "v4", 2, []string{"ci.yml"}},
+		{"actions/setup-go", "v5", 1, []string{"ci.yml"}},
+		{"docker://alpine:3.20", "", 1, []string{"release.yml"}},
+		{"octo-org/workflows/.github/workflows/publish.yml", "main", 1, []string{"release.yml"}},
+	}
+
+	table := Actions(testWorkflows())
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("Expected rows %v, got %v", expected, table.Rows)
+	}
+}
+
+// TestSecrets tests the secrets usage report
+func TestSecrets(t *testing.T) {
+	expected := [][]interface{}{
+		{"GITHUB_TOKEN", []string{"release.yml"}},
+		{"NPM_TOKEN", []string{"ci.yml", "release.yml"}},
+	}
+
+	table := Secrets(testWorkflows())
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("Expected rows %v, got %v", expected, table.Rows)
+	}
+}
+
+// TestRunners tests the runner usage report
+func TestRunners(t *testing.T) {
+	expected := [][]interface{}{
+		{"self-hosted, linux", 1, []string{"release.yml"}},
+		{"ubuntu-latest", 2, []string{"ci.yml"}},
+	}
+
+	table := Runners(testWorkflows())
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("Expected rows %v, got %v", expected, table.Rows)
+	}
+}
+
+// TestSchedules tests the schedule list
+func TestSchedules(t *testing.T) {
+	expected := [][]interface{}{
+		{"ci.yml", "0 2 * * *"},
+	}
+
+	table := Schedules(testWorkflows())
+	if !reflect.DeepEqual(table.Rows, expected) {
+		t.Errorf("Expected rows %v, got %v", expected, table.Rows)
+	}
+}
+
+// TestRender tests rendering a report in every format
+func TestRender(t *testing.T) {
+	table := Secrets(testWorkflows())
+
+	markdown, err := table.Render(FormatMarkdown)
+	if err != nil {
+		t.Fatalf("Render markdown failed: %v", err)
+	}
+	expectedMarkdown := "# Secrets Usage\n\n" +
+		"| Secret | Workflows |\n" +
+		"| --- | --- |\n" +
+		"| GITHUB_TOKEN | release.yml |\n" +
+		"| NPM_TOKEN | ci.yml, release.yml |\n"
+	if markdown != expectedMarkdown {
+		t.Errorf("Expected markdown:\n%s\nGot:\n%s", expectedMarkdown, markdown)
+	}
+
+	csv, err := table.Render(FormatCSV)
+	if err != nil {
+		t.Fatalf("Render csv failed: %v", err)
+	}
+	expectedCSV := "Secret,Workflows\nGITHUB_TOKEN,release.yml\nNPM_TOKEN,\"ci.yml, release.yml\"\n"
+	if csv != expectedCSV {
+		t.Errorf("Expected CSV:\n%s\nGot:\n%s", expectedCSV, csv)
+	}
+
+	content, err := table.Render(FormatJSON)
+	if err != nil {
+		t.Fatalf("Render json failed: %v", err)
+	}
+	var rows []map[string]interface{}
+	if err := json.Unmarshal([]byte(content), &rows); err != nil {
+		t.Fatalf("Output is not valid JSON: %v", err)
+	}
+	expectedRows := []map[string]interface{}{
+		{"secret": "GITHUB_TOKEN", "workflows": []interface{}{"release.yml"}},
+		{"secret": "NPM_TOKEN", "workflows": []interface{}{"ci.yml", "release.yml"}},
+	}
+	if !reflect.DeepEqual(rows, expectedRows) {
+		t.Errorf("Expected JSON rows %v, got %v", expectedRows, rows)
+	}
+
+	if _, err := table.Render("xml"); err == nil || !strings.Contains(err.Error(), "unsupported") {
+		t.Errorf("Expected unsupported format error, got %v", err)
+	}
+}
+
+// TestRenderEmptyJSON tests that empty reports render as an empty JSON array
+func TestRenderEmptyJSON(t *testing.T) {
+	content, err := Schedules(nil).Render(FormatJSON)
+	if err != nil {
+		t.Fatalf("Render json failed: %v", err)
+	}
+	if content != "[]\n" {
+		t.Errorf("Expected empty JSON array, got %q", content)
+	}
+}
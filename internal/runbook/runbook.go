@@ -0,0 +1,185 @@
+package runbook
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// Generate writes an operational runbook for every workflow into outputDir,
+// one page per workflow, creating the directory if necessary.
+func Generate(workflows []generate.WorkflowInfo, workflowsDir, outputDir string) error {
+	err := os.MkdirAll(outputDir, 0755)
+	if err != nil {
+		return fmt.Errorf("error creating runbook directory: %v", err)
+	}
+
+	for _, workflow := range workflows {
+		pagePath := filepath.Join(outputDir, generate.PageName(workflow.Filename))
+
+		err = os.WriteFile(pagePath, []byte(Render(workflow, sourceLink(workflowsDir, workflow.Filename, pagePath))), 0644)
+		if err != nil {
+			return fmt.Errorf("error writing runbook for %s: %v", workflow.Filename, err)
+		}
+	}
+
+	return nil
+}
+
+// sourceLink returns the path of the workflow file relative to the page.
+func sourceLink(workflowsDir, filename, pagePath string) string {
+	relativePath, err := filepath.Rel(filepath.Dir(pagePath), filepath.Join(workflowsDir, filename))
+	if err != nil {
+		return filename
+	}
+	return filepath.ToSlash(relativePath)
+}
+
+// Render creates the runbook page of a single workflow. sourceLink is the
+// link to the workflow file.
+func Render(workflow generate.WorkflowInfo, sourceLink string) string {
+	var sb strings.Builder
+
+	title := workflow.Name
+	if title == "" {
+		title = workflow.Filename
+	}
+	sb.WriteString(fmt.Sprintf("# Runbook: %s\n\n", title))
+	sb.WriteString(fmt.Sprintf("Source: [%s](%s)\n\n", workflow.Filename, sourceLink))
+
+	sb.WriteString("## What it does\n\n")
+	if workflow.Description != "" {
+		sb.WriteString(strings.ReplaceAll(workflow.Description, "<br>", "\n") + "\n\n")
+	} else {
+		sb.WriteString("_No description provided. Add a `##` comment at the top of the workflow._\n\n")
+	}
+
+	sb.WriteString("## When it runs\n\n")
+	writeList(&sb, describeTriggers(workflow), "_No triggers._")
+
+	sb.WriteString("## Required secrets\n\n")
+	var secrets []string
+	for _, secret := range workflow.Secrets {
+		if secret == "GITHUB_TOKEN" {
+			secrets = append(secrets, "`GITHUB_TOKEN` (provided automatically)")
+		} else {
+			secrets = append(secrets, "`"+secret+"`")
+		}
+	}
+	writeList(&sb, secrets, "None")
+
+	sb.WriteString("## Environments\n\n")
+	writeList(&sb, codeItems(workflow.Environments), "None")
+
+	sb.WriteString("## Owners\n\n")
+	owners := metadataList(workflow.Metadata, "owner", "owners")
+	writeList(&sb, owners, "_No owners listed. Add `owner` to the workflow's metadata block._")
+
+	sb.WriteString("## Troubleshooting\n\n")
+	writeList(&sb, troubleshootingLinks(workflow.Metadata), "_No troubleshooting links. Add `troubleshooting` or `links` to the workflow's metadata block._")
+
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// describeTriggers describes each trigger together with its filters.
+func describeTriggers(workflow generate.WorkflowInfo) []string {
+	var descriptions []string
+	for _, trigger := range workflow.Triggers {
+		description := "`" + trigger + "`"
+
+		switch trigger {
+		case "schedule":
+			if len(workflow.Schedules) > 0 {
+				description += " at " + strings.Join(codeItems(workflow.Schedules), ", ")
+			}
+		case "workflow_dispatch":
+			description += " (manually)"
+		}
+
+		filter := workflow.Filters[trigger]
+		details := []struct {
+			label string
+			items []string
+		}{
+			{"types", filter.Types},
+			{"branches", filter.Branches},
+			{"ignoring branches", filter.BranchesIgnore},
+			{"tags", filter.Tags},
+			{"ignoring tags", filter.TagsIgnore},
+			{"paths", filter.Paths},
+			{"ignoring paths", filter.PathsIgnore},
+		}
+		for _, detail := range details {
+			if len(detail.items) > 0 {
+				description += fmt.Sprintf("; %s %s", detail.label, strings.Join(codeItems(detail.items), ", "))
+			}
+		}
+
+		descriptions = append(descriptions, description)
+	}
+	return descriptions
+}
+
+// metadataList returns the values of the first of keys present in metadata,
+// which may be a single value or a list.
+func metadataList(metadata map[string]interface{}, keys ...string) []string {
+	for _, key := range keys {
+		switch value := metadata[key].(type) {
+		case nil:
+			continue
+		case []interface{}:
+			var items []string
+			for _, item := range value {
+				items = append(items, fmt.Sprint(item))
+			}
+			return items
+		default:
+			return []string{fmt.Sprint(value)}
+		}
+	}
+	return nil
+}
+
+// troubleshootingLinks collects the `troubleshooting` entries and the named
+// `links` of the metadata.
+func troubleshootingLinks(metadata map[string]interface{}) []string {
+	links := metadataList(metadata, "troubleshooting")
+
+	if named, ok := metadata["links"].(map[string]interface{}); ok {
+		var names []string
+		for name := range named {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			links = append(links, fmt.Sprintf("[%s](%v)", name, named[name]))
+		}
+	}
+	return links
+}
+
+// codeItems formats each item as inline code.
+func codeItems(items []string) []string {
+	var formatted []string
+	for _, item := range items {
+		formatted = append(formatted, "`"+item+"`")
+	}
+	return formatted
+}
+
+// writeList writes items as a bulleted list, or empty if there are none.
+func writeList(sb *strings.Builder, items []string, empty string) {
+	if len(items) == 0 {
+		sb.WriteString(empty + "\n\n")
+		return
+	}
+	for _, item := range items {
+		sb.WriteString("- " + item + "\n")
+	}
+	sb.WriteString("\n")
+}
@@ -0,0 +1,103 @@
+package runbook
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// TestRender tests the runbook of a fully annotated workflow
+func TestRender(t *testing.T) {
+	workflow, err := generate.ParseWorkflow([]byte(`## Deploys the application.
+## --- metadata
+## owners: [platform-team, sre]
+## troubleshooting: https://example.com/wiki/deploy
+## links:
+##   dashboard: https://example.com/dashboard
+## ---
+name: Deploy
+on:
+  push:
+    branches: [main]
+    paths-ignore: ['**/*.md']
+  schedule:
+    - cron: '0 2 * * *'
+  workflow_dispatch:
+jobs:
+  deploy:
+    environment: production
+    runs-on: ubuntu-latest
+    steps:
+      - run: deploy
+        env:
+          TOKEN: ${{ secrets.DEPLOY_TOKEN }}
+          GH: ${{ secrets.GITHUB_TOKEN }}
+`))
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	workflow.Filename = "deploy.yml"
+
+	expected := "# Runbook: Deploy\n\n" +
+		"Source: [deploy.yml](../.github/workflows/deploy.yml)\n\n" +
+		"## What it does\n\nDeploys the application.\n\n" +
+		"## When it runs\n\n" +
+		"- `push`; branches `main`; ignoring paths `**/*.md`\n" +
+		"- `schedule` at `0 2 * * *`\n" +
+		"- `workflow_dispatch` (manually)\n\n" +
+		"## Required secrets\n\n" +
+		"- `DEPLOY_TOKEN`\n" +
+		"- `GITHUB_TOKEN` (provided automatically)\n\n" +
+		"## Environments\n\n- `production`\n\n" +
+		"## Owners\n\n- platform-team\n- sre\n\n" +
+		"## Troubleshooting\n\n" +
+		"- https://example.com/wiki/deploy\n" +
+		"- [dashboard](https://example.com/dashboard)\n"
+
+	actual := Render(workflow, "../.github/workflows/deploy.yml")
+	if actual != expected {
+		t.Errorf("Expected runbook:\n%s\ngot:\n%s", expected, actual)
+	}
+}
+
+// TestRenderMinimal tests the runbook of a workflow without annotations
+func TestRenderMinimal(t *testing.T) {
+	workflow := generate.WorkflowInfo{Filename: "ci.yml", Triggers: []string{"pull_request"}}
+
+	runbook := Render(workflow, "ci.yml")
+
+	expectedStrings := []string{
+		"# Runbook: ci.yml\n",
+		"_No description provided.",
+		"- `pull_request`\n",
+		"## Required secrets\n\nNone\n",
+		"## Environments\n\nNone\n",
+		"_No owners listed.",
+		"_No troubleshooting links.",
+	}
+	for _, str := range expectedStrings {
+		if !strings.Contains(runbook, str) {
+			t.Errorf("Expected runbook to contain %q, got:\n%s", str, runbook)
+		}
+	}
+}
+
+// TestGenerate tests writing runbooks to a directory
+func TestGenerate(t *testing.T) {
+	outputDir := filepath.Join(t.TempDir(), "runbooks")
+	workflows := []generate.WorkflowInfo{{Filename: "ci.yml"}, {Filename: "release.yaml"}}
+
+	err := Generate(workflows, ".github/workflows", outputDir)
+	if err != nil {
+		t.Fatalf("Generate failed: %v", err)
+	}
+
+	for _, page := range []string{"ci.md", "release.md"} {
+		if _, err := os.Stat(filepath.Join(outputDir, page)); err != nil {
+			t.Errorf("Expected runbook %s to exist: %v", page, err)
+		}
+	}
+}
@@ -0,0 +1,248 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// maxSlackRequestAge bounds the age of Slack requests to prevent replays.
+const maxSlackRequestAge = 5 * time.Minute
+
+// Timeouts of the HTTP server. Slack gives up on slash commands after 3
+// seconds, so requests taking much longer are not worth answering.
+const (
+	readHeaderTimeout = 5 * time.Second
+	readTimeout       = 10 * time.Second
+	writeTimeout      = 30 * time.Second
+	shutdownTimeout   = 10 * time.Second
+)
+
+// Server answers questions about the workflows in a directory over HTTP.
+type Server struct {
+	WorkflowsDir       string
+	SlackSigningSecret string          // Enables the Slack slash command endpoint when set
+	Cache              *generate.Cache // Optional cache of parsed workflows, which are re-read on every request
+
+	now func() time.Time
+}
+
+// New returns a server for the workflows in workflowsDir.
+func New(workflowsDir, slackSigningSecret string) *Server {
+	return &Server{
+		WorkflowsDir:       workflowsDir,
+		SlackSigningSecret: slackSigningSecret,
+		now:                time.Now,
+	}
+}
+
+// Handler returns the HTTP handler of the server.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "ok")
+	})
+	if s.SlackSigningSecret != "" {
+		mux.HandleFunc("/slack/command", s.handleSlackCommand)
+	}
+	return mux
+}
+
+// ListenAndServe serves the handler of the server on addr until ctx is done,
+// then shuts down, waiting for the requests in progress to be answered.
+func (s *Server) ListenAndServe(ctx context.Context, addr string) error {
+	httpServer := &http.Server{
+		Addr:              addr,
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: readHeaderTimeout,
+		ReadTimeout:       readTimeout,
+		WriteTimeout:      writeTimeout,
+	}
+
+	errs := make(chan error, 1)
+	go func() {
+		errs <- httpServer.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errs:
+		return err
+	case <-ctx.Done():
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := httpServer.Shutdown(shutdownCtx); err != nil {
+		return fmt.Errorf("error shutting down: %v", err)
+	}
+	if err := <-errs; err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// slackResponse is the JSON response to a Slack slash command.
+type slackResponse struct {
+	ResponseType string `json:"response_type"`
+	Text         string `json:"text"`
+}
+
+// handleSlackCommand verifies and answers a Slack slash command such as
+// `/ghadoc describe ci.yml`.
+func (s *Server) handleSlackCommand(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+	if err != nil {
+		http.Error(w, "error reading request", http.StatusBadRequest)
+		return
+	}
+
+	if err := s.verifySlackRequest(r.Header, body); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid form body", http.StatusBadRequest)
+		return
+	}
+
+	text, err := s.answer(r.Context(), form.Get("command"), form.Get("text"))
+	if err != nil {
+		text = fmt.Sprintf("Error reading workflows: %v", err)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(slackResponse{ResponseType: "ephemeral", Text: text})
+}
+
+// verifySlackRequest checks the Slack request signature and timestamp.
+func (s *Server) verifySlackRequest(header http.Header, body []byte) error {
+	timestamp := header.Get("X-Slack-Request-Timestamp")
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("missing or invalid request timestamp")
+	}
+
+	age := s.now().Sub(time.Unix(seconds, 0))
+	if age > maxSlackRequestAge || age < -maxSlackRequestAge {
+		return fmt.Errorf("request timestamp is too old")
+	}
+
+	mac := hmac.New(sha256.New, []byte(s.SlackSigningSecret))
+	mac.Write([]byte("v0:" + timestamp + ":"))
+	mac.Write(body)
+	expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
+
+	if !hmac.Equal([]byte(expected), []byte(header.Get("X-Slack-Signature"))) {
+		return fmt.Errorf("invalid request signature")
+	}
+	return nil
+}
+
+// answer returns the reply to a slash command with the given text, giving
+// up reading the workflows once ctx is done.
+func (s *Server) answer(ctx context.Context, command, text string) (string, error) {
+	if command == "" {
+		command = "/ghadoc"
+	}
+
+	subcommand, argument, _ := strings.Cut(strings.TrimSpace(text), " ")
+	argument = strings.TrimSpace(argument)
+
+	switch {
+	case subcommand == "describe" && argument != "":
+		return s.describe(ctx, argument)
+	case subcommand == "which-workflows" && argument != "":
+		return s.whichWorkflows(ctx, argument)
+	default:
+		return fmt.Sprintf("Usage:\n• `%s describe <workflow file>` describes a workflow\n"+
+			"• `%s which-workflows <path>` lists the workflows a change to path triggers", command, command), nil
+	}
+}
+
+// describe summarizes the workflow with the given filename.
+func (s *Server) describe(ctx context.Context, filename string) (string, error) {
+	workflows, err := s.scan(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	for _, workflow := range workflows {
+		if workflow.Filename != filename {
+			continue
+		}
+
+		var sb strings.Builder
+		sb.WriteString(fmt.Sprintf("*%s*\n", workflow.Filename))
+		if workflow.Description != "" {
+			sb.WriteString(strings.ReplaceAll(workflow.Description, "<br>", "\n") + "\n")
+		}
+		sb.WriteString("Triggers: " + codeList(workflow.Triggers))
+		return sb.String(), nil
+	}
+
+	return fmt.Sprintf("No workflow named `%s` was found.", filename), nil
+}
+
+// whichWorkflows lists the workflows triggered by a change to path.
+func (s *Server) whichWorkflows(ctx context.Context, path string) (string, error) {
+	workflows, err := s.scan(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	path = strings.TrimPrefix(path, "/")
+
+	var lines []string
+	for _, workflow := range workflows {
+		if triggers := workflow.TriggersForPath(path); len(triggers) > 0 {
+			lines = append(lines, fmt.Sprintf("• *%s* (%s)", workflow.Filename, codeList(triggers)))
+		}
+	}
+
+	if len(lines) == 0 {
+		return fmt.Sprintf("No workflows are triggered by changes to `%s`.", path), nil
+	}
+	return fmt.Sprintf("Changes to `%s` trigger:\n%s", path, strings.Join(lines, "\n")), nil
+}
+
+// scan reads the workflows of the server until ctx is done. Files that fail
+// to parse are logged and skipped.
+func (s *Server) scan(ctx context.Context) ([]generate.WorkflowInfo, error) {
+	workflows, parseErrors, err := generate.ScanDirContext(ctx, s.WorkflowsDir, generate.ScanOptions{Parse: generate.ParseOptions{Partial: true}, Cache: s.Cache})
+	for _, parseError := range parseErrors {
+		slog.Warn("Error parsing workflow file", "file", parseError.Filename, "error", parseError.Err)
+	}
+	return workflows, err
+}
+
+// codeList formats items as a comma-separated list of inline code.
+func codeList(items []string) string {
+	if len(items) == 0 {
+		return "none"
+	}
+
+	quoted := make([]string, len(items))
+	for i, item := range items {
+		quoted[i] = "`" + item + "`"
+	}
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "test-secret"
+
+// newTestServer creates a server for a directory with two workflows
+func newTestServer(t *testing.T) *Server {
+	t.Helper()
+	dir := t.TempDir()
+
+	workflows := map[string]string{
+		"ci.yml": `## Runs CI.
+on:
+  pull_request:
+    paths: ['backend/**']
+  push:
+    paths-ignore: ['**/*.md']
+`,
+		"nightly.yml": "## Nightly build.\non:\n  schedule:\n    - cron: '0 0 * * *'\n",
+	}
+	for name, content := range workflows {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write workflow: %v", err)
+		}
+	}
+
+	server := New(dir, testSecret)
+	server.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return server
+}
+
+// slackRequest builds a signed slash command request
+func slackRequest(text string, timestamp int64, secret string) *http.Request {
+	body := url.Values{"command": {"/ghadoc"}, "text": {text}}.Encode()
+	ts := strconv.FormatInt(timestamp, 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/slack/command", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+// TestSlackCommands tests answers to the supported slash commands
+func TestSlackCommands(t *testing.T) {
+	server := newTestServer(t)
+
+	testCases := []struct {
+		text     string
+		expected string
+	}{
+		{"describe ci.yml", "*ci.yml*\nRuns CI.\nTriggers: `pull_request`, `push`"},
+		{"describe missing.yml", "No workflow named `missing.yml` was found."},
+		{"which-workflows backend/main.go", "Changes to `backend/main.go` trigger:\n• *ci.yml* (`pull_request`, `push`)"},
+		{"which-workflows /docs/README.md", "No workflows are triggered by changes to `docs/README.md`."},
+		{"", "Usage:\n• `/ghadoc describe <workflow file>`"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.text, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, slackRequest(tc.text, 1700000000, testSecret))
+
+			if recorder.Code != http.StatusOK {
+				t.Fatalf("Expected status 200, got %d: %s", recorder.Code, recorder.Body)
+			}
+
+			var response slackResponse
+			if err := json.NewDecoder(recorder.Body).Decode(&response); err != nil {
+				t.Fatalf("Failed to decode response: %v", err)
+			}
+			if !strings.HasPrefix(response.Text, tc.expected) {
+				t.Errorf("Expected response starting with %q, got %q", tc.expected, response.Text)
+			}
+		})
+	}
+}
+
+// TestSlackVerification tests rejection of unsigned and stale requests
+func TestSlackVerification(t *testing.T) {
+	server := newTestServer(t)
+
+	testCases := map[string]*http.Request{
+		"Wrong secret":  slackRequest("describe ci.yml", 1700000000, "wrong"),
+		"Stale request": slackRequest("describe ci.yml", 1700000000-600, testSecret),
+	}
+
+	for name, req := range testCases {
+		t.Run(name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			server.Handler().ServeHTTP(recorder, req)
+			if recorder.Code != http.StatusUnauthorized {
+				t.Errorf("Expected status 401, got %d", recorder.Code)
+			}
+		})
+	}
+
+	// Without a signing secret the Slack endpoint is not served
+	recorder := httptest.NewRecorder()
+	New(server.WorkflowsDir, "").Handler().ServeHTTP(recorder, slackRequest("describe ci.yml", 1700000000, ""))
+	if recorder.Code != http.StatusNotFound {
+		t.Errorf("Expected status 404 without signing secret, got %d", recorder.Code)
+	}
+}
+
+// TestListenAndServe tests that the server shuts down once its context is
+// done, and that failing to listen is reported
+func TestListenAndServe(t *testing.T) {
+	server := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- server.ListenAndServe(ctx, "127.0.0.1:0")
+	}()
+	cancel()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("Expected a clean shutdown, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Server did not shut down")
+	}
+
+	if err := server.ListenAndServe(context.Background(), "invalid-address"); err == nil {
+		t.Error("Expected an error for an invalid address")
+	}
+}
+
+// TestAnswerCanceled tests that scans of slash commands stop with the
+// request
+func TestAnswerCanceled(t *testing.T) {
+	server := newTestServer(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := server.answer(ctx, "/ghadoc", "describe ci.yml"); err == nil {
+		t.Error("Expected an error for a canceled request")
+	}
+}
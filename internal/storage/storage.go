@@ -0,0 +1,230 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// Unattributed is the workflow of storage that cannot be attributed to any
+// of the documented workflows.
+const Unattributed = "unattributed"
+
+// Usage is the storage used by the artifacts or caches of a workflow.
+type Usage struct {
+	Workflow string // Workflow filename, several comma-separated filenames, or Unattributed
+	Count    int
+	Bytes    int64
+}
+
+// Report is the Actions storage of a repository, attributed to workflows.
+type Report struct {
+	Artifacts []Usage // Unexpired artifacts, by the workflow of the run that uploaded them
+	Caches    []Usage // Cache entries, by the workflows whose cache keys match them
+}
+
+// setupCachePrefixes maps setup actions that cache dependencies to the
+// prefix of their cache keys.
+var setupCachePrefixes = map[string]string{
+	"actions/setup-go":     "setup-go-",
+	"actions/setup-java":   "setup-java-",
+	"actions/setup-node":   "node-cache-",
+	"actions/setup-python": "setup-python-",
+}
+
+// expressionPattern matches the expressions of a cache key template.
+var expressionPattern = regexp.MustCompile(`\$\{\{.*?\}\}`)
+
+// Collect fetches the artifacts and caches of owner/repo and attributes
+// their storage to workflows. Artifacts are attributed to the workflow of
+// the run that uploaded them. Caches are not linked to runs, so they are
+// attributed to the workflows whose actions/cache keys or setup actions
+// produce keys like theirs.
+func Collect(ctx context.Context, client *github.Client, owner, repo string, workflows []generate.WorkflowInfo) (Report, error) {
+	var report Report
+
+	artifacts, err := client.ListArtifacts(ctx, owner, repo)
+	if err != nil {
+		return report, fmt.Errorf("error listing artifacts: %v", err)
+	}
+
+	known := make(map[string]bool)
+	for _, workflow := range workflows {
+		known[workflow.Filename] = true
+	}
+
+	runWorkflows := make(map[int64]string)
+	artifactUsage := make(map[string]*Usage)
+	for _, artifact := range artifacts {
+		if artifact.Expired {
+			continue
+		}
+
+		runID := artifact.WorkflowRun.ID
+		workflow, ok := runWorkflows[runID]
+		if !ok {
+			workflow = Unattributed
+			if runID != 0 {
+				run, err := client.GetRun(ctx, owner, repo, runID)
+				if err != nil && !github.IsNotFound(err) {
+					return report, fmt.Errorf("error fetching run %d: %v", runID, err)
+				}
+				if known[path.Base(run.Path)] {
+					workflow = path.Base(run.Path)
+				}
+			}
+			runWorkflows[runID] = workflow
+		}
+
+		add(artifactUsage, workflow, artifact.SizeInBytes)
+	}
+	report.Artifacts = sortUsages(artifactUsage)
+
+	caches, err := client.ListCaches(ctx, owner, repo)
+	if err != nil {
+		return report, fmt.Errorf("error listing caches: %v", err)
+	}
+
+	matchers := cacheMatchers(workflows)
+	cacheUsage := make(map[string]*Usage)
+	for _, cache := range caches {
+		var producers []string
+		for _, workflow := range workflows {
+			for _, matcher := range matchers[workflow.Filename] {
+				if matcher.MatchString(cache.Key) {
+					producers = append(producers, workflow.Filename)
+					break
+				}
+			}
+		}
+
+		workflow := Unattributed
+		if len(producers) > 0 {
+			workflow = strings.Join(producers, ", ")
+		}
+		add(cacheUsage, workflow, cache.SizeInBytes)
+	}
+	report.Caches = sortUsages(cacheUsage)
+
+	return report, nil
+}
+
+// cacheMatchers returns, for each workflow, patterns matching the keys of the
+// caches its steps save.
+func cacheMatchers(workflows []generate.WorkflowInfo) map[string][]*regexp.Regexp {
+	matchers := make(map[string][]*regexp.Regexp)
+	for _, workflow := range workflows {
+		for _, job := range workflow.Jobs {
+			for _, step := range job.Steps {
+				action, _, _ := strings.Cut(step.Uses, "@")
+
+				if prefix, ok := setupCachePrefixes[action]; ok {
+					matchers[workflow.Filename] = append(matchers[workflow.Filename], regexp.MustCompile("^"+regexp.QuoteMeta(prefix)))
+					continue
+				}
+
+				if action != "actions/cache" && action != "actions/cache/save" {
+					continue
+				}
+				if matcher := keyMatcher(step.With["key"]); matcher != nil {
+					matchers[workflow.Filename] = append(matchers[workflow.Filename], matcher)
+				}
+			}
+		}
+	}
+	return matchers
+}
+
+// keyMatcher converts a cache key template into a pattern matching the keys
+// it produces, with every expression matching anything. Templates without
+// literal text would match every key, so they yield nil.
+func keyMatcher(key string) *regexp.Regexp {
+	key = strings.TrimSpace(key)
+	literals := expressionPattern.Split(key, -1)
+	if strings.TrimSpace(strings.Join(literals, "")) == "" {
+		return nil
+	}
+
+	for i, literal := range literals {
+		literals[i] = regexp.QuoteMeta(literal)
+	}
+	return regexp.MustCompile("^" + strings.Join(literals, ".*") + "$")
+}
+
+// add adds an item of size bytes to the usage of workflow.
+func add(usages map[string]*Usage, workflow string, bytes int64) {
+	if usages[workflow] == nil {
+		usages[workflow] = &Usage{Workflow: workflow}
+	}
+	usages[workflow].Count++
+	usages[workflow].Bytes += bytes
+}
+
+// sortUsages returns the usages by decreasing size.
+func sortUsages(usages map[string]*Usage) []Usage {
+	var sorted []Usage
+	for _, usage := range usages {
+		sorted = append(sorted, *usage)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Bytes != sorted[j].Bytes {
+			return sorted[i].Bytes > sorted[j].Bytes
+		}
+		return sorted[i].Workflow < sorted[j].Workflow
+	})
+	return sorted
+}
+
+// Render renders the storage report of repo as a markdown document.
+func Render(repo string, report Report) string {
+	var sb strings.Builder
+
+	sb.WriteString(fmt.Sprintf("# Actions Storage of %s\n", repo))
+	writeUsages(&sb, "Artifacts", report.Artifacts)
+	writeUsages(&sb, "Caches", report.Caches)
+
+	return sb.String()
+}
+
+// writeUsages writes a section with a table of usages and their total.
+func writeUsages(sb *strings.Builder, title string, usages []Usage) {
+	sb.WriteString(fmt.Sprintf("\n## %s\n\n", title))
+	if len(usages) == 0 {
+		sb.WriteString("None.\n")
+		return
+	}
+
+	sb.WriteString(fmt.Sprintf("| Workflow | %s | Size |\n", title))
+	sb.WriteString("| --- | --- | --- |\n")
+	var count int
+	var bytes int64
+	for _, usage := range usages {
+		count += usage.Count
+		bytes += usage.Bytes
+		sb.WriteString(fmt.Sprintf("| %s | %d | %s |\n", usage.Workflow, usage.Count, formatBytes(usage.Bytes)))
+	}
+	sb.WriteString(fmt.Sprintf("| **Total** | %d | %s |\n", count, formatBytes(bytes)))
+}
+
+// formatBytes formats a size in bytes with a binary unit.
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	size := float64(bytes) / unit
+	for _, suffix := range []string{"KiB", "MiB", "GiB"} {
+		if size < unit {
+			return fmt.Sprintf("%.1f %s", size, suffix)
+		}
+		size /= unit
+	}
+	return fmt.Sprintf("%.1f TiB", size)
+}
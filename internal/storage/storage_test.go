@@ -0,0 +1,110 @@
+package storage
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+	"github.com/droctothorpe/gha-docs/internal/github"
+)
+
+// TestKeyMatcher tests matching cache keys against key templates
+func TestKeyMatcher(t *testing.T) {
+	matcher := keyMatcher("${{ runner.os }}-cargo-${{ hashFiles('**/Cargo.lock') }}")
+	for key, expected := range map[string]bool{
+		"Linux-cargo-0a1b2c":  true,
+		"Windows-cargo-":      true,
+		"Linux-gradle-0a1b2c": false,
+	} {
+		if matched := matcher.MatchString(key); matched != expected {
+			t.Errorf("Expected match of %q to be %v, got %v", key, expected, matched)
+		}
+	}
+
+	if matcher := keyMatcher("${{ github.sha }}"); matcher != nil {
+		t.Errorf("Expected no matcher for a key without literal text, got %v", matcher)
+	}
+}
+
+// TestCollect tests attributing artifacts and caches to workflows
+func TestCollect(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/owner/repo/actions/artifacts":
+			w.Write([]byte(`{"artifacts": [
+				{"id": 1, "size_in_bytes": 1000, "workflow_run": {"id": 10}},
+				{"id": 2, "size_in_bytes": 3000, "workflow_run": {"id": 10}},
+				{"id": 3, "size_in_bytes": 500, "workflow_run": {"id": 11}},
+				{"id": 4, "size_in_bytes": 9000, "expired": true, "workflow_run": {"id": 10}},
+				{"id": 5, "size_in_bytes": 200, "workflow_run": {"id": 12}}
+			]}`))
+		case "/repos/owner/repo/actions/runs/10":
+			w.Write([]byte(`{"id": 10, "path": ".github/workflows/build.yml"}`))
+		case "/repos/owner/repo/actions/runs/11":
+			w.Write([]byte(`{"id": 11, "path": ".github/workflows/removed.yml"}`))
+		case "/repos/owner/repo/actions/caches":
+			w.Write([]byte(`{"actions_caches": [
+				{"id": 1, "key": "Linux-cargo-abc", "size_in_bytes": 4000},
+				{"id": 2, "key": "setup-go-Linux-go-1.23-def", "size_in_bytes": 6000},
+				{"id": 3, "key": "something-else", "size_in_bytes": 100}
+			]}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	workflows := []generate.WorkflowInfo{
+		{Filename: "build.yml", Jobs: []generate.JobInfo{{ID: "build", Steps: []generate.StepInfo{
+			{Uses: "actions/setup-go@v5"},
+			{Uses: "actions/cache@v4", With: map[string]string{"key": "${{ runner.os }}-cargo-${{ hashFiles('Cargo.lock') }}"}},
+		}}}},
+		{Filename: "lint.yml", Jobs: []generate.JobInfo{{ID: "lint", Steps: []generate.StepInfo{
+			{Uses: "actions/setup-go@v5"},
+		}}}},
+	}
+
+	report, err := Collect(context.Background(), github.NewClient(server.URL, ""), "owner", "repo", workflows)
+	if err != nil {
+		t.Fatalf("Collect failed: %v", err)
+	}
+
+	expected := Report{
+		Artifacts: []Usage{
+			{Workflow: "build.yml", Count: 2, Bytes: 4000},
+			{Workflow: Unattributed, Count: 2, Bytes: 700},
+		},
+		Caches: []Usage{
+			{Workflow: "build.yml, lint.yml", Count: 1, Bytes: 6000},
+			{Workflow: "build.yml", Count: 1, Bytes: 4000},
+			{Workflow: Unattributed, Count: 1, Bytes: 100},
+		},
+	}
+	if !reflect.DeepEqual(report, expected) {
+		t.Errorf("Expected report %+v, got %+v", expected, report)
+	}
+}
+
+// TestRender tests rendering the storage report
+func TestRender(t *testing.T) {
+	content := Render("owner/repo", Report{
+		Artifacts: []Usage{
+			{Workflow: "build.yml", Count: 2, Bytes: 3 * 1024 * 1024},
+			{Workflow: Unattributed, Count: 1, Bytes: 512},
+		},
+	})
+
+	for _, expected := range []string{
+		"# Actions Storage of owner/repo\n",
+		"## Artifacts\n\n| Workflow | Artifacts | Size |\n| --- | --- | --- |\n| build.yml | 2 | 3.0 MiB |\n| unattributed | 1 | 512 B |\n| **Total** | 3 | 3.0 MiB |\n",
+		"## Caches\n\nNone.\n",
+	} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+		}
+	}
+}
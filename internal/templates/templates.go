@@ -0,0 +1,204 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Kinds of templates.
+const (
+	KindIssue       = "Issue"
+	KindIssueForm   = "Issue form"
+	KindPullRequest = "Pull request"
+)
+
+// pullRequestLocations are the paths, relative to the repository root, of
+// single pull request templates.
+var pullRequestLocations = []string{
+	".github/PULL_REQUEST_TEMPLATE.md",
+	"PULL_REQUEST_TEMPLATE.md",
+	"docs/PULL_REQUEST_TEMPLATE.md",
+}
+
+// Template stores information about an issue or pull request template.
+type Template struct {
+	Path        string // Path relative to the repository root, with forward slashes
+	Kind        string
+	Name        string
+	Description string
+	Labels      []string
+	Assignees   []string
+}
+
+// header holds the keys shared by issue template front matter and issue
+// forms.
+type header struct {
+	Name        string      `yaml:"name"`
+	About       string      `yaml:"about"`
+	Description string      `yaml:"description"`
+	Labels      interface{} `yaml:"labels"`
+	Assignees   interface{} `yaml:"assignees"`
+}
+
+// Scan finds the issue and pull request templates of the repository at
+// repoDir.
+func Scan(repoDir string) ([]Template, error) {
+	var templates []Template
+
+	issueDir := filepath.Join(repoDir, ".github", "ISSUE_TEMPLATE")
+	entries, err := os.ReadDir(issueDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading issue templates: %v", err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		ext := strings.ToLower(filepath.Ext(name))
+		// config.yml configures the template chooser and is not a template
+		if entry.IsDir() || strings.TrimSuffix(name, filepath.Ext(name)) == "config" {
+			continue
+		}
+		if ext != ".md" && ext != ".yml" && ext != ".yaml" {
+			continue
+		}
+
+		template, err := parseFile(repoDir, ".github/ISSUE_TEMPLATE/"+name)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+
+	pullRequestFiles := pullRequestLocations
+	multipleDir := filepath.Join(repoDir, ".github", "PULL_REQUEST_TEMPLATE")
+	entries, err = os.ReadDir(multipleDir)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("error reading pull request templates: %v", err)
+	}
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(entry.Name()), ".md") {
+			pullRequestFiles = append(pullRequestFiles, ".github/PULL_REQUEST_TEMPLATE/"+entry.Name())
+		}
+	}
+
+	for _, file := range pullRequestFiles {
+		if _, err := os.Stat(filepath.Join(repoDir, file)); err != nil {
+			continue
+		}
+		templates = append(templates, Template{
+			Path: file,
+			Kind: KindPullRequest,
+			Name: strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)),
+		})
+	}
+
+	return templates, nil
+}
+
+// parseFile parses the issue template at file, relative to repoDir.
+func parseFile(repoDir, file string) (Template, error) {
+	content, err := os.ReadFile(filepath.Join(repoDir, file))
+	if err != nil {
+		return Template{}, fmt.Errorf("error reading issue template %s: %v", file, err)
+	}
+
+	template := Template{Path: file, Kind: KindIssueForm}
+	metadata := content
+	if strings.EqualFold(filepath.Ext(file), ".md") {
+		template.Kind = KindIssue
+		metadata = frontMatter(content)
+	}
+
+	var h header
+	if err := yaml.Unmarshal(metadata, &h); err != nil {
+		return Template{}, fmt.Errorf("error parsing issue template %s: %v", file, err)
+	}
+
+	template.Name = h.Name
+	if template.Name == "" {
+		template.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+	template.Description = h.Description
+	if template.Description == "" {
+		template.Description = h.About
+	}
+	template.Labels = list(h.Labels)
+	template.Assignees = list(h.Assignees)
+
+	return template, nil
+}
+
+// frontMatter returns the YAML front matter between the leading `---` lines
+// of a markdown file, or nil if there is none.
+func frontMatter(content []byte) []byte {
+	content = bytes.ReplaceAll(content, []byte("\r\n"), []byte("\n"))
+	if !bytes.HasPrefix(content, []byte("---\n")) {
+		return nil
+	}
+	rest := content[len("---\n"):]
+	end := bytes.Index(rest, []byte("\n---"))
+	if end == -1 {
+		return nil
+	}
+	return rest[:end]
+}
+
+// list converts a YAML list or comma-separated string into a list.
+func list(value interface{}) []string {
+	var items []string
+	switch v := value.(type) {
+	case string:
+		items = strings.Split(v, ",")
+	case []interface{}:
+		for _, item := range v {
+			items = append(items, fmt.Sprint(item))
+		}
+	}
+
+	var cleaned []string
+	for _, item := range items {
+		if item = strings.TrimSpace(item); item != "" {
+			cleaned = append(cleaned, item)
+		}
+	}
+	return cleaned
+}
+
+// Render renders the templates as a markdown table. Links to the template
+// files are relative to the output file.
+func Render(templates []Template, repoDir, output string) string {
+	var sb strings.Builder
+
+	sb.WriteString("# Issue and Pull Request Templates\n\n")
+	if len(templates) == 0 {
+		sb.WriteString("No templates found.\n")
+		return sb.String()
+	}
+
+	sb.WriteString("| Template | Type | Description | Labels | Assignees |\n")
+	sb.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, template := range templates {
+		sb.WriteString(fmt.Sprintf("| [%s](%s) | %s | %s | %s | %s |\n",
+			template.Name,
+			link(repoDir, template.Path, output),
+			template.Kind,
+			template.Description,
+			strings.Join(template.Labels, ", "),
+			strings.Join(template.Assignees, ", ")))
+	}
+
+	return sb.String()
+}
+
+// link returns the path of file relative to the directory of output.
+func link(repoDir, file, output string) string {
+	relativePath, err := filepath.Rel(filepath.Dir(output), filepath.Join(repoDir, filepath.FromSlash(file)))
+	if err != nil {
+		return file
+	}
+	return filepath.ToSlash(relativePath)
+}
@@ -0,0 +1,111 @@
+package templates
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// writeFiles writes files relative to dir, creating directories as needed
+func writeFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("Failed to create directory for %s: %v", name, err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+}
+
+// TestScan tests discovery and parsing of templates
+func TestScan(t *testing.T) {
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{
+		".github/ISSUE_TEMPLATE/bug_report.md": `---
+name: Bug report
+about: Report a problem
+labels: bug, triage
+assignees: ''
+---
+
+**Describe the bug**
+`,
+		".github/ISSUE_TEMPLATE/feature.yml": `name: Feature request
+description: Suggest an idea
+labels: [enhancement]
+assignees:
+  - octocat
+body:
+  - type: textarea
+    attributes:
+      label: Idea
+`,
+		".github/ISSUE_TEMPLATE/config.yml":              "blank_issues_enabled: false\n",
+		".github/ISSUE_TEMPLATE/notes.txt":               "not a template\n",
+		".github/PULL_REQUEST_TEMPLATE.md":               "## Summary\n",
+		".github/PULL_REQUEST_TEMPLATE/release.md":       "## Release checklist\n",
+		".github/PULL_REQUEST_TEMPLATE/ignored-dir/a.md": "## Nested\n",
+	})
+
+	found, err := Scan(repo)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+
+	expected := []Template{
+		{Path: ".github/ISSUE_TEMPLATE/bug_report.md", Kind: KindIssue, Name: "Bug report", Description: "Report a problem", Labels: []string{"bug", "triage"}},
+		{Path: ".github/ISSUE_TEMPLATE/feature.yml", Kind: KindIssueForm, Name: "Feature request", Description: "Suggest an idea", Labels: []string{"enhancement"}, Assignees: []string{"octocat"}},
+		{Path: ".github/PULL_REQUEST_TEMPLATE.md", Kind: KindPullRequest, Name: "PULL_REQUEST_TEMPLATE"},
+		{Path: ".github/PULL_REQUEST_TEMPLATE/release.md", Kind: KindPullRequest, Name: "release"},
+	}
+	if !reflect.DeepEqual(found, expected) {
+		t.Errorf("Expected templates:\n%+v\nGot:\n%+v", expected, found)
+	}
+}
+
+// TestScanNoTemplates tests a repository without templates
+func TestScanNoTemplates(t *testing.T) {
+	repo := t.TempDir()
+
+	found, err := Scan(repo)
+	if err != nil {
+		t.Fatalf("Scan failed: %v", err)
+	}
+	if len(found) != 0 {
+		t.Errorf("Expected no templates, got %+v", found)
+	}
+
+	if content := Render(found, repo, ""); !strings.Contains(content, "No templates found.") {
+		t.Errorf("Expected no templates message, got:\n%s", content)
+	}
+}
+
+// TestScanInvalidTemplate tests the error for malformed issue forms
+func TestScanInvalidTemplate(t *testing.T) {
+	repo := t.TempDir()
+	writeFiles(t, repo, map[string]string{
+		".github/ISSUE_TEMPLATE/broken.yml": "name: [unterminated\n",
+	})
+
+	_, err := Scan(repo)
+	if err == nil || !strings.Contains(err.Error(), "broken.yml") {
+		t.Errorf("Expected parse error naming the template, got %v", err)
+	}
+}
+
+// TestRender tests rendering the templates table
+func TestRender(t *testing.T) {
+	content := Render([]Template{
+		{Path: ".github/ISSUE_TEMPLATE/bug.md", Kind: KindIssue, Name: "Bug", Description: "Report a bug", Labels: []string{"bug", "triage"}, Assignees: []string{"octocat"}},
+	}, "repo", "repo/docs/templates.md")
+
+	expected := "| [Bug](../.github/ISSUE_TEMPLATE/bug.md) | Issue | Report a bug | bug, triage | octocat |\n"
+	if !strings.Contains(content, expected) {
+		t.Errorf("Expected output to contain %q, got:\n%s", expected, content)
+	}
+}
@@ -0,0 +1,147 @@
+// Package ghadoc parses GitHub Actions workflow files and renders
+// documentation for them, for Go tools such as platform portals and bots
+// that embed gha-docs rather than running the CLI.
+//
+// Scan a directory, or parse workflows fetched from elsewhere, then render
+// them:
+//
+//	workflows, parseErrors, err := ghadoc.ScanDir(".github/workflows", ghadoc.ScanOptions{})
+//	if err != nil {
+//		return err
+//	}
+//	markdown, err := ghadoc.Render(workflows, ghadoc.Options{
+//		WorkflowsDir: ".github/workflows",
+//		ParseErrors:  parseErrors,
+//	})
+package ghadoc
+
+import (
+	"context"
+	"io"
+
+	"github.com/droctothorpe/gha-docs/internal/generate"
+)
+
+// WorkflowInfo is everything parsed from a workflow file.
+type WorkflowInfo = generate.WorkflowInfo
+
+// JobInfo and StepInfo describe the jobs of a workflow and their steps.
+type (
+	JobInfo  = generate.JobInfo
+	StepInfo = generate.StepInfo
+)
+
+// TriggerFilter holds the filters configured for a trigger of a workflow.
+type TriggerFilter = generate.TriggerFilter
+
+// ParseOptions configures how a workflow file is parsed.
+type ParseOptions = generate.ParseOptions
+
+// ScanOptions configures how a directory is scanned for workflow files.
+type ScanOptions = generate.ScanOptions
+
+// Hook runs on every workflow of a scan once it is parsed, to enrich,
+// annotate, or leave it out; set it in ScanOptions.Hooks.
+type Hook = generate.Hook
+
+// Cache keeps parsed workflows on disk by the hash of their content; set it
+// in ScanOptions.Cache so that unchanged workflow files are not parsed again.
+type Cache = generate.Cache
+
+// DefaultCacheDir returns the directory the gha-docs command caches parsed
+// workflows in, within the user's cache directory.
+func DefaultCacheDir() (string, error) {
+	return generate.DefaultCacheDir()
+}
+
+// DefaultMaxFileSize is the size in bytes above which workflow files are
+// skipped unless ScanOptions.MaxFileSize is set.
+const DefaultMaxFileSize = generate.DefaultMaxFileSize
+
+// ParseError records a workflow file that failed to parse.
+type ParseError = generate.ParseError
+
+// ParseErrors aggregates the workflow files that failed to parse in a scan
+// with ScanOptions.Strict set.
+type ParseErrors = generate.ParseErrors
+
+// Classes of errors, to tell them apart with errors.Is.
+var (
+	ErrNotAWorkflow = generate.ErrNotAWorkflow // YAML files that are not workflows
+	ErrParse        = generate.ErrParse        // Workflow files that fail to parse
+	ErrOutputWrite  = generate.ErrOutputWrite  // Failures writing the documentation
+	ErrLimit        = generate.ErrLimit        // Files and directories skipped by ScanOptions limits
+)
+
+// Options configures how workflows are rendered.
+type Options = generate.Options
+
+// Column is a custom column of the summary table.
+type Column = generate.Column
+
+// ReadsDocument reports whether any of columns reads the workflow document,
+// which must then be parsed without ParseOptions.Partial.
+func ReadsDocument(columns []Column) bool {
+	return generate.ReadsDocument(columns)
+}
+
+// Output formats of Options.Format.
+const (
+	FormatMarkdown = generate.FormatMarkdown
+	FormatHTML     = generate.FormatHTML
+	FormatCSV      = generate.FormatCSV
+	FormatJSON     = generate.FormatJSON
+)
+
+// Renderer renders workflows in an output format.
+type Renderer = generate.Renderer
+
+// RegisterRenderer makes renderer available as the output format of its
+// name, selected by Options.Format, replacing any renderer of the same name.
+func RegisterRenderer(renderer Renderer) {
+	generate.RegisterRenderer(renderer)
+}
+
+// Formats returns the registered output formats, sorted.
+func Formats() []string {
+	return generate.Formats()
+}
+
+// ParseWorkflow extracts information from the content of a workflow file as
+// configured by opts. The Filename field is left for the caller to populate.
+func ParseWorkflow(content []byte, opts ParseOptions) (WorkflowInfo, error) {
+	return generate.ParseWorkflowWithOptions(content, opts)
+}
+
+// ScanDir parses the workflow files in dir as configured by opts. Files that
+// fail to parse are skipped and returned, unless opts.Strict is set, which
+// fails the scan with ParseErrors.
+func ScanDir(dir string, opts ScanOptions) ([]WorkflowInfo, []ParseError, error) {
+	return generate.ScanDirWithErrors(dir, opts)
+}
+
+// ScanDirContext is ScanDir, returning the error of ctx if it is done before
+// every file is parsed.
+func ScanDirContext(ctx context.Context, dir string, opts ScanOptions) ([]WorkflowInfo, []ParseError, error) {
+	return generate.ScanDirContext(ctx, dir, opts)
+}
+
+// Render renders workflows in the format of opts, the markdown summary table
+// by default, without writing any files. Links to the workflow files are
+// relative to opts.Output, from opts.WorkflowsDir, unless opts.RepoURL and
+// opts.Ref are set.
+func Render(workflows []WorkflowInfo, opts Options) (string, error) {
+	return generate.RenderWorkflows(workflows, opts)
+}
+
+// RenderTo renders workflows like Render, writing to w, e.g. an HTTP
+// response, as the output is rendered.
+func RenderTo(w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	return generate.RenderTo(w, workflows, opts)
+}
+
+// RenderToContext is RenderTo, killing the commands of exec columns once ctx
+// is done, e.g. when the request of an HTTP response is canceled.
+func RenderToContext(ctx context.Context, w io.Writer, workflows []WorkflowInfo, opts Options) error {
+	return generate.RenderToContext(ctx, w, workflows, opts)
+}
@@ -0,0 +1,52 @@
+package ghadoc
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestScanAndRender tests scanning a directory and rendering its workflows
+// through the public API
+func TestScanAndRender(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"ci.yml":     "## Runs CI.\non: [push, pull_request]\n",
+		"broken.yml": "on: [push\n",
+	} {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	workflows, parseErrors, err := ScanDir(dir, ScanOptions{})
+	if err != nil {
+		t.Fatalf("ScanDir failed: %v", err)
+	}
+	if len(workflows) != 1 || workflows[0].Filename != "ci.yml" {
+		t.Fatalf("Expected ci.yml, got %+v", workflows)
+	}
+	if len(parseErrors) != 1 || parseErrors[0].Filename != "broken.yml" {
+		t.Errorf("Expected a parse error for broken.yml, got %v", parseErrors)
+	}
+
+	markdown, err := Render(workflows, Options{WorkflowsDir: dir, Output: filepath.Join(dir, "workflows.md")})
+	if err != nil {
+		t.Fatalf("Render failed: %v", err)
+	}
+	if !strings.Contains(markdown, "| [ci.yml](ci.yml) | Runs CI. | pull_request, push |") {
+		t.Errorf("Expected a row for ci.yml, got:\n%s", markdown)
+	}
+}
+
+// TestParseWorkflow tests parsing workflow content through the public API
+func TestParseWorkflow(t *testing.T) {
+	workflow, err := ParseWorkflow([]byte("name: Deploy\non: workflow_dispatch\n"), ParseOptions{DescriptionFrom: []string{"name"}})
+	if err != nil {
+		t.Fatalf("ParseWorkflow failed: %v", err)
+	}
+	if workflow.Description != "Deploy" {
+		t.Errorf("Expected description Deploy, got %q", workflow.Description)
+	}
+}